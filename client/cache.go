@@ -0,0 +1,238 @@
+package client
+
+import (
+	"container/list"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+var (
+	ErrCacheDisabled = errors.New("permission cache is disabled")
+)
+
+// CacheOpts параметры кэша проверок доступа клиента. TTL - время жизни разрешения, NegativeTTL - время жизни
+// отказа, в том числе ответа о том, что пользователь, агент или действие не существуют. Size - максимальное
+// количество записей. Нулевой TTL или Size отключают кэш, нулевой NegativeTTL означает тот же срок, что и TTL.
+type CacheOpts struct {
+	TTL         time.Duration
+	NegativeTTL time.Duration
+	Size        int
+}
+
+// Возвращает параметры кэша проверок доступа
+func NewCacheOpts(ttl, negativeTTL time.Duration, size int) *CacheOpts {
+	return &CacheOpts{
+		TTL:         ttl,
+		NegativeTTL: negativeTTL,
+		Size:        size,
+	}
+}
+
+// CacheStats счетчики кэша проверок доступа клиента. Shared - проверки, которые дождались результата
+// одновременного такого же запроса вместо отдельного обращения к сервису.
+type CacheStats struct {
+	Size          int
+	Hits          int64
+	Misses        int64
+	Shared        int64
+	Evictions     int64
+	Invalidations int64
+}
+
+type permissionKey struct {
+	email  string
+	agent  string
+	action string
+}
+
+type permissionEntry struct {
+	key        permissionKey
+	canExecute bool
+	err        error
+	statusCode int
+	expiresAt  time.Time
+}
+
+type permissionResult struct {
+	canExecute bool
+	status     *RequestStatus
+}
+
+// permissionCache ограниченный LRU кэш результатов CanUserExecute и CanUserExecuteAction. Одновременные
+// одинаковые проверки объединяются в один запрос к сервису. Ошибки, кроме ответа 400 о несуществующем
+// пользователе, агенте или действии, не кэшируются.
+type permissionCache struct {
+	mu          sync.Mutex
+	ttl         time.Duration
+	negativeTTL time.Duration
+	capacity    int
+	entries     map[permissionKey]*list.Element
+	lru         *list.List
+	generation  uint64
+	group       singleflight.Group
+
+	hits          atomic.Int64
+	misses        atomic.Int64
+	shared        atomic.Int64
+	evictions     atomic.Int64
+	invalidations atomic.Int64
+}
+
+// newPermissionCache возвращает кэш проверок доступа или nil, если кэш отключен.
+func newPermissionCache(opts *CacheOpts) *permissionCache {
+	if opts == nil || opts.TTL <= 0 || opts.Size <= 0 {
+		return nil
+	}
+	negativeTTL := opts.NegativeTTL
+	if negativeTTL <= 0 {
+		negativeTTL = opts.TTL
+	}
+	return &permissionCache{
+		ttl:         opts.TTL,
+		negativeTTL: negativeTTL,
+		capacity:    opts.Size,
+		entries:     make(map[permissionKey]*list.Element),
+		lru:         list.New(),
+	}
+}
+
+// check возвращает результат проверки из кэша, а при промахе выполняет request. Поколение кэша входит в ключ
+// объединения запросов, поэтому после сброса новые проверки не ждут запрос, начатый до него, а результат
+// такого запроса не попадает в кэш.
+func (c *permissionCache) check(key permissionKey, request func() (bool, *RequestStatus)) (bool, *RequestStatus) {
+	c.mu.Lock()
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*permissionEntry)
+		if time.Now().Before(entry.expiresAt) {
+			c.lru.MoveToFront(el)
+			c.mu.Unlock()
+			c.hits.Add(1)
+			return entry.canExecute, newRequestStatus(entry.err, entry.statusCode)
+		}
+		c.lru.Remove(el)
+		delete(c.entries, key)
+	}
+	generation := c.generation
+	c.mu.Unlock()
+	c.misses.Add(1)
+
+	flightKey := strconv.FormatUint(generation, 10) + "\x00" + key.email + "\x00" + key.agent + "\x00" + key.action
+	leader := false
+	v, _, shared := c.group.Do(flightKey, func() (any, error) {
+		leader = true
+		canExecute, status := request()
+		c.put(key, generation, canExecute, status)
+		return &permissionResult{canExecute: canExecute, status: status}, nil
+	})
+	if shared && !leader {
+		c.shared.Add(1)
+	}
+	result := v.(*permissionResult)
+	return result.canExecute, newRequestStatus(result.status.Err, result.status.StatusCode)
+}
+
+// put сохраняет разрешение на ttl, отказ или ответ 400 - на negativeTTL, если с начала запроса кэш не сбрасывался.
+func (c *permissionCache) put(key permissionKey, generation uint64, canExecute bool, status *RequestStatus) {
+	ttl := c.negativeTTL
+	switch {
+	case status.Err == nil && canExecute:
+		ttl = c.ttl
+	case status.Err == nil, status.StatusCode == http.StatusBadRequest:
+	default:
+		return
+	}
+	entry := &permissionEntry{
+		key:        key,
+		canExecute: canExecute,
+		err:        status.Err,
+		statusCode: status.StatusCode,
+		expiresAt:  time.Now().Add(ttl),
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	if el, ok := c.entries[key]; ok {
+		el.Value = entry
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*permissionEntry).key)
+		c.evictions.Add(1)
+	}
+}
+
+// invalidate удаляет записи, для которых match возвращает true.
+func (c *permissionCache) invalidate(match func(key permissionKey) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for el := c.lru.Front(); el != nil; {
+		next := el.Next()
+		entry := el.Value.(*permissionEntry)
+		if match(entry.key) {
+			c.lru.Remove(el)
+			delete(c.entries, entry.key)
+			c.invalidations.Add(1)
+		}
+		el = next
+	}
+}
+
+func (c *permissionCache) stats() *CacheStats {
+	c.mu.Lock()
+	size := c.lru.Len()
+	c.mu.Unlock()
+	return &CacheStats{
+		Size:          size,
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Shared:        c.shared.Load(),
+		Evictions:     c.evictions.Load(),
+		Invalidations: c.invalidations.Load(),
+	}
+}
+
+// InvalidateUser сбрасывает закэшированные проверки доступа пользователя.
+func (p *PrivelegeManager) InvalidateUser(email string) {
+	if p.cache == nil {
+		return
+	}
+	p.cache.invalidate(func(key permissionKey) bool { return key.email == email })
+}
+
+// InvalidateAgent сбрасывает закэшированные проверки доступа к агенту и его действиям.
+func (p *PrivelegeManager) InvalidateAgent(agentName string) {
+	if p.cache == nil {
+		return
+	}
+	p.cache.invalidate(func(key permissionKey) bool { return key.agent == agentName })
+}
+
+// InvalidateAll сбрасывает все закэшированные проверки доступа, например при изменении группы, которое
+// затрагивает всех ее участников.
+func (p *PrivelegeManager) InvalidateAll() {
+	if p.cache == nil {
+		return
+	}
+	p.cache.invalidate(func(permissionKey) bool { return true })
+}
+
+// CacheStats возвращает счетчики кэша проверок доступа клиента или ErrCacheDisabled, если кэш не настроен.
+func (p *PrivelegeManager) CacheStats() (*CacheStats, error) {
+	if p.cache == nil {
+		return nil, ErrCacheDisabled
+	}
+	return p.cache.stats(), nil
+}
@@ -1,19 +1,23 @@
 package client
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
 const (
-	XRealIP   = "X-Real-IP"
-	UserAgent = "User-Agent"
+	XRealIP       = "X-Real-IP"
+	UserAgent     = "User-Agent"
+	Authorization = "Authorization"
 )
 
 var (
@@ -24,6 +28,10 @@ type ClientOpts struct {
 	Host   string
 	Port   int
 	UseSsl bool
+	// Cache включает кэш проверок доступа Privelege.CanUserExecute и Privelege.CanUserExecuteAction, nil отключает его
+	Cache *CacheOpts
+	// GRPC переключает проверки доступа и чтение агентов, пользователей и групп на gRPC API, nil оставляет HTTP API
+	GRPC *GRPCOpts
 }
 
 // Возвращает опции подключения к серверу
@@ -41,21 +49,47 @@ type Client struct {
 	User           UserManager
 	Group          GroupManager
 	Privelege      PrivelegeManager
+	Auth           AuthManager
+	Role           RoleManager
+	AccessRequest  AccessRequestManager
+	Invitation     InvitationManager
+	Notification   NotificationManager
+	Audit          AuditManager
+	Decision       DecisionManager
+	conn           *grpc.ClientConn
 }
 
-// NewClient создает нового клиента для соединения с микросервисом
+// NewClient создает нового клиента для соединения с микросервисом. Если соединение с gRPC API не удалось настроить,
+// клиент использует только HTTP API.
 func NewClient(opts *ClientOpts) *Client {
 	schema := "http"
 	if opts.UseSsl {
 		schema = "https"
 	}
 	connectionLine := fmt.Sprintf("%s://%s:%d", schema, opts.Host, opts.Port)
+	var conn *grpc.ClientConn
+	var rpc *rpcClient
+	if opts.GRPC != nil {
+		var err error
+		conn, rpc, err = newRPCClient(opts)
+		if err != nil {
+			zap.Must(zap.NewProduction()).Warn(fmt.Sprintf("grpc api of microservice 'privelege' is disabled: %v", err))
+		}
+	}
 	return &Client{
 		ConnectionLine: connectionLine,
-		Agent:          AgentManager{ConnectionLine: connectionLine},
-		User:           UserManager{ConnectionLine: connectionLine},
-		Group:          GroupManager{ConnectionLine: connectionLine},
-		Privelege:      PrivelegeManager{ConnectionLine: connectionLine},
+		conn:           conn,
+		Agent:          AgentManager{ConnectionLine: connectionLine, rpc: rpc},
+		User:           UserManager{ConnectionLine: connectionLine, rpc: rpc},
+		Group:          GroupManager{ConnectionLine: connectionLine, rpc: rpc},
+		Privelege:      PrivelegeManager{ConnectionLine: connectionLine, rpc: rpc, cache: newPermissionCache(opts.Cache)},
+		Auth:           AuthManager{ConnectionLine: connectionLine},
+		Role:           RoleManager{ConnectionLine: connectionLine},
+		AccessRequest:  AccessRequestManager{ConnectionLine: connectionLine},
+		Invitation:     InvitationManager{ConnectionLine: connectionLine},
+		Notification:   NotificationManager{ConnectionLine: connectionLine},
+		Audit:          AuditManager{ConnectionLine: connectionLine},
+		Decision:       DecisionManager{ConnectionLine: connectionLine},
 	}
 }
 
@@ -76,6 +110,14 @@ func (c *Client) CheckConnection() {
 	}
 }
 
+// Close закрывает соединение с gRPC API, если оно было открыто.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
 // Ping проверяет, отвечает ли сервер. В случае успеха должен вернуть статус 200;
 func (c *Client) Ping() error {
 	urlRequest := fmt.Sprintf("%s/api/v1/ping", c.ConnectionLine)
@@ -92,12 +134,12 @@ func (c *Client) Ping() error {
 // //////// AGENT //////////
 type AgentManager struct {
 	ConnectionLine string
+	rpc            *rpcClient
 }
 
 // Create создает агента
-func (a *AgentManager) Create(agentName, emailCreate string, meta *RequestMeta) (*Agent, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/agents/%s/who_creates/%s",
-		a.ConnectionLine, agentName, emailCreate)
+func (a *AgentManager) Create(agentName string, meta *RequestMeta) (*Agent, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/agents/%s", a.ConnectionLine, agentName)
 	req, err := http.NewRequest("POST", urlRequest, nil)
 	if err != nil {
 		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
@@ -105,6 +147,51 @@ func (a *AgentManager) Create(agentName, emailCreate string, meta *RequestMeta)
 
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp Agent
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// CreateWithActions создает агента с действиями, body содержит JSON вида {"actions": ["read", "write"]}
+func (a *AgentManager) CreateWithActions(agentName string, body io.ReadCloser, meta *RequestMeta) (*Agent, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/agents/%s", a.ConnectionLine, agentName)
+	req, err := http.NewRequest("POST", urlRequest, body)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
@@ -122,7 +209,7 @@ func (a *AgentManager) Create(agentName, emailCreate string, meta *RequestMeta)
 		}
 		return &resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusForbidden, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
@@ -135,9 +222,8 @@ func (a *AgentManager) Create(agentName, emailCreate string, meta *RequestMeta)
 }
 
 // Delete удаляет агента
-func (a *AgentManager) Delete(agentName, emailDelete string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/agents/%s/who_deletes/%s",
-		a.ConnectionLine, agentName, emailDelete)
+func (a *AgentManager) Delete(agentName string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/agents/%s", a.ConnectionLine, agentName)
 	req, err := http.NewRequest("DELETE", urlRequest, nil)
 	if err != nil {
 		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
@@ -145,6 +231,9 @@ func (a *AgentManager) Delete(agentName, emailDelete string, meta *RequestMeta)
 
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
@@ -162,7 +251,7 @@ func (a *AgentManager) Delete(agentName, emailDelete string, meta *RequestMeta)
 		}
 		return &resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusForbidden, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
@@ -176,14 +265,20 @@ func (a *AgentManager) Delete(agentName, emailDelete string, meta *RequestMeta)
 }
 
 // GetAll возвращает всех агентов в системе
-func (a *AgentManager) GetAll(emailRead string, meta *RequestMeta) ([]Agent, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/agents/who_reads/%s", a.ConnectionLine, emailRead)
+func (a *AgentManager) GetAll(meta *RequestMeta) ([]Agent, *RequestStatus) {
+	if a.rpc != nil {
+		return a.rpcGetAll(meta)
+	}
+	urlRequest := fmt.Sprintf("%s/api/v1/agents", a.ConnectionLine)
 	req, err := http.NewRequest("GET", urlRequest, nil)
 	if err != nil {
 		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
@@ -201,7 +296,7 @@ func (a *AgentManager) GetAll(emailRead string, meta *RequestMeta) ([]Agent, *Re
 		}
 		return resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusForbidden, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
@@ -217,18 +312,66 @@ func (a *AgentManager) GetAll(emailRead string, meta *RequestMeta) ([]Agent, *Re
 // //////// GROUP //////////
 type GroupManager struct {
 	ConnectionLine string
+	rpc            *rpcClient
 }
 
 // AddUserToGroup добавляет пользователя в группу
-func (g *GroupManager) AddUserToGroup(groupName, email, emailInvite string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/add_user/%s/who_invites/%s",
-		g.ConnectionLine, groupName, email, emailInvite)
+func (g *GroupManager) AddUserToGroup(groupName, email string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/add_user/%s",
+		g.ConnectionLine, groupName, email)
 	req, err := http.NewRequest("POST", urlRequest, nil)
 	if err != nil {
 		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// AddUserToGroupWithPeriod добавляет пользователя в группу на срок, заданный в body.
+// body содержит JSON вида {"valid_from": "2024-09-01T10:00:00Z", "valid_until": "2024-09-01T14:00:00Z"}, оба поля необязательны
+func (g *GroupManager) AddUserToGroupWithPeriod(groupName, email string, body io.ReadCloser, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/add_user/%s",
+		g.ConnectionLine, groupName, email)
+	req, err := http.NewRequest("POST", urlRequest, body)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
@@ -246,7 +389,7 @@ func (g *GroupManager) AddUserToGroup(groupName, email, emailInvite string, meta
 		}
 		return &resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
@@ -260,14 +403,20 @@ func (g *GroupManager) AddUserToGroup(groupName, email, emailInvite string, meta
 }
 
 // UserList возвращает группы пользователя
-func (g *GroupManager) UserList(email, emailAsk string, meta *RequestMeta) ([]Group, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/groups/who_asks/%s", g.ConnectionLine, email, emailAsk)
+func (g *GroupManager) UserList(email string, meta *RequestMeta) ([]Group, *RequestStatus) {
+	if g.rpc != nil {
+		return g.rpcUserList(email, meta)
+	}
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/groups", g.ConnectionLine, email)
 	req, err := http.NewRequest("GET", urlRequest, nil)
 	if err != nil {
 		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
@@ -285,7 +434,7 @@ func (g *GroupManager) UserList(email, emailAsk string, meta *RequestMeta) ([]Gr
 		}
 		return resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
@@ -299,15 +448,60 @@ func (g *GroupManager) UserList(email, emailAsk string, meta *RequestMeta) ([]Gr
 }
 
 // KickOutUser удаляет пользователя из группы
-func (g *GroupManager) KickOutUser(groupName, email, emailKick string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/kick_user/%s/who_kicks/%s",
-		g.ConnectionLine, groupName, email, emailKick)
+func (g *GroupManager) KickOutUser(groupName, email string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/kick_user/%s",
+		g.ConnectionLine, groupName, email)
+	req, err := http.NewRequest("POST", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// LeaveGroup выводит аутентифицированного пользователя из группы
+func (g *GroupManager) LeaveGroup(groupName string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/leave", g.ConnectionLine, groupName)
 	req, err := http.NewRequest("POST", urlRequest, nil)
 	if err != nil {
 		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
@@ -325,7 +519,7 @@ func (g *GroupManager) KickOutUser(groupName, email, emailKick string, meta *Req
 		}
 		return &resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
@@ -339,15 +533,17 @@ func (g *GroupManager) KickOutUser(groupName, email, emailKick string, meta *Req
 }
 
 // MakeBidToCreateGroup создает заявку на создание группы
-func (g *GroupManager) MakeBidToCreateGroup(groupName, email string, meta *RequestMeta) (*Bid, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/who_adds/%s",
-		g.ConnectionLine, groupName, email)
+func (g *GroupManager) MakeBidToCreateGroup(groupName string, meta *RequestMeta) (*Bid, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s", g.ConnectionLine, groupName)
 	req, err := http.NewRequest("POST", urlRequest, nil)
 	if err != nil {
 		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
@@ -365,7 +561,7 @@ func (g *GroupManager) MakeBidToCreateGroup(groupName, email string, meta *Reque
 		}
 		return &resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
@@ -379,15 +575,18 @@ func (g *GroupManager) MakeBidToCreateGroup(groupName, email string, meta *Reque
 }
 
 // ChangeBidStatus меняет статус заявки на создание группы
-func (g *GroupManager) ChangeBidStatus(groupName, email, emailChangeStatus, newStatus string, meta *RequestMeta) (*Bid, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/groups/%s/who_change_status/%s?status=%s",
-		g.ConnectionLine, email, groupName, emailChangeStatus, newStatus)
+func (g *GroupManager) ChangeBidStatus(groupName, email, newStatus string, meta *RequestMeta) (*Bid, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/groups/%s?status=%s",
+		g.ConnectionLine, email, groupName, newStatus)
 	req, err := http.NewRequest("PUT", urlRequest, nil)
 	if err != nil {
 		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
@@ -405,7 +604,7 @@ func (g *GroupManager) ChangeBidStatus(groupName, email, emailChangeStatus, newS
 		}
 		return &resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
@@ -418,16 +617,20 @@ func (g *GroupManager) ChangeBidStatus(groupName, email, emailChangeStatus, newS
 	}
 }
 
-// ChangeOwner изменяет ответственного в группе
-func (g *GroupManager) ChangeOwner(groupName, email, emailWhoChange string, meta *RequestMeta) (*Group, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/users/%s/who_change_owner/%s",
-		g.ConnectionLine, groupName, email, emailWhoChange)
-	req, err := http.NewRequest("PUT", urlRequest, nil)
+// ChangeBidStatusWithComment меняет статус заявки на создание группы с комментарием рассмотревшего.
+// body содержит JSON вида {"comment": "группа нужна для дежурств"}
+func (g *GroupManager) ChangeBidStatusWithComment(groupName, email, newStatus string, body io.ReadCloser, meta *RequestMeta) (*Bid, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/groups/%s?status=%s",
+		g.ConnectionLine, email, groupName, newStatus)
+	req, err := http.NewRequest("PUT", urlRequest, body)
 	if err != nil {
 		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
@@ -438,14 +641,14 @@ func (g *GroupManager) ChangeOwner(groupName, email, emailWhoChange string, meta
 
 	switch respRequest.StatusCode {
 	case http.StatusOK:
-		var resp Group
+		var resp Bid
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
 			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 		}
 		return &resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
@@ -458,20 +661,18 @@ func (g *GroupManager) ChangeOwner(groupName, email, emailWhoChange string, meta
 	}
 }
 
-// //////// USER //////////
-type UserManager struct {
-	ConnectionLine string
-}
-
-// Create создает пользователя
-func (u *UserManager) Create(body io.ReadCloser, meta *RequestMeta) (*UserWithoutPassword, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/users", u.ConnectionLine)
-	req, err := http.NewRequest("POST", urlRequest, body)
+// Bids возвращает страницу заявок на создание группы. filter может содержать status, email, group, limit и offset
+func (g *GroupManager) Bids(filter url.Values, meta *RequestMeta) ([]Bid, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/bids?%s", g.ConnectionLine, filter.Encode())
+	req, err := http.NewRequest("GET", urlRequest, nil)
 	if err != nil {
 		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
@@ -482,14 +683,14 @@ func (u *UserManager) Create(body io.ReadCloser, meta *RequestMeta) (*UserWithou
 
 	switch respRequest.StatusCode {
 	case http.StatusOK:
-		var resp UserWithoutPassword
+		var resp []Bid
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
 			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 		}
-		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+		return resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
@@ -502,15 +703,18 @@ func (u *UserManager) Create(body io.ReadCloser, meta *RequestMeta) (*UserWithou
 	}
 }
 
-// Get возвращает пользователя
-func (u *UserManager) Get(email string, meta *RequestMeta) (*UserWithoutPassword, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/users/%s", u.ConnectionLine, email)
+// GetBid возвращает заявку на создание группы вместе с историей ее статусов
+func (g *GroupManager) GetBid(bidID int, meta *RequestMeta) (*Bid, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/bids/%d", g.ConnectionLine, bidID)
 	req, err := http.NewRequest("GET", urlRequest, nil)
 	if err != nil {
 		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
@@ -521,14 +725,14 @@ func (u *UserManager) Get(email string, meta *RequestMeta) (*UserWithoutPassword
 
 	switch respRequest.StatusCode {
 	case http.StatusOK:
-		var resp UserWithoutPassword
+		var resp Bid
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
 			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 		}
 		return &resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
@@ -541,15 +745,18 @@ func (u *UserManager) Get(email string, meta *RequestMeta) (*UserWithoutPassword
 	}
 }
 
-// Delete удаляет пользователя
-func (a *UserManager) Delete(email, emailDelete string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/who_deletes/%s", a.ConnectionLine, email, emailDelete)
-	req, err := http.NewRequest("DELETE", urlRequest, nil)
+// CancelBid отзывает заявку на создание группы. body необязателен и содержит JSON вида {"comment": "..."}
+func (g *GroupManager) CancelBid(bidID int, body io.ReadCloser, meta *RequestMeta) (*Bid, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/bids/%d/cancel", g.ConnectionLine, bidID)
+	req, err := http.NewRequest("POST", urlRequest, body)
 	if err != nil {
 		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
@@ -560,14 +767,14 @@ func (a *UserManager) Delete(email, emailDelete string, meta *RequestMeta) (*Res
 
 	switch respRequest.StatusCode {
 	case http.StatusOK:
-		var resp ResponseDetail
+		var resp Bid
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
 			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 		}
 		return &resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusForbidden, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
@@ -580,21 +787,19 @@ func (a *UserManager) Delete(email, emailDelete string, meta *RequestMeta) (*Res
 	}
 }
 
-// //////// PRIVELEGE //////////
-type PrivelegeManager struct {
-	ConnectionLine string
-}
-
-// AddAgentToGroup создает связь между агентом и группой
-func (p *PrivelegeManager) AddAgentToGroup(groupName, agentName, emailAdd string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/priveleges/new/agents/%s/who_adds/%s",
-		p.ConnectionLine, groupName, agentName, emailAdd)
+// AttachSubgroup делает группу subgroupName подгруппой groupName
+func (g *GroupManager) AttachSubgroup(groupName, subgroupName string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/add_subgroup/%s",
+		g.ConnectionLine, groupName, subgroupName)
 	req, err := http.NewRequest("POST", urlRequest, nil)
 	if err != nil {
 		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
@@ -612,7 +817,7 @@ func (p *PrivelegeManager) AddAgentToGroup(groupName, agentName, emailAdd string
 		}
 		return &resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusForbidden, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
@@ -625,16 +830,19 @@ func (p *PrivelegeManager) AddAgentToGroup(groupName, agentName, emailAdd string
 	}
 }
 
-// DeleteAgentFromGroup разрывает связь между агентом и группой
-func (p *PrivelegeManager) DeleteAgentFromGroup(groupName, agentName, emailDelete string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/priveleges/delete/agents/%s/who_deletes/%s",
-		p.ConnectionLine, groupName, agentName, emailDelete)
-	req, err := http.NewRequest("DELETE", urlRequest, nil)
+// DetachSubgroup отвязывает подгруппу subgroupName от группы groupName
+func (g *GroupManager) DetachSubgroup(groupName, subgroupName string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/remove_subgroup/%s",
+		g.ConnectionLine, groupName, subgroupName)
+	req, err := http.NewRequest("POST", urlRequest, nil)
 	if err != nil {
 		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
@@ -652,7 +860,7 @@ func (p *PrivelegeManager) DeleteAgentFromGroup(groupName, agentName, emailDelet
 		}
 		return &resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusForbidden, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
@@ -665,17 +873,18 @@ func (p *PrivelegeManager) DeleteAgentFromGroup(groupName, agentName, emailDelet
 	}
 }
 
-// GetGroupAgents возвращает список агентов какойлибо группы
-func (p *PrivelegeManager) GetGroupAgents(groupName, emailAsk string, meta *RequestMeta) ([]Agent, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/priveleges/who_asks/%s",
-		p.ConnectionLine, groupName, emailAsk)
-
+// Subgroups возвращает подгруппы первого уровня
+func (g *GroupManager) Subgroups(groupName string, meta *RequestMeta) ([]Group, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/subgroups", g.ConnectionLine, groupName)
 	req, err := http.NewRequest("GET", urlRequest, nil)
 	if err != nil {
 		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
@@ -686,14 +895,14 @@ func (p *PrivelegeManager) GetGroupAgents(groupName, emailAsk string, meta *Requ
 
 	switch respRequest.StatusCode {
 	case http.StatusOK:
-		var resp []Agent
+		var resp []Group
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
 			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 		}
 		return resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusForbidden, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
@@ -706,16 +915,62 @@ func (p *PrivelegeManager) GetGroupAgents(groupName, emailAsk string, meta *Requ
 	}
 }
 
-// AddAgentToUser создает связь между агентом и пользователем
-func (p *PrivelegeManager) AddAgentToUser(email, agentName, emailAdd string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/priveleges/new/agents/%s/who_adds/%s",
-		p.ConnectionLine, email, agentName, emailAdd)
+// ChangeOwner изменяет ответственного в группе
+func (g *GroupManager) ChangeOwner(groupName, email string, meta *RequestMeta) (*Group, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/change_owner/%s",
+		g.ConnectionLine, groupName, email)
+	req, err := http.NewRequest("PUT", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp Group
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// AddOwner делает пользователя совладельцем группы
+func (g *GroupManager) AddOwner(groupName, email string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/add_owner/%s",
+		g.ConnectionLine, groupName, email)
 	req, err := http.NewRequest("POST", urlRequest, nil)
 	if err != nil {
 		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
@@ -733,7 +988,7 @@ func (p *PrivelegeManager) AddAgentToUser(email, agentName, emailAdd string, met
 		}
 		return &resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusForbidden, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
@@ -746,16 +1001,19 @@ func (p *PrivelegeManager) AddAgentToUser(email, agentName, emailAdd string, met
 	}
 }
 
-// DeleteAgentFromUser разрывает связь между агентом и пользователем
-func (p *PrivelegeManager) DeleteAgentFromUser(email, agentName, emailDelete string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/priveleges/delete/agents/%s/who_deletes/%s",
-		p.ConnectionLine, email, agentName, emailDelete)
-	req, err := http.NewRequest("DELETE", urlRequest, nil)
+// RemoveOwner снимает с пользователя ответственность за группу
+func (g *GroupManager) RemoveOwner(groupName, email string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/remove_owner/%s",
+		g.ConnectionLine, groupName, email)
+	req, err := http.NewRequest("POST", urlRequest, nil)
 	if err != nil {
 		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
@@ -773,7 +1031,7 @@ func (p *PrivelegeManager) DeleteAgentFromUser(email, agentName, emailDelete str
 		}
 		return &resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusForbidden, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
@@ -786,16 +1044,18 @@ func (p *PrivelegeManager) DeleteAgentFromUser(email, agentName, emailDelete str
 	}
 }
 
-// GetGroupAgents возвращает список агентов какойлибо группы
-func (p *PrivelegeManager) GetUserAgents(email, emailAsk string, meta *RequestMeta) ([]Agent, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/priveleges/who_asks/%s",
-		p.ConnectionLine, email, emailAsk)
+// Members возвращает страницу участников группы, page содержит необязательные limit и offset
+func (g *GroupManager) Members(groupName string, page url.Values, meta *RequestMeta) ([]GroupMember, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/members?%s", g.ConnectionLine, groupName, page.Encode())
 	req, err := http.NewRequest("GET", urlRequest, nil)
 	if err != nil {
 		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
@@ -806,14 +1066,14 @@ func (p *PrivelegeManager) GetUserAgents(email, emailAsk string, meta *RequestMe
 
 	switch respRequest.StatusCode {
 	case http.StatusOK:
-		var resp []Agent
+		var resp []GroupMember
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
 			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 		}
 		return resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusForbidden, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
@@ -826,42 +1086,2901 @@ func (p *PrivelegeManager) GetUserAgents(email, emailAsk string, meta *RequestMe
 	}
 }
 
-// CanUserExecute проверяет, может ли пользователь выполнить процесс на выбранном агенте
-func (p *PrivelegeManager) CanUserExecute(email, agentName string, meta *RequestMeta) (bool, *RequestStatus) {
-	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/check_access/agents/%s",
-		p.ConnectionLine, email, agentName)
-	req, err := http.NewRequest("GET", urlRequest, nil)
+// Rename меняет название группы
+func (g *GroupManager) Rename(groupName, newGroupName string, meta *RequestMeta) (*Group, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/rename/%s",
+		g.ConnectionLine, groupName, newGroupName)
+	req, err := http.NewRequest("PUT", urlRequest, nil)
 	if err != nil {
-		return false, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 	req.Header.Set(XRealIP, meta.RealIp)
 	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
 
 	client := &http.Client{}
 	respRequest, err := client.Do(req)
 	if err != nil {
-		return false, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 	defer respRequest.Body.Close()
 
 	switch respRequest.StatusCode {
 	case http.StatusOK:
-		var data map[string]bool
-		err = json.NewDecoder(respRequest.Body).Decode(&data)
+		var resp Group
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
-			return false, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 		}
-		return data["can_execute"], newRequestStatus(nil, respRequest.StatusCode)
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
 
-	case http.StatusBadRequest, http.StatusInternalServerError:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
 		var resp ResponseError
 		err = json.NewDecoder(respRequest.Body).Decode(&resp)
 		if err != nil {
-			return false, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 		}
-		return false, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
 
 	default:
-		return false, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// Delete удаляет группу вместе с ее привелегиями
+func (g *GroupManager) Delete(groupName string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s", g.ConnectionLine, groupName)
+	req, err := http.NewRequest("DELETE", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// //////// USER //////////
+type UserManager struct {
+	ConnectionLine string
+	rpc            *rpcClient
+}
+
+// Create создает пользователя
+func (u *UserManager) Create(body io.ReadCloser, meta *RequestMeta) (*UserWithoutPassword, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/users", u.ConnectionLine)
+	req, err := http.NewRequest("POST", urlRequest, body)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp UserWithoutPassword
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// Get возвращает пользователя
+func (u *UserManager) Get(email string, meta *RequestMeta) (*UserWithoutPassword, *RequestStatus) {
+	if u.rpc != nil {
+		return u.rpcGet(email, meta)
+	}
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s", u.ConnectionLine, email)
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp UserWithoutPassword
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// Delete удаляет пользователя
+func (a *UserManager) Delete(email string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s", a.ConnectionLine, email)
+	req, err := http.NewRequest("DELETE", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// //////// PRIVELEGE //////////
+type PrivelegeManager struct {
+	ConnectionLine string
+	rpc            *rpcClient
+	cache          *permissionCache
+}
+
+// AddAgentToGroup создает связь между агентом и группой
+func (p *PrivelegeManager) AddAgentToGroup(groupName, agentName string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/priveleges/new/agents/%s",
+		p.ConnectionLine, groupName, agentName)
+	req, err := http.NewRequest("POST", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// AddAgentToGroupWithPeriod выдает группе доступ к агенту на срок, заданный в body.
+// body содержит JSON вида {"valid_from": "2024-09-01T10:00:00Z", "valid_until": "2024-09-01T14:00:00Z"}, оба поля необязательны
+func (p *PrivelegeManager) AddAgentToGroupWithPeriod(groupName, agentName string, body io.ReadCloser, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/priveleges/new/agents/%s",
+		p.ConnectionLine, groupName, agentName)
+	req, err := http.NewRequest("POST", urlRequest, body)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// AddAgentActionToGroup выдает группе доступ к действию агента
+func (p *PrivelegeManager) AddAgentActionToGroup(groupName, agentName, action string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/priveleges/new/agents/%s/actions/%s",
+		p.ConnectionLine, groupName, agentName, action)
+	req, err := http.NewRequest("POST", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// AddAgentActionToGroupWithPeriod выдает группе доступ к действию агента на срок, заданный в body.
+// body содержит JSON вида {"valid_from": "2024-09-01T10:00:00Z", "valid_until": "2024-09-01T14:00:00Z"}, оба поля необязательны
+func (p *PrivelegeManager) AddAgentActionToGroupWithPeriod(groupName, agentName, action string, body io.ReadCloser, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/priveleges/new/agents/%s/actions/%s",
+		p.ConnectionLine, groupName, agentName, action)
+	req, err := http.NewRequest("POST", urlRequest, body)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// DeleteAgentFromGroup разрывает связь между агентом и группой
+func (p *PrivelegeManager) DeleteAgentFromGroup(groupName, agentName string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/priveleges/delete/agents/%s",
+		p.ConnectionLine, groupName, agentName)
+	req, err := http.NewRequest("DELETE", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// DeleteAgentActionFromGroup отзывает у группы доступ к действию агента
+func (p *PrivelegeManager) DeleteAgentActionFromGroup(groupName, agentName, action string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/priveleges/delete/agents/%s/actions/%s",
+		p.ConnectionLine, groupName, agentName, action)
+	req, err := http.NewRequest("DELETE", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// GetGroupAgents возвращает список агентов какойлибо группы
+func (p *PrivelegeManager) GetGroupAgents(groupName string, meta *RequestMeta) ([]Agent, *RequestStatus) {
+	if p.rpc != nil {
+		return p.rpcGetGroupAgents(groupName, meta)
+	}
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/priveleges",
+		p.ConnectionLine, groupName)
+
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp []Agent
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// AddAgentToUser создает связь между агентом и пользователем
+func (p *PrivelegeManager) AddAgentToUser(email, agentName string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/priveleges/new/agents/%s",
+		p.ConnectionLine, email, agentName)
+	req, err := http.NewRequest("POST", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// AddAgentToUserWithPeriod выдает пользователю доступ к агенту на срок, заданный в body.
+// body содержит JSON вида {"valid_from": "2024-09-01T10:00:00Z", "valid_until": "2024-09-01T14:00:00Z"}, оба поля необязательны
+func (p *PrivelegeManager) AddAgentToUserWithPeriod(email, agentName string, body io.ReadCloser, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/priveleges/new/agents/%s",
+		p.ConnectionLine, email, agentName)
+	req, err := http.NewRequest("POST", urlRequest, body)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// AddAgentActionToUser выдает пользователю доступ к действию агента
+func (p *PrivelegeManager) AddAgentActionToUser(email, agentName, action string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/priveleges/new/agents/%s/actions/%s",
+		p.ConnectionLine, email, agentName, action)
+	req, err := http.NewRequest("POST", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// AddAgentActionToUserWithPeriod выдает пользователю доступ к действию агента на срок, заданный в body.
+// body содержит JSON вида {"valid_from": "2024-09-01T10:00:00Z", "valid_until": "2024-09-01T14:00:00Z"}, оба поля необязательны
+func (p *PrivelegeManager) AddAgentActionToUserWithPeriod(email, agentName, action string, body io.ReadCloser, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/priveleges/new/agents/%s/actions/%s",
+		p.ConnectionLine, email, agentName, action)
+	req, err := http.NewRequest("POST", urlRequest, body)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// DeleteAgentFromUser разрывает связь между агентом и пользователем
+func (p *PrivelegeManager) DeleteAgentFromUser(email, agentName string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/priveleges/delete/agents/%s",
+		p.ConnectionLine, email, agentName)
+	req, err := http.NewRequest("DELETE", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// DeleteAgentActionFromUser отзывает у пользователя доступ к действию агента
+func (p *PrivelegeManager) DeleteAgentActionFromUser(email, agentName, action string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/priveleges/delete/agents/%s/actions/%s",
+		p.ConnectionLine, email, agentName, action)
+	req, err := http.NewRequest("DELETE", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// GetGroupAgents возвращает список агентов какойлибо группы
+func (p *PrivelegeManager) GetUserAgents(email string, meta *RequestMeta) ([]Agent, *RequestStatus) {
+	if p.rpc != nil {
+		return p.rpcGetUserAgents(email, meta)
+	}
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/priveleges",
+		p.ConnectionLine, email)
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp []Agent
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// CanUserExecute проверяет, может ли пользователь выполнить процесс на выбранном агенте. Если у клиента включен кэш,
+// результат берется из него, а одновременные одинаковые проверки выполняются одним запросом.
+func (p *PrivelegeManager) CanUserExecute(email, agentName string, meta *RequestMeta) (bool, *RequestStatus) {
+	if p.cache == nil {
+		return p.canUserExecute(email, agentName, meta)
+	}
+	return p.cache.check(permissionKey{email: email, agent: agentName}, func() (bool, *RequestStatus) {
+		return p.canUserExecute(email, agentName, meta)
+	})
+}
+
+func (p *PrivelegeManager) canUserExecute(email, agentName string, meta *RequestMeta) (bool, *RequestStatus) {
+	if p.rpc != nil {
+		return p.rpcCanUserExecute(email, agentName, "", meta)
+	}
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/check_access/agents/%s",
+		p.ConnectionLine, email, agentName)
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return false, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return false, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var data map[string]bool
+		err = json.NewDecoder(respRequest.Body).Decode(&data)
+		if err != nil {
+			return false, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return data["can_execute"], newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return false, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return false, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return false, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// CanUserExecuteAction проверяет, может ли пользователь выполнить действие агента. Если у клиента включен кэш,
+// результат берется из него, а одновременные одинаковые проверки выполняются одним запросом.
+func (p *PrivelegeManager) CanUserExecuteAction(email, agentName, action string, meta *RequestMeta) (bool, *RequestStatus) {
+	if p.cache == nil {
+		return p.canUserExecuteAction(email, agentName, action, meta)
+	}
+	return p.cache.check(permissionKey{email: email, agent: agentName, action: action}, func() (bool, *RequestStatus) {
+		return p.canUserExecuteAction(email, agentName, action, meta)
+	})
+}
+
+func (p *PrivelegeManager) canUserExecuteAction(email, agentName, action string, meta *RequestMeta) (bool, *RequestStatus) {
+	if p.rpc != nil {
+		return p.rpcCanUserExecute(email, agentName, action, meta)
+	}
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/check_access/agents/%s/actions/%s",
+		p.ConnectionLine, email, agentName, action)
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return false, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return false, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var data map[string]bool
+		err = json.NewDecoder(respRequest.Body).Decode(&data)
+		if err != nil {
+			return false, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return data["can_execute"], newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return false, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return false, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return false, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// CanUserExecuteBatch проверяет доступ для набора пар пользователь - агент или действие агента одним запросом
+// (не больше 100 проверок). Результаты возвращаются в порядке проверок и не используют кэш клиента.
+func (p *PrivelegeManager) CanUserExecuteBatch(checks []AccessCheckItem, meta *RequestMeta) ([]*AccessCheckResult, *RequestStatus) {
+	if p.rpc != nil {
+		return p.rpcCanUserExecuteBatch(checks, meta)
+	}
+	urlRequest := fmt.Sprintf("%s/api/v1/priveleges/check_access", p.ConnectionLine)
+	body, err := json.Marshal(map[string][]AccessCheckItem{"checks": checks})
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req, err := http.NewRequest("POST", urlRequest, bytes.NewReader(body))
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp []*AccessCheckResult
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// GetAgentAccessors возвращает страницу пользователей с действующим доступом к агенту или к его действиям,
+// page содержит limit и offset. Требует права privilege.read_accessors
+func (p *PrivelegeManager) GetAgentAccessors(agentName string, page url.Values, meta *RequestMeta) ([]AgentAccessor, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/agents/%s/priveleges?%s", p.ConnectionLine, agentName, page.Encode())
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp []AgentAccessor
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// ExportAgentAccessors выгружает в формате csv всех пользователей с действующим доступом к агенту или к его действиям.
+// Требует права privilege.read_accessors
+func (p *PrivelegeManager) ExportAgentAccessors(agentName string, meta *RequestMeta) (*AgentAccessorsExport, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/agents/%s/priveleges/export", p.ConnectionLine, agentName)
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		data, err := io.ReadAll(respRequest.Body)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &AgentAccessorsExport{
+			ContentType:        respRequest.Header.Get("Content-Type"),
+			ContentDisposition: respRequest.Header.Get("Content-Disposition"),
+			Data:               data,
+		}, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// ExplainAccess возвращает вывод решения о доступе пользователя к агенту. Пустое действие означает агента целиком.
+// Объяснение доступно самому пользователю или пользователю с правом privilege.read.
+func (p *PrivelegeManager) ExplainAccess(email, agentName, action string, meta *RequestMeta) (*AccessExplanation, *RequestStatus) {
+	if p.rpc != nil {
+		return p.rpcExplainAccess(email, agentName, action, meta)
+	}
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/check_access/agents/%s/explain", p.ConnectionLine, email, agentName)
+	if action != "" {
+		urlRequest = fmt.Sprintf("%s/api/v1/users/%s/check_access/agents/%s/actions/%s/explain",
+			p.ConnectionLine, email, agentName, action)
+	}
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp AccessExplanation
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// GetAccessCacheStats возвращает счетчики кэша решений о доступе. Требуется право privilege.read.
+func (p *PrivelegeManager) GetAccessCacheStats(meta *RequestMeta) (*AccessCacheStats, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/priveleges/cache/stats", p.ConnectionLine)
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp AccessCacheStats
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// AddDenyToGroup запрещает группе доступ к агенту
+func (p *PrivelegeManager) AddDenyToGroup(groupName, agentName string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/priveleges/deny/agents/%s",
+		p.ConnectionLine, groupName, agentName)
+	req, err := http.NewRequest("POST", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// DeleteDenyFromGroup снимает с группы запрет доступа к агенту
+func (p *PrivelegeManager) DeleteDenyFromGroup(groupName, agentName string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/priveleges/deny/agents/%s",
+		p.ConnectionLine, groupName, agentName)
+	req, err := http.NewRequest("DELETE", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// AddActionDenyToGroup запрещает группе действие агента
+func (p *PrivelegeManager) AddActionDenyToGroup(groupName, agentName, action string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/priveleges/deny/agents/%s/actions/%s",
+		p.ConnectionLine, groupName, agentName, action)
+	req, err := http.NewRequest("POST", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// DeleteActionDenyFromGroup снимает с группы запрет действия агента
+func (p *PrivelegeManager) DeleteActionDenyFromGroup(groupName, agentName, action string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/priveleges/deny/agents/%s/actions/%s",
+		p.ConnectionLine, groupName, agentName, action)
+	req, err := http.NewRequest("DELETE", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// AddDenyToUser запрещает пользователю доступ к агенту
+func (p *PrivelegeManager) AddDenyToUser(email, agentName string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/priveleges/deny/agents/%s",
+		p.ConnectionLine, email, agentName)
+	req, err := http.NewRequest("POST", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// DeleteDenyFromUser снимает с пользователя запрет доступа к агенту
+func (p *PrivelegeManager) DeleteDenyFromUser(email, agentName string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/priveleges/deny/agents/%s",
+		p.ConnectionLine, email, agentName)
+	req, err := http.NewRequest("DELETE", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// AddActionDenyToUser запрещает пользователю действие агента
+func (p *PrivelegeManager) AddActionDenyToUser(email, agentName, action string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/priveleges/deny/agents/%s/actions/%s",
+		p.ConnectionLine, email, agentName, action)
+	req, err := http.NewRequest("POST", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// DeleteActionDenyFromUser снимает с пользователя запрет действия агента
+func (p *PrivelegeManager) DeleteActionDenyFromUser(email, agentName, action string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/priveleges/deny/agents/%s/actions/%s",
+		p.ConnectionLine, email, agentName, action)
+	req, err := http.NewRequest("DELETE", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// //////// ROLE //////////
+type RoleManager struct {
+	ConnectionLine string
+}
+
+// GetPermissions возвращает список прав, которые можно включить в роль
+func (rm *RoleManager) GetPermissions(meta *RequestMeta) ([]Permission, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/permissions", rm.ConnectionLine)
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp []Permission
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// GetAll возвращает список ролей вместе с их правами
+func (rm *RoleManager) GetAll(meta *RequestMeta) ([]Role, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/roles", rm.ConnectionLine)
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp []Role
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// Create создает пользовательскую роль
+func (rm *RoleManager) Create(body io.ReadCloser, meta *RequestMeta) (*Role, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/roles", rm.ConnectionLine)
+	req, err := http.NewRequest("POST", urlRequest, body)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp Role
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// Delete удаляет пользовательскую роль
+func (rm *RoleManager) Delete(roleName string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/roles/%s", rm.ConnectionLine, roleName)
+	req, err := http.NewRequest("DELETE", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// SetPermissions заменяет набор прав пользовательской роли
+func (rm *RoleManager) SetPermissions(roleName string, body io.ReadCloser, meta *RequestMeta) (*Role, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/roles/%s/permissions", rm.ConnectionLine, roleName)
+	req, err := http.NewRequest("PUT", urlRequest, body)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp Role
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// AssignToUser назначает роль пользователю
+func (rm *RoleManager) AssignToUser(roleName, email string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/roles/%s/users/%s", rm.ConnectionLine, roleName, email)
+	req, err := http.NewRequest("POST", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// RevokeFromUser отзывает роль у пользователя
+func (rm *RoleManager) RevokeFromUser(roleName, email string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/roles/%s/users/%s", rm.ConnectionLine, roleName, email)
+	req, err := http.NewRequest("DELETE", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// AssignToGroup назначает роль группе
+func (rm *RoleManager) AssignToGroup(roleName, groupName string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/roles/%s/groups/%s", rm.ConnectionLine, roleName, groupName)
+	req, err := http.NewRequest("POST", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// RevokeFromGroup отзывает роль у группы
+func (rm *RoleManager) RevokeFromGroup(roleName, groupName string, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/roles/%s/groups/%s", rm.ConnectionLine, roleName, groupName)
+	req, err := http.NewRequest("DELETE", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// GetUserPermissions возвращает права пользователя, полученные через его роли и роли его групп
+func (rm *RoleManager) GetUserPermissions(email string, meta *RequestMeta) ([]string, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/users/%s/permissions", rm.ConnectionLine, email)
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp []string
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// //////// AUTH //////////
+type AuthManager struct {
+	ConnectionLine string
+}
+
+// Login выполняет вход по почте и паролю, возвращает пару токенов
+func (a *AuthManager) Login(body io.ReadCloser, meta *RequestMeta) (*TokenPair, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/auth/login", a.ConnectionLine)
+	req, err := http.NewRequest("POST", urlRequest, body)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp TokenPair
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// Refresh обменивает refresh токен на новую пару токенов
+func (a *AuthManager) Refresh(body io.ReadCloser, meta *RequestMeta) (*TokenPair, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/auth/refresh", a.ConnectionLine)
+	req, err := http.NewRequest("POST", urlRequest, body)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp TokenPair
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// Logout отзывает refresh токен
+func (a *AuthManager) Logout(body io.ReadCloser, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/auth/logout", a.ConnectionLine)
+	req, err := http.NewRequest("POST", urlRequest, body)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// WhoAmI возвращает пользователя, которому принадлежит токен доступа из meta.Authorization
+func (a *AuthManager) WhoAmI(meta *RequestMeta) (*UserWithoutPassword, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/auth/whoami", a.ConnectionLine)
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp UserWithoutPassword
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusUnauthorized, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// OpenIDLogin возвращает адрес страницы входа OpenID провайдера, на который нужно перенаправить пользователя
+func (a *AuthManager) OpenIDLogin(meta *RequestMeta) (string, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/openid/login", a.ConnectionLine)
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return "", newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+
+	// перенаправление на провайдера должен выполнить браузер пользователя, а не клиент
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return "", newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusFound:
+		return respRequest.Header.Get("Location"), newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusNotFound, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return "", newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return "", newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return "", newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// OpenIDCallback завершает вход через OpenID провайдера, params - параметры, с которыми провайдер вернул пользователя
+func (a *AuthManager) OpenIDCallback(params url.Values, meta *RequestMeta) (*TokenPair, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/openid/callback?%s", a.ConnectionLine, params.Encode())
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp TokenPair
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// //////// ACCESS REQUEST //////////
+type AccessRequestManager struct {
+	ConnectionLine string
+}
+
+// Create создает заявку на временный доступ к агенту, body содержит JSON вида
+// {"agent": "archive", "action": "read", "group": "", "justification": "разбор инцидента", "duration": "4h"}
+func (m *AccessRequestManager) Create(body io.ReadCloser, meta *RequestMeta) (*AccessRequest, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/access_requests", m.ConnectionLine)
+	req, err := http.NewRequest("POST", urlRequest, body)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp AccessRequest
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// List возвращает список заявок, filter может содержать параметры status, agent, group и email
+func (m *AccessRequestManager) List(filter url.Values, meta *RequestMeta) ([]AccessRequest, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/access_requests?%s", m.ConnectionLine, filter.Encode())
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp []AccessRequest
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// Approve одобряет заявку и выдает доступ на запрошенный срок
+func (m *AccessRequestManager) Approve(requestID int, meta *RequestMeta) (*AccessRequest, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/access_requests/%d/approve", m.ConnectionLine, requestID)
+	req, err := http.NewRequest("POST", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp AccessRequest
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// Reject отклоняет заявку
+func (m *AccessRequestManager) Reject(requestID int, meta *RequestMeta) (*AccessRequest, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/access_requests/%d/reject", m.ConnectionLine, requestID)
+	req, err := http.NewRequest("POST", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp AccessRequest
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// Cancel отменяет заявку, доступно только ее автору
+func (m *AccessRequestManager) Cancel(requestID int, meta *RequestMeta) (*AccessRequest, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/access_requests/%d/cancel", m.ConnectionLine, requestID)
+	req, err := http.NewRequest("POST", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp AccessRequest
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// //////// INVITATION //////////
+type InvitationManager struct {
+	ConnectionLine string
+}
+
+// Create приглашает пользователя в группу. body необязателен и содержит JSON вида
+// {"valid_from": "2024-09-01T10:00:00Z", "valid_until": "2024-09-01T14:00:00Z"} со сроком участия после принятия приглашения
+func (m *InvitationManager) Create(groupName, email string, body io.ReadCloser, meta *RequestMeta) (*Invitation, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/groups/%s/invitations/%s", m.ConnectionLine, groupName, email)
+	req, err := http.NewRequest("POST", urlRequest, body)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp Invitation
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// List возвращает страницу приглашений, filter может содержать status, group, limit и offset
+func (m *InvitationManager) List(filter url.Values, meta *RequestMeta) ([]Invitation, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/invitations?%s", m.ConnectionLine, filter.Encode())
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp []Invitation
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// Accept принимает приглашение, пользователь становится участником группы
+func (m *InvitationManager) Accept(invitationID int, meta *RequestMeta) (*Invitation, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/invitations/%d/accept", m.ConnectionLine, invitationID)
+	req, err := http.NewRequest("POST", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp Invitation
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// Decline отклоняет приглашение
+func (m *InvitationManager) Decline(invitationID int, meta *RequestMeta) (*Invitation, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/invitations/%d/decline", m.ConnectionLine, invitationID)
+	req, err := http.NewRequest("POST", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp Invitation
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// Revoke отзывает приглашение
+func (m *InvitationManager) Revoke(invitationID int, meta *RequestMeta) (*Invitation, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/invitations/%d/revoke", m.ConnectionLine, invitationID)
+	req, err := http.NewRequest("POST", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp Invitation
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// //////// NOTIFICATION //////////
+type NotificationManager struct {
+	ConnectionLine string
+}
+
+// List возвращает страницу уведомлений пользователя, filter может содержать unread, limit и offset
+func (m *NotificationManager) List(filter url.Values, meta *RequestMeta) ([]Notification, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/notifications?%s", m.ConnectionLine, filter.Encode())
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp []Notification
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// Read отмечает уведомление прочитанным
+func (m *NotificationManager) Read(notificationID int64, meta *RequestMeta) (*ResponseDetail, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/notifications/%d/read", m.ConnectionLine, notificationID)
+	req, err := http.NewRequest("POST", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp ResponseDetail
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// //////// AUDIT //////////
+type AuditManager struct {
+	ConnectionLine string
+}
+
+// List возвращает страницу журнала аудита, filter может содержать actor, action, target_type, target, request_id,
+// from, to, limit и offset. Требует права audit.read
+func (m *AuditManager) List(filter url.Values, meta *RequestMeta) ([]AuditEntry, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/audit?%s", m.ConnectionLine, filter.Encode())
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp []AuditEntry
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// Verify проверяет цепочку хэшей журнала аудита. Требует права audit.read
+func (m *AuditManager) Verify(meta *RequestMeta) (*AuditVerification, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/audit/verify", m.ConnectionLine)
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp AuditVerification
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// //////// DECISION LOG //////////
+type DecisionManager struct {
+	ConnectionLine string
+}
+
+// List возвращает страницу журнала решений о доступе, filter может содержать caller, user, agent, action, real_ip,
+// allowed, from, to, limit и offset. Требует права decision_log.read
+func (m *DecisionManager) List(filter url.Values, meta *RequestMeta) ([]AccessDecision, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/decisions?%s", m.ConnectionLine, filter.Encode())
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		var resp []AccessDecision
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return resp, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+// Export выгружает журнал решений о доступе, filter содержит format (csv или jsonl) и те же фильтры, что и List.
+// Требует права decision_log.read
+func (m *DecisionManager) Export(filter url.Values, meta *RequestMeta) (*DecisionExport, *RequestStatus) {
+	urlRequest := fmt.Sprintf("%s/api/v1/decisions/export?%s", m.ConnectionLine, filter.Encode())
+	req, err := http.NewRequest("GET", urlRequest, nil)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	req.Header.Set(XRealIP, meta.RealIp)
+	req.Header.Set(UserAgent, meta.UserAgent)
+	if meta.Authorization != "" {
+		req.Header.Set(Authorization, meta.Authorization)
+	}
+
+	client := &http.Client{}
+	respRequest, err := client.Do(req)
+	if err != nil {
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	defer respRequest.Body.Close()
+
+	switch respRequest.StatusCode {
+	case http.StatusOK:
+		data, err := io.ReadAll(respRequest.Body)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return &DecisionExport{
+			ContentType:        respRequest.Header.Get("Content-Type"),
+			ContentDisposition: respRequest.Header.Get("Content-Disposition"),
+			Data:               data,
+		}, newRequestStatus(nil, respRequest.StatusCode)
+
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		var resp ResponseError
+		err = json.NewDecoder(respRequest.Body).Decode(&resp)
+		if err != nil {
+			return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
+		}
+		return nil, newRequestStatus(errors.New(resp.Error), respRequest.StatusCode)
+
+	default:
+		return nil, newRequestStatus(ErrInternal, http.StatusInternalServerError)
 	}
 }
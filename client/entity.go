@@ -1,8 +1,14 @@
 package client
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type Agent struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID      int      `json:"id"`
+	Name    string   `json:"name"`
+	Actions []string `json:"actions"`
 }
 
 type ResponseDetail struct {
@@ -41,16 +47,237 @@ type Group struct {
 	OwnerID string `json:"owner_id"`
 }
 
+type GroupMember struct {
+	ID         string     `json:"id"`
+	Email      string     `json:"email"`
+	FirstName  string     `json:"first_name"`
+	LastName   string     `json:"last_name"`
+	IsOwner    bool       `json:"is_owner"`
+	ValidFrom  time.Time  `json:"valid_from"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+}
+
 type Bid struct {
-	ID        int    `json:"id"`
-	GroupName string `json:"group_name"`
-	UserId    string `json:"user_id"`
-	Status    string `json:"status"`
+	ID            int              `json:"id"`
+	GroupName     string           `json:"group_name"`
+	UserId        string           `json:"user_id"`
+	Email         string           `json:"email,omitempty"`
+	Status        string           `json:"status"`
+	Reviewer      string           `json:"reviewer,omitempty"`
+	ReviewComment string           `json:"review_comment,omitempty"`
+	ReviewedAt    *time.Time       `json:"reviewed_at,omitempty"`
+	CreatedAt     *time.Time       `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time       `json:"updated_at,omitempty"`
+	History       []*BidTransition `json:"history,omitempty"`
+}
+
+type BidTransition struct {
+	StatusFrom string    `json:"status_from,omitempty"`
+	StatusTo   string    `json:"status_to"`
+	Actor      string    `json:"actor,omitempty"`
+	Comment    string    `json:"comment,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type Permission struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type Role struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	IsSystem    bool     `json:"is_system"`
+	Permissions []string `json:"permissions"`
+}
+
+// AccessExplanation вывод решения о доступе пользователя к агенту или к его действию
+type AccessExplanation struct {
+	Email       string        `json:"email"`
+	Agent       string        `json:"agent"`
+	Action      string        `json:"action,omitempty"`
+	CanExecute  bool          `json:"can_execute"`
+	Decision    string        `json:"decision"`
+	FailedCheck string        `json:"failed_check,omitempty"`
+	Rules       []*AccessRule `json:"rules"`
+}
+
+// AccessCheckItem проверка доступа пользователя к агенту из пакетной проверки. Пустое действие означает агента целиком
+type AccessCheckItem struct {
+	Email  string `json:"email"`
+	Agent  string `json:"agent"`
+	Action string `json:"action,omitempty"`
+}
+
+// AccessCheckResult решение о доступе из пакетной проверки. Если пользователь, агент или действие не существуют,
+// Decision равно check_failed, а FailedCheck указывает причину
+type AccessCheckResult struct {
+	Email       string `json:"email"`
+	Agent       string `json:"agent"`
+	Action      string `json:"action,omitempty"`
+	CanExecute  bool   `json:"can_execute"`
+	Decision    string `json:"decision"`
+	FailedCheck string `json:"failed_check,omitempty"`
+}
+
+// AgentAccessor пользователь с действующим доступом к агенту (Action пустое) или к его действию. Source равно user
+// для индивидуальной привелегии и group для привелегии, унаследованной от групп Groups
+type AgentAccessor struct {
+	Email  string   `json:"email"`
+	Action string   `json:"action,omitempty"`
+	Source string   `json:"source"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// AgentAccessorsExport выгрузка пользователей с доступом к агенту в формате csv в том виде, в котором ее отдал сервис
+type AgentAccessorsExport struct {
+	ContentType        string
+	ContentDisposition string
+	Data               []byte
+}
+
+// AccessCacheStats счетчики кэша решений о доступе микросервиса авторизации
+type AccessCacheStats struct {
+	Enabled       bool    `json:"enabled"`
+	Size          int     `json:"size"`
+	Capacity      int     `json:"capacity"`
+	TTL           string  `json:"ttl"`
+	Hits          int64   `json:"hits"`
+	Misses        int64   `json:"misses"`
+	HitRatio      float64 `json:"hit_ratio"`
+	Evictions     int64   `json:"evictions"`
+	Invalidations int64   `json:"invalidations"`
+}
+
+type AccessRule struct {
+	Effect          string `json:"effect"`
+	Source          string `json:"source"`
+	Group           string `json:"group,omitempty"`
+	ViaGroup        string `json:"via_group,omitempty"`
+	Action          string `json:"action,omitempty"`
+	Table           string `json:"table"`
+	RowID           int    `json:"row_id"`
+	ParticipationID int    `json:"participation_id,omitempty"`
+	Applied         bool   `json:"applied"`
+}
+
+// AccessRequest заявка на временный доступ к агенту или на участие в группе, через которую доступен агент
+type AccessRequest struct {
+	ID            int        `json:"id"`
+	Email         string     `json:"email"`
+	Agent         string     `json:"agent"`
+	Action        string     `json:"action,omitempty"`
+	Group         string     `json:"group,omitempty"`
+	Justification string     `json:"justification"`
+	Duration      string     `json:"duration"`
+	Status        string     `json:"status"`
+	Reviewer      string     `json:"reviewer,omitempty"`
+	ValidUntil    *time.Time `json:"valid_until,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+type Invitation struct {
+	ID          int        `json:"id"`
+	Group       string     `json:"group"`
+	Email       string     `json:"email"`
+	Inviter     string     `json:"inviter,omitempty"`
+	Status      string     `json:"status"`
+	ValidFrom   *time.Time `json:"valid_from,omitempty"`
+	ValidUntil  *time.Time `json:"valid_until,omitempty"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type Notification struct {
+	ID        int64           `json:"id"`
+	Event     string          `json:"event"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+	ReadAt    *time.Time      `json:"read_at,omitempty"`
+}
+
+type AuditEntry struct {
+	ID         int64           `json:"id"`
+	ActorID    *string         `json:"actor_id,omitempty"`
+	Actor      string          `json:"actor,omitempty"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	Target     string          `json:"target"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	PrevHash   string          `json:"prev_hash"`
+	Hash       string          `json:"hash"`
+}
+
+type AuditVerification struct {
+	Valid          bool   `json:"valid"`
+	Checked        int64  `json:"checked"`
+	FirstInvalidID *int64 `json:"first_invalid_id,omitempty"`
+	LastHash       string `json:"last_hash"`
+}
+
+type AccessDecision struct {
+	ID          int64     `json:"id"`
+	CheckedAt   time.Time `json:"checked_at"`
+	CallerID    string    `json:"caller_id,omitempty"`
+	Caller      string    `json:"caller,omitempty"`
+	User        string    `json:"user"`
+	Agent       string    `json:"agent"`
+	Action      string    `json:"action,omitempty"`
+	RealIP      string    `json:"real_ip,omitempty"`
+	Allowed     bool      `json:"allowed"`
+	Decision    string    `json:"decision"`
+	FailedCheck string    `json:"failed_check,omitempty"`
+	RequestID   string    `json:"request_id,omitempty"`
+}
+
+// ChangeEvent событие изменения, влияющего на доступ к агентам, которое сервис доставляет вебхуком
+type ChangeEvent struct {
+	ID         int64           `json:"id"`
+	Event      string          `json:"event"`
+	TargetType string          `json:"target_type"`
+	Target     string          `json:"target"`
+	Actor      string          `json:"actor,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// DecisionExport выгрузка журнала решений о доступе в том виде, в котором ее отдал сервис
+type DecisionExport struct {
+	ContentType        string
+	ContentDisposition string
+	Data               []byte
 }
 
 type RequestMeta struct {
 	UserAgent string
 	RealIp    string
+	// Authorization значение заголовка 'Authorization' исходного запроса, пробрасывается в микросервис как есть
+	Authorization string
+}
+
+type LoginData struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type RefreshData struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
 }
 
 type RequestStatus struct {
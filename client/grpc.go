@@ -0,0 +1,217 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+
+	pb "github.com/cantylv/authorization-service/client/privelegepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCOpts параметры подключения к gRPC API сервиса на том же хосте, что и HTTP API. Если они заданы, проверки
+// доступа Privelege.CanUserExecute, Privelege.CanUserExecuteAction, Privelege.CanUserExecuteBatch,
+// Privelege.ExplainAccess и чтение Agent.GetAll, User.Get, Group.UserList, Privelege.GetGroupAgents,
+// Privelege.GetUserAgents выполняются по gRPC, остальные методы используют HTTP API.
+type GRPCOpts struct {
+	Port int
+}
+
+// Возвращает параметры подключения к gRPC API
+func NewGRPCOpts(port int) *GRPCOpts {
+	return &GRPCOpts{
+		Port: port,
+	}
+}
+
+// rpcClient сгенерированные клиенты сервисов gRPC API, общие для менеджеров клиента.
+type rpcClient struct {
+	users      pb.UserServiceClient
+	groups     pb.GroupServiceClient
+	agents     pb.AgentServiceClient
+	priveleges pb.PrivelegeServiceClient
+	access     pb.AccessServiceClient
+}
+
+// newRPCClient создает соединение с gRPC API. Соединение устанавливается при первом вызове, поэтому ошибка
+// возможна только при неверных параметрах подключения.
+func newRPCClient(opts *ClientOpts) (*grpc.ClientConn, *rpcClient, error) {
+	creds := insecure.NewCredentials()
+	if opts.UseSsl {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	conn, err := grpc.NewClient(fmt.Sprintf("%s:%d", opts.Host, opts.GRPC.Port), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, &rpcClient{
+		users:      pb.NewUserServiceClient(conn),
+		groups:     pb.NewGroupServiceClient(conn),
+		agents:     pb.NewAgentServiceClient(conn),
+		priveleges: pb.NewPrivelegeServiceClient(conn),
+		access:     pb.NewAccessServiceClient(conn),
+	}, nil
+}
+
+// rpcContext передает IP-адрес клиента и заголовок 'Authorization' исходного запроса в метаданных вызова.
+func rpcContext(meta *RequestMeta) context.Context {
+	md := metadata.Pairs(XRealIP, meta.RealIp)
+	if meta.Authorization != "" {
+		md.Set(Authorization, meta.Authorization)
+	}
+	return metadata.NewOutgoingContext(context.Background(), md)
+}
+
+// rpcStatus переводит статус gRPC в статус ответа HTTP API, чтобы вызывающий код не зависел от протокола.
+func rpcStatus(err error) *RequestStatus {
+	if err == nil {
+		return newRequestStatus(nil, http.StatusOK)
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+	switch st.Code() {
+	case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.FailedPrecondition:
+		return newRequestStatus(errors.New(st.Message()), http.StatusBadRequest)
+	case codes.Unauthenticated:
+		return newRequestStatus(errors.New(st.Message()), http.StatusUnauthorized)
+	case codes.PermissionDenied:
+		return newRequestStatus(errors.New(st.Message()), http.StatusForbidden)
+	default:
+		return newRequestStatus(ErrInternal, http.StatusInternalServerError)
+	}
+}
+
+func newAgents(agents []*pb.Agent) []Agent {
+	result := make([]Agent, 0, len(agents))
+	for _, a := range agents {
+		result = append(result, Agent{
+			ID:      int(a.GetId()),
+			Name:    a.GetName(),
+			Actions: a.GetActions(),
+		})
+	}
+	return result
+}
+
+func (a *AgentManager) rpcGetAll(meta *RequestMeta) ([]Agent, *RequestStatus) {
+	resp, err := a.rpc.agents.ListAgents(rpcContext(meta), &pb.ListAgentsRequest{})
+	if err != nil {
+		return nil, rpcStatus(err)
+	}
+	return newAgents(resp.GetAgents()), rpcStatus(nil)
+}
+
+func (u *UserManager) rpcGet(email string, meta *RequestMeta) (*UserWithoutPassword, *RequestStatus) {
+	resp, err := u.rpc.users.GetUser(rpcContext(meta), &pb.GetUserRequest{Email: email})
+	if err != nil {
+		return nil, rpcStatus(err)
+	}
+	return &UserWithoutPassword{
+		ID:        resp.GetId(),
+		Email:     resp.GetEmail(),
+		FirstName: resp.GetFirstName(),
+		LastName:  resp.GetLastName(),
+	}, rpcStatus(nil)
+}
+
+func (g *GroupManager) rpcUserList(email string, meta *RequestMeta) ([]Group, *RequestStatus) {
+	resp, err := g.rpc.groups.ListUserGroups(rpcContext(meta), &pb.ListUserGroupsRequest{Email: email})
+	if err != nil {
+		return nil, rpcStatus(err)
+	}
+	groups := make([]Group, 0, len(resp.GetGroups()))
+	for _, group := range resp.GetGroups() {
+		groups = append(groups, Group{
+			ID:      int(group.GetId()),
+			Name:    group.GetName(),
+			OwnerID: group.GetOwnerId(),
+		})
+	}
+	return groups, rpcStatus(nil)
+}
+
+func (p *PrivelegeManager) rpcGetGroupAgents(groupName string, meta *RequestMeta) ([]Agent, *RequestStatus) {
+	resp, err := p.rpc.priveleges.ListGroupAgents(rpcContext(meta), &pb.ListGroupAgentsRequest{Group: groupName})
+	if err != nil {
+		return nil, rpcStatus(err)
+	}
+	return newAgents(resp.GetAgents()), rpcStatus(nil)
+}
+
+func (p *PrivelegeManager) rpcGetUserAgents(email string, meta *RequestMeta) ([]Agent, *RequestStatus) {
+	resp, err := p.rpc.priveleges.ListUserAgents(rpcContext(meta), &pb.ListUserAgentsRequest{Email: email})
+	if err != nil {
+		return nil, rpcStatus(err)
+	}
+	return newAgents(resp.GetAgents()), rpcStatus(nil)
+}
+
+func (p *PrivelegeManager) rpcCanUserExecute(email, agentName, action string, meta *RequestMeta) (bool, *RequestStatus) {
+	resp, err := p.rpc.access.CanExecute(rpcContext(meta), &pb.AccessCheck{Email: email, Agent: agentName, Action: action})
+	if err != nil {
+		return false, rpcStatus(err)
+	}
+	return resp.GetCanExecute(), rpcStatus(nil)
+}
+
+func (p *PrivelegeManager) rpcCanUserExecuteBatch(checks []AccessCheckItem, meta *RequestMeta) ([]*AccessCheckResult, *RequestStatus) {
+	req := &pb.CanExecuteBatchRequest{Checks: make([]*pb.AccessCheck, 0, len(checks))}
+	for _, check := range checks {
+		req.Checks = append(req.Checks, &pb.AccessCheck{Email: check.Email, Agent: check.Agent, Action: check.Action})
+	}
+	resp, err := p.rpc.access.CanExecuteBatch(rpcContext(meta), req)
+	if err != nil {
+		return nil, rpcStatus(err)
+	}
+	results := make([]*AccessCheckResult, 0, len(resp.GetResults()))
+	for _, r := range resp.GetResults() {
+		results = append(results, &AccessCheckResult{
+			Email:       r.GetEmail(),
+			Agent:       r.GetAgent(),
+			Action:      r.GetAction(),
+			CanExecute:  r.GetCanExecute(),
+			Decision:    r.GetDecision(),
+			FailedCheck: r.GetFailedCheck(),
+		})
+	}
+	return results, rpcStatus(nil)
+}
+
+func (p *PrivelegeManager) rpcExplainAccess(email, agentName, action string, meta *RequestMeta) (*AccessExplanation, *RequestStatus) {
+	resp, err := p.rpc.access.ExplainAccess(rpcContext(meta), &pb.AccessCheck{Email: email, Agent: agentName, Action: action})
+	if err != nil {
+		return nil, rpcStatus(err)
+	}
+	rules := make([]*AccessRule, 0, len(resp.GetRules()))
+	for _, r := range resp.GetRules() {
+		rules = append(rules, &AccessRule{
+			Effect:          r.GetEffect(),
+			Source:          r.GetSource(),
+			Group:           r.GetGroup(),
+			ViaGroup:        r.GetViaGroup(),
+			Action:          r.GetAction(),
+			Table:           r.GetTable(),
+			RowID:           int(r.GetRowId()),
+			ParticipationID: int(r.GetParticipationId()),
+			Applied:         r.GetApplied(),
+		})
+	}
+	return &AccessExplanation{
+		Email:       resp.GetEmail(),
+		Agent:       resp.GetAgent(),
+		Action:      resp.GetAction(),
+		CanExecute:  resp.GetCanExecute(),
+		Decision:    resp.GetDecision(),
+		FailedCheck: resp.GetFailedCheck(),
+		Rules:       rules,
+	}, rpcStatus(nil)
+}
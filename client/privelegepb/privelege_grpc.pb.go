@@ -0,0 +1,1285 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: privelege/v1/privelege.proto
+
+// gRPC API сервиса привелегий. Методы повторяют ручки HTTP/JSON API и вызывают те же usecase. Токен доступа
+// передается в метаданных 'authorization: Bearer <token>', IP-адрес клиента - в 'x-real-ip'. Без токена запрос
+// обрабатывается анонимно, а решение о доступе принимает usecase, как и в HTTP API.
+
+package privelegepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	UserService_CreateUser_FullMethodName = "/privelege.v1.UserService/CreateUser"
+	UserService_GetUser_FullMethodName    = "/privelege.v1.UserService/GetUser"
+	UserService_DeleteUser_FullMethodName = "/privelege.v1.UserService/DeleteUser"
+)
+
+// UserServiceClient is the client API for UserService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// //////// USERS //////////
+type UserServiceClient interface {
+	// CreateUser создает пользователя
+	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*User, error)
+	// GetUser возвращает данные пользователя
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error)
+	// DeleteUser удаляет пользователя, требуется право user.delete или удаление самого себя
+	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
+}
+
+type userServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserServiceClient(cc grpc.ClientConnInterface) UserServiceClient {
+	return &userServiceClient{cc}
+}
+
+func (c *userServiceClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_CreateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_GetUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUserResponse)
+	err := c.cc.Invoke(ctx, UserService_DeleteUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+// All implementations must embed UnimplementedUserServiceServer
+// for forward compatibility.
+//
+// //////// USERS //////////
+type UserServiceServer interface {
+	// CreateUser создает пользователя
+	CreateUser(context.Context, *CreateUserRequest) (*User, error)
+	// GetUser возвращает данные пользователя
+	GetUser(context.Context, *GetUserRequest) (*User, error)
+	// DeleteUser удаляет пользователя, требуется право user.delete или удаление самого себя
+	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+// UnimplementedUserServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUserServiceServer struct{}
+
+func (UnimplementedUserServiceServer) CreateUser(context.Context, *CreateUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedUserServiceServer) GetUser(context.Context, *GetUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
+func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserServiceServer will
+// result in compilation errors.
+type UnsafeUserServiceServer interface {
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
+	// If the following call pancis, it indicates UnimplementedUserServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UserService_ServiceDesc, srv)
+}
+
+func _UserService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteUser(ctx, req.(*DeleteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "privelege.v1.UserService",
+	HandlerType: (*UserServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateUser",
+			Handler:    _UserService_CreateUser_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _UserService_GetUser_Handler,
+		},
+		{
+			MethodName: "DeleteUser",
+			Handler:    _UserService_DeleteUser_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "privelege/v1/privelege.proto",
+}
+
+const (
+	GroupService_AddUserToGroup_FullMethodName    = "/privelege.v1.GroupService/AddUserToGroup"
+	GroupService_KickUserFromGroup_FullMethodName = "/privelege.v1.GroupService/KickUserFromGroup"
+	GroupService_ListUserGroups_FullMethodName    = "/privelege.v1.GroupService/ListUserGroups"
+	GroupService_ListGroupMembers_FullMethodName  = "/privelege.v1.GroupService/ListGroupMembers"
+)
+
+// GroupServiceClient is the client API for GroupService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// //////// GROUPS //////////
+type GroupServiceClient interface {
+	// AddUserToGroup добавляет пользователя в группу, period задает срок участия
+	AddUserToGroup(ctx context.Context, in *AddUserToGroupRequest, opts ...grpc.CallOption) (*AddUserToGroupResponse, error)
+	// KickUserFromGroup удаляет пользователя из группы
+	KickUserFromGroup(ctx context.Context, in *KickUserFromGroupRequest, opts ...grpc.CallOption) (*KickUserFromGroupResponse, error)
+	// ListUserGroups возвращает группы пользователя, общие с группами того, кто выполняет запрос
+	ListUserGroups(ctx context.Context, in *ListUserGroupsRequest, opts ...grpc.CallOption) (*ListUserGroupsResponse, error)
+	// ListGroupMembers возвращает страницу участников группы
+	ListGroupMembers(ctx context.Context, in *ListGroupMembersRequest, opts ...grpc.CallOption) (*ListGroupMembersResponse, error)
+}
+
+type groupServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGroupServiceClient(cc grpc.ClientConnInterface) GroupServiceClient {
+	return &groupServiceClient{cc}
+}
+
+func (c *groupServiceClient) AddUserToGroup(ctx context.Context, in *AddUserToGroupRequest, opts ...grpc.CallOption) (*AddUserToGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddUserToGroupResponse)
+	err := c.cc.Invoke(ctx, GroupService_AddUserToGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupServiceClient) KickUserFromGroup(ctx context.Context, in *KickUserFromGroupRequest, opts ...grpc.CallOption) (*KickUserFromGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KickUserFromGroupResponse)
+	err := c.cc.Invoke(ctx, GroupService_KickUserFromGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupServiceClient) ListUserGroups(ctx context.Context, in *ListUserGroupsRequest, opts ...grpc.CallOption) (*ListUserGroupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserGroupsResponse)
+	err := c.cc.Invoke(ctx, GroupService_ListUserGroups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupServiceClient) ListGroupMembers(ctx context.Context, in *ListGroupMembersRequest, opts ...grpc.CallOption) (*ListGroupMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGroupMembersResponse)
+	err := c.cc.Invoke(ctx, GroupService_ListGroupMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GroupServiceServer is the server API for GroupService service.
+// All implementations must embed UnimplementedGroupServiceServer
+// for forward compatibility.
+//
+// //////// GROUPS //////////
+type GroupServiceServer interface {
+	// AddUserToGroup добавляет пользователя в группу, period задает срок участия
+	AddUserToGroup(context.Context, *AddUserToGroupRequest) (*AddUserToGroupResponse, error)
+	// KickUserFromGroup удаляет пользователя из группы
+	KickUserFromGroup(context.Context, *KickUserFromGroupRequest) (*KickUserFromGroupResponse, error)
+	// ListUserGroups возвращает группы пользователя, общие с группами того, кто выполняет запрос
+	ListUserGroups(context.Context, *ListUserGroupsRequest) (*ListUserGroupsResponse, error)
+	// ListGroupMembers возвращает страницу участников группы
+	ListGroupMembers(context.Context, *ListGroupMembersRequest) (*ListGroupMembersResponse, error)
+	mustEmbedUnimplementedGroupServiceServer()
+}
+
+// UnimplementedGroupServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGroupServiceServer struct{}
+
+func (UnimplementedGroupServiceServer) AddUserToGroup(context.Context, *AddUserToGroupRequest) (*AddUserToGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddUserToGroup not implemented")
+}
+func (UnimplementedGroupServiceServer) KickUserFromGroup(context.Context, *KickUserFromGroupRequest) (*KickUserFromGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KickUserFromGroup not implemented")
+}
+func (UnimplementedGroupServiceServer) ListUserGroups(context.Context, *ListUserGroupsRequest) (*ListUserGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserGroups not implemented")
+}
+func (UnimplementedGroupServiceServer) ListGroupMembers(context.Context, *ListGroupMembersRequest) (*ListGroupMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroupMembers not implemented")
+}
+func (UnimplementedGroupServiceServer) mustEmbedUnimplementedGroupServiceServer() {}
+func (UnimplementedGroupServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeGroupServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GroupServiceServer will
+// result in compilation errors.
+type UnsafeGroupServiceServer interface {
+	mustEmbedUnimplementedGroupServiceServer()
+}
+
+func RegisterGroupServiceServer(s grpc.ServiceRegistrar, srv GroupServiceServer) {
+	// If the following call pancis, it indicates UnimplementedGroupServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GroupService_ServiceDesc, srv)
+}
+
+func _GroupService_AddUserToGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddUserToGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServiceServer).AddUserToGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupService_AddUserToGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServiceServer).AddUserToGroup(ctx, req.(*AddUserToGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupService_KickUserFromGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KickUserFromGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServiceServer).KickUserFromGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupService_KickUserFromGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServiceServer).KickUserFromGroup(ctx, req.(*KickUserFromGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupService_ListUserGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServiceServer).ListUserGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupService_ListUserGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServiceServer).ListUserGroups(ctx, req.(*ListUserGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupService_ListGroupMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGroupMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServiceServer).ListGroupMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GroupService_ListGroupMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServiceServer).ListGroupMembers(ctx, req.(*ListGroupMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GroupService_ServiceDesc is the grpc.ServiceDesc for GroupService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GroupService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "privelege.v1.GroupService",
+	HandlerType: (*GroupServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddUserToGroup",
+			Handler:    _GroupService_AddUserToGroup_Handler,
+		},
+		{
+			MethodName: "KickUserFromGroup",
+			Handler:    _GroupService_KickUserFromGroup_Handler,
+		},
+		{
+			MethodName: "ListUserGroups",
+			Handler:    _GroupService_ListUserGroups_Handler,
+		},
+		{
+			MethodName: "ListGroupMembers",
+			Handler:    _GroupService_ListGroupMembers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "privelege/v1/privelege.proto",
+}
+
+const (
+	AgentService_CreateAgent_FullMethodName = "/privelege.v1.AgentService/CreateAgent"
+	AgentService_DeleteAgent_FullMethodName = "/privelege.v1.AgentService/DeleteAgent"
+	AgentService_ListAgents_FullMethodName  = "/privelege.v1.AgentService/ListAgents"
+)
+
+// AgentServiceClient is the client API for AgentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// //////// AGENTS //////////
+type AgentServiceClient interface {
+	// CreateAgent создает агента с действиями actions, требуется право agent.create
+	CreateAgent(ctx context.Context, in *CreateAgentRequest, opts ...grpc.CallOption) (*Agent, error)
+	// DeleteAgent удаляет агента, требуется право agent.delete
+	DeleteAgent(ctx context.Context, in *DeleteAgentRequest, opts ...grpc.CallOption) (*DeleteAgentResponse, error)
+	// ListAgents возвращает всех агентов, требуется право agent.read
+	ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error)
+}
+
+type agentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAgentServiceClient(cc grpc.ClientConnInterface) AgentServiceClient {
+	return &agentServiceClient{cc}
+}
+
+func (c *agentServiceClient) CreateAgent(ctx context.Context, in *CreateAgentRequest, opts ...grpc.CallOption) (*Agent, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Agent)
+	err := c.cc.Invoke(ctx, AgentService_CreateAgent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) DeleteAgent(ctx context.Context, in *DeleteAgentRequest, opts ...grpc.CallOption) (*DeleteAgentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAgentResponse)
+	err := c.cc.Invoke(ctx, AgentService_DeleteAgent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAgentsResponse)
+	err := c.cc.Invoke(ctx, AgentService_ListAgents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AgentServiceServer is the server API for AgentService service.
+// All implementations must embed UnimplementedAgentServiceServer
+// for forward compatibility.
+//
+// //////// AGENTS //////////
+type AgentServiceServer interface {
+	// CreateAgent создает агента с действиями actions, требуется право agent.create
+	CreateAgent(context.Context, *CreateAgentRequest) (*Agent, error)
+	// DeleteAgent удаляет агента, требуется право agent.delete
+	DeleteAgent(context.Context, *DeleteAgentRequest) (*DeleteAgentResponse, error)
+	// ListAgents возвращает всех агентов, требуется право agent.read
+	ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResponse, error)
+	mustEmbedUnimplementedAgentServiceServer()
+}
+
+// UnimplementedAgentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAgentServiceServer struct{}
+
+func (UnimplementedAgentServiceServer) CreateAgent(context.Context, *CreateAgentRequest) (*Agent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAgent not implemented")
+}
+func (UnimplementedAgentServiceServer) DeleteAgent(context.Context, *DeleteAgentRequest) (*DeleteAgentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAgent not implemented")
+}
+func (UnimplementedAgentServiceServer) ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAgents not implemented")
+}
+func (UnimplementedAgentServiceServer) mustEmbedUnimplementedAgentServiceServer() {}
+func (UnimplementedAgentServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAgentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AgentServiceServer will
+// result in compilation errors.
+type UnsafeAgentServiceServer interface {
+	mustEmbedUnimplementedAgentServiceServer()
+}
+
+func RegisterAgentServiceServer(s grpc.ServiceRegistrar, srv AgentServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAgentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AgentService_ServiceDesc, srv)
+}
+
+func _AgentService_CreateAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).CreateAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_CreateAgent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).CreateAgent(ctx, req.(*CreateAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_DeleteAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).DeleteAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_DeleteAgent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).DeleteAgent(ctx, req.(*DeleteAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_ListAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAgentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).ListAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_ListAgents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).ListAgents(ctx, req.(*ListAgentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AgentService_ServiceDesc is the grpc.ServiceDesc for AgentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AgentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "privelege.v1.AgentService",
+	HandlerType: (*AgentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAgent",
+			Handler:    _AgentService_CreateAgent_Handler,
+		},
+		{
+			MethodName: "DeleteAgent",
+			Handler:    _AgentService_DeleteAgent_Handler,
+		},
+		{
+			MethodName: "ListAgents",
+			Handler:    _AgentService_ListAgents_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "privelege/v1/privelege.proto",
+}
+
+const (
+	PrivelegeService_GrantToGroup_FullMethodName        = "/privelege.v1.PrivelegeService/GrantToGroup"
+	PrivelegeService_RevokeFromGroup_FullMethodName     = "/privelege.v1.PrivelegeService/RevokeFromGroup"
+	PrivelegeService_GrantToUser_FullMethodName         = "/privelege.v1.PrivelegeService/GrantToUser"
+	PrivelegeService_RevokeFromUser_FullMethodName      = "/privelege.v1.PrivelegeService/RevokeFromUser"
+	PrivelegeService_DenyToGroup_FullMethodName         = "/privelege.v1.PrivelegeService/DenyToGroup"
+	PrivelegeService_RemoveDenyFromGroup_FullMethodName = "/privelege.v1.PrivelegeService/RemoveDenyFromGroup"
+	PrivelegeService_DenyToUser_FullMethodName          = "/privelege.v1.PrivelegeService/DenyToUser"
+	PrivelegeService_RemoveDenyFromUser_FullMethodName  = "/privelege.v1.PrivelegeService/RemoveDenyFromUser"
+	PrivelegeService_ListGroupAgents_FullMethodName     = "/privelege.v1.PrivelegeService/ListGroupAgents"
+	PrivelegeService_ListUserAgents_FullMethodName      = "/privelege.v1.PrivelegeService/ListUserAgents"
+)
+
+// PrivelegeServiceClient is the client API for PrivelegeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// //////// PRIVELEGES //////////
+// Пустое action во всех методах означает привелегию или запрет на агента целиком.
+type PrivelegeServiceClient interface {
+	// GrantToGroup выдает группе доступ к агенту или к его действию
+	GrantToGroup(ctx context.Context, in *GroupPrivelegeRequest, opts ...grpc.CallOption) (*PrivelegeResponse, error)
+	// RevokeFromGroup отзывает у группы доступ к агенту или к его действию
+	RevokeFromGroup(ctx context.Context, in *GroupPrivelegeRequest, opts ...grpc.CallOption) (*PrivelegeResponse, error)
+	// GrantToUser выдает пользователю доступ к агенту или к его действию
+	GrantToUser(ctx context.Context, in *UserPrivelegeRequest, opts ...grpc.CallOption) (*PrivelegeResponse, error)
+	// RevokeFromUser отзывает у пользователя доступ к агенту или к его действию
+	RevokeFromUser(ctx context.Context, in *UserPrivelegeRequest, opts ...grpc.CallOption) (*PrivelegeResponse, error)
+	// DenyToGroup запрещает группе доступ к агенту или к его действию
+	DenyToGroup(ctx context.Context, in *GroupPrivelegeRequest, opts ...grpc.CallOption) (*PrivelegeResponse, error)
+	// RemoveDenyFromGroup снимает запрет группы
+	RemoveDenyFromGroup(ctx context.Context, in *GroupPrivelegeRequest, opts ...grpc.CallOption) (*PrivelegeResponse, error)
+	// DenyToUser запрещает пользователю доступ к агенту или к его действию
+	DenyToUser(ctx context.Context, in *UserPrivelegeRequest, opts ...grpc.CallOption) (*PrivelegeResponse, error)
+	// RemoveDenyFromUser снимает запрет пользователя
+	RemoveDenyFromUser(ctx context.Context, in *UserPrivelegeRequest, opts ...grpc.CallOption) (*PrivelegeResponse, error)
+	// ListGroupAgents возвращает агентов, доступных группе
+	ListGroupAgents(ctx context.Context, in *ListGroupAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error)
+	// ListUserAgents возвращает агентов, доступных пользователю
+	ListUserAgents(ctx context.Context, in *ListUserAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error)
+}
+
+type privelegeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPrivelegeServiceClient(cc grpc.ClientConnInterface) PrivelegeServiceClient {
+	return &privelegeServiceClient{cc}
+}
+
+func (c *privelegeServiceClient) GrantToGroup(ctx context.Context, in *GroupPrivelegeRequest, opts ...grpc.CallOption) (*PrivelegeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PrivelegeResponse)
+	err := c.cc.Invoke(ctx, PrivelegeService_GrantToGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privelegeServiceClient) RevokeFromGroup(ctx context.Context, in *GroupPrivelegeRequest, opts ...grpc.CallOption) (*PrivelegeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PrivelegeResponse)
+	err := c.cc.Invoke(ctx, PrivelegeService_RevokeFromGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privelegeServiceClient) GrantToUser(ctx context.Context, in *UserPrivelegeRequest, opts ...grpc.CallOption) (*PrivelegeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PrivelegeResponse)
+	err := c.cc.Invoke(ctx, PrivelegeService_GrantToUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privelegeServiceClient) RevokeFromUser(ctx context.Context, in *UserPrivelegeRequest, opts ...grpc.CallOption) (*PrivelegeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PrivelegeResponse)
+	err := c.cc.Invoke(ctx, PrivelegeService_RevokeFromUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privelegeServiceClient) DenyToGroup(ctx context.Context, in *GroupPrivelegeRequest, opts ...grpc.CallOption) (*PrivelegeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PrivelegeResponse)
+	err := c.cc.Invoke(ctx, PrivelegeService_DenyToGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privelegeServiceClient) RemoveDenyFromGroup(ctx context.Context, in *GroupPrivelegeRequest, opts ...grpc.CallOption) (*PrivelegeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PrivelegeResponse)
+	err := c.cc.Invoke(ctx, PrivelegeService_RemoveDenyFromGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privelegeServiceClient) DenyToUser(ctx context.Context, in *UserPrivelegeRequest, opts ...grpc.CallOption) (*PrivelegeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PrivelegeResponse)
+	err := c.cc.Invoke(ctx, PrivelegeService_DenyToUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privelegeServiceClient) RemoveDenyFromUser(ctx context.Context, in *UserPrivelegeRequest, opts ...grpc.CallOption) (*PrivelegeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PrivelegeResponse)
+	err := c.cc.Invoke(ctx, PrivelegeService_RemoveDenyFromUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privelegeServiceClient) ListGroupAgents(ctx context.Context, in *ListGroupAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAgentsResponse)
+	err := c.cc.Invoke(ctx, PrivelegeService_ListGroupAgents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privelegeServiceClient) ListUserAgents(ctx context.Context, in *ListUserAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAgentsResponse)
+	err := c.cc.Invoke(ctx, PrivelegeService_ListUserAgents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PrivelegeServiceServer is the server API for PrivelegeService service.
+// All implementations must embed UnimplementedPrivelegeServiceServer
+// for forward compatibility.
+//
+// //////// PRIVELEGES //////////
+// Пустое action во всех методах означает привелегию или запрет на агента целиком.
+type PrivelegeServiceServer interface {
+	// GrantToGroup выдает группе доступ к агенту или к его действию
+	GrantToGroup(context.Context, *GroupPrivelegeRequest) (*PrivelegeResponse, error)
+	// RevokeFromGroup отзывает у группы доступ к агенту или к его действию
+	RevokeFromGroup(context.Context, *GroupPrivelegeRequest) (*PrivelegeResponse, error)
+	// GrantToUser выдает пользователю доступ к агенту или к его действию
+	GrantToUser(context.Context, *UserPrivelegeRequest) (*PrivelegeResponse, error)
+	// RevokeFromUser отзывает у пользователя доступ к агенту или к его действию
+	RevokeFromUser(context.Context, *UserPrivelegeRequest) (*PrivelegeResponse, error)
+	// DenyToGroup запрещает группе доступ к агенту или к его действию
+	DenyToGroup(context.Context, *GroupPrivelegeRequest) (*PrivelegeResponse, error)
+	// RemoveDenyFromGroup снимает запрет группы
+	RemoveDenyFromGroup(context.Context, *GroupPrivelegeRequest) (*PrivelegeResponse, error)
+	// DenyToUser запрещает пользователю доступ к агенту или к его действию
+	DenyToUser(context.Context, *UserPrivelegeRequest) (*PrivelegeResponse, error)
+	// RemoveDenyFromUser снимает запрет пользователя
+	RemoveDenyFromUser(context.Context, *UserPrivelegeRequest) (*PrivelegeResponse, error)
+	// ListGroupAgents возвращает агентов, доступных группе
+	ListGroupAgents(context.Context, *ListGroupAgentsRequest) (*ListAgentsResponse, error)
+	// ListUserAgents возвращает агентов, доступных пользователю
+	ListUserAgents(context.Context, *ListUserAgentsRequest) (*ListAgentsResponse, error)
+	mustEmbedUnimplementedPrivelegeServiceServer()
+}
+
+// UnimplementedPrivelegeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPrivelegeServiceServer struct{}
+
+func (UnimplementedPrivelegeServiceServer) GrantToGroup(context.Context, *GroupPrivelegeRequest) (*PrivelegeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantToGroup not implemented")
+}
+func (UnimplementedPrivelegeServiceServer) RevokeFromGroup(context.Context, *GroupPrivelegeRequest) (*PrivelegeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeFromGroup not implemented")
+}
+func (UnimplementedPrivelegeServiceServer) GrantToUser(context.Context, *UserPrivelegeRequest) (*PrivelegeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantToUser not implemented")
+}
+func (UnimplementedPrivelegeServiceServer) RevokeFromUser(context.Context, *UserPrivelegeRequest) (*PrivelegeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeFromUser not implemented")
+}
+func (UnimplementedPrivelegeServiceServer) DenyToGroup(context.Context, *GroupPrivelegeRequest) (*PrivelegeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DenyToGroup not implemented")
+}
+func (UnimplementedPrivelegeServiceServer) RemoveDenyFromGroup(context.Context, *GroupPrivelegeRequest) (*PrivelegeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveDenyFromGroup not implemented")
+}
+func (UnimplementedPrivelegeServiceServer) DenyToUser(context.Context, *UserPrivelegeRequest) (*PrivelegeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DenyToUser not implemented")
+}
+func (UnimplementedPrivelegeServiceServer) RemoveDenyFromUser(context.Context, *UserPrivelegeRequest) (*PrivelegeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveDenyFromUser not implemented")
+}
+func (UnimplementedPrivelegeServiceServer) ListGroupAgents(context.Context, *ListGroupAgentsRequest) (*ListAgentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroupAgents not implemented")
+}
+func (UnimplementedPrivelegeServiceServer) ListUserAgents(context.Context, *ListUserAgentsRequest) (*ListAgentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserAgents not implemented")
+}
+func (UnimplementedPrivelegeServiceServer) mustEmbedUnimplementedPrivelegeServiceServer() {}
+func (UnimplementedPrivelegeServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafePrivelegeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PrivelegeServiceServer will
+// result in compilation errors.
+type UnsafePrivelegeServiceServer interface {
+	mustEmbedUnimplementedPrivelegeServiceServer()
+}
+
+func RegisterPrivelegeServiceServer(s grpc.ServiceRegistrar, srv PrivelegeServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPrivelegeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PrivelegeService_ServiceDesc, srv)
+}
+
+func _PrivelegeService_GrantToGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupPrivelegeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivelegeServiceServer).GrantToGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PrivelegeService_GrantToGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivelegeServiceServer).GrantToGroup(ctx, req.(*GroupPrivelegeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivelegeService_RevokeFromGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupPrivelegeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivelegeServiceServer).RevokeFromGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PrivelegeService_RevokeFromGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivelegeServiceServer).RevokeFromGroup(ctx, req.(*GroupPrivelegeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivelegeService_GrantToUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPrivelegeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivelegeServiceServer).GrantToUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PrivelegeService_GrantToUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivelegeServiceServer).GrantToUser(ctx, req.(*UserPrivelegeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivelegeService_RevokeFromUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPrivelegeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivelegeServiceServer).RevokeFromUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PrivelegeService_RevokeFromUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivelegeServiceServer).RevokeFromUser(ctx, req.(*UserPrivelegeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivelegeService_DenyToGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupPrivelegeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivelegeServiceServer).DenyToGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PrivelegeService_DenyToGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivelegeServiceServer).DenyToGroup(ctx, req.(*GroupPrivelegeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivelegeService_RemoveDenyFromGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupPrivelegeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivelegeServiceServer).RemoveDenyFromGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PrivelegeService_RemoveDenyFromGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivelegeServiceServer).RemoveDenyFromGroup(ctx, req.(*GroupPrivelegeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivelegeService_DenyToUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPrivelegeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivelegeServiceServer).DenyToUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PrivelegeService_DenyToUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivelegeServiceServer).DenyToUser(ctx, req.(*UserPrivelegeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivelegeService_RemoveDenyFromUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPrivelegeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivelegeServiceServer).RemoveDenyFromUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PrivelegeService_RemoveDenyFromUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivelegeServiceServer).RemoveDenyFromUser(ctx, req.(*UserPrivelegeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivelegeService_ListGroupAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGroupAgentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivelegeServiceServer).ListGroupAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PrivelegeService_ListGroupAgents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivelegeServiceServer).ListGroupAgents(ctx, req.(*ListGroupAgentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivelegeService_ListUserAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserAgentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivelegeServiceServer).ListUserAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PrivelegeService_ListUserAgents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivelegeServiceServer).ListUserAgents(ctx, req.(*ListUserAgentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PrivelegeService_ServiceDesc is the grpc.ServiceDesc for PrivelegeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PrivelegeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "privelege.v1.PrivelegeService",
+	HandlerType: (*PrivelegeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GrantToGroup",
+			Handler:    _PrivelegeService_GrantToGroup_Handler,
+		},
+		{
+			MethodName: "RevokeFromGroup",
+			Handler:    _PrivelegeService_RevokeFromGroup_Handler,
+		},
+		{
+			MethodName: "GrantToUser",
+			Handler:    _PrivelegeService_GrantToUser_Handler,
+		},
+		{
+			MethodName: "RevokeFromUser",
+			Handler:    _PrivelegeService_RevokeFromUser_Handler,
+		},
+		{
+			MethodName: "DenyToGroup",
+			Handler:    _PrivelegeService_DenyToGroup_Handler,
+		},
+		{
+			MethodName: "RemoveDenyFromGroup",
+			Handler:    _PrivelegeService_RemoveDenyFromGroup_Handler,
+		},
+		{
+			MethodName: "DenyToUser",
+			Handler:    _PrivelegeService_DenyToUser_Handler,
+		},
+		{
+			MethodName: "RemoveDenyFromUser",
+			Handler:    _PrivelegeService_RemoveDenyFromUser_Handler,
+		},
+		{
+			MethodName: "ListGroupAgents",
+			Handler:    _PrivelegeService_ListGroupAgents_Handler,
+		},
+		{
+			MethodName: "ListUserAgents",
+			Handler:    _PrivelegeService_ListUserAgents_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "privelege/v1/privelege.proto",
+}
+
+const (
+	AccessService_CanExecute_FullMethodName      = "/privelege.v1.AccessService/CanExecute"
+	AccessService_CanExecuteBatch_FullMethodName = "/privelege.v1.AccessService/CanExecuteBatch"
+	AccessService_ExplainAccess_FullMethodName   = "/privelege.v1.AccessService/ExplainAccess"
+)
+
+// AccessServiceClient is the client API for AccessService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// //////// ACCESS CHECKS //////////
+type AccessServiceClient interface {
+	// CanExecute проверяет, может ли пользователь выполнить действие агента или запустить агента целиком
+	CanExecute(ctx context.Context, in *AccessCheck, opts ...grpc.CallOption) (*CanExecuteResponse, error)
+	// CanExecuteBatch проверяет доступ для набора проверок (не больше 100) и возвращает решения в порядке проверок
+	CanExecuteBatch(ctx context.Context, in *CanExecuteBatchRequest, opts ...grpc.CallOption) (*CanExecuteBatchResponse, error)
+	// ExplainAccess объясняет решение о доступе, требуется право privilege.read или проверка своего доступа
+	ExplainAccess(ctx context.Context, in *AccessCheck, opts ...grpc.CallOption) (*AccessExplanation, error)
+}
+
+type accessServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAccessServiceClient(cc grpc.ClientConnInterface) AccessServiceClient {
+	return &accessServiceClient{cc}
+}
+
+func (c *accessServiceClient) CanExecute(ctx context.Context, in *AccessCheck, opts ...grpc.CallOption) (*CanExecuteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CanExecuteResponse)
+	err := c.cc.Invoke(ctx, AccessService_CanExecute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessServiceClient) CanExecuteBatch(ctx context.Context, in *CanExecuteBatchRequest, opts ...grpc.CallOption) (*CanExecuteBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CanExecuteBatchResponse)
+	err := c.cc.Invoke(ctx, AccessService_CanExecuteBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessServiceClient) ExplainAccess(ctx context.Context, in *AccessCheck, opts ...grpc.CallOption) (*AccessExplanation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccessExplanation)
+	err := c.cc.Invoke(ctx, AccessService_ExplainAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AccessServiceServer is the server API for AccessService service.
+// All implementations must embed UnimplementedAccessServiceServer
+// for forward compatibility.
+//
+// //////// ACCESS CHECKS //////////
+type AccessServiceServer interface {
+	// CanExecute проверяет, может ли пользователь выполнить действие агента или запустить агента целиком
+	CanExecute(context.Context, *AccessCheck) (*CanExecuteResponse, error)
+	// CanExecuteBatch проверяет доступ для набора проверок (не больше 100) и возвращает решения в порядке проверок
+	CanExecuteBatch(context.Context, *CanExecuteBatchRequest) (*CanExecuteBatchResponse, error)
+	// ExplainAccess объясняет решение о доступе, требуется право privilege.read или проверка своего доступа
+	ExplainAccess(context.Context, *AccessCheck) (*AccessExplanation, error)
+	mustEmbedUnimplementedAccessServiceServer()
+}
+
+// UnimplementedAccessServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAccessServiceServer struct{}
+
+func (UnimplementedAccessServiceServer) CanExecute(context.Context, *AccessCheck) (*CanExecuteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CanExecute not implemented")
+}
+func (UnimplementedAccessServiceServer) CanExecuteBatch(context.Context, *CanExecuteBatchRequest) (*CanExecuteBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CanExecuteBatch not implemented")
+}
+func (UnimplementedAccessServiceServer) ExplainAccess(context.Context, *AccessCheck) (*AccessExplanation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainAccess not implemented")
+}
+func (UnimplementedAccessServiceServer) mustEmbedUnimplementedAccessServiceServer() {}
+func (UnimplementedAccessServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeAccessServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AccessServiceServer will
+// result in compilation errors.
+type UnsafeAccessServiceServer interface {
+	mustEmbedUnimplementedAccessServiceServer()
+}
+
+func RegisterAccessServiceServer(s grpc.ServiceRegistrar, srv AccessServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAccessServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AccessService_ServiceDesc, srv)
+}
+
+func _AccessService_CanExecute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccessCheck)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessServiceServer).CanExecute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessService_CanExecute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessServiceServer).CanExecute(ctx, req.(*AccessCheck))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessService_CanExecuteBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CanExecuteBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessServiceServer).CanExecuteBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessService_CanExecuteBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessServiceServer).CanExecuteBatch(ctx, req.(*CanExecuteBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessService_ExplainAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccessCheck)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessServiceServer).ExplainAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessService_ExplainAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessServiceServer).ExplainAccess(ctx, req.(*AccessCheck))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AccessService_ServiceDesc is the grpc.ServiceDesc for AccessService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AccessService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "privelege.v1.AccessService",
+	HandlerType: (*AccessServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CanExecute",
+			Handler:    _AccessService_CanExecute_Handler,
+		},
+		{
+			MethodName: "CanExecuteBatch",
+			Handler:    _AccessService_CanExecuteBatch_Handler,
+		},
+		{
+			MethodName: "ExplainAccess",
+			Handler:    _AccessService_ExplainAccess_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "privelege/v1/privelege.proto",
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderEventID   = "X-Event-ID"
+	HeaderTimestamp = "X-Signature-Timestamp"
+	HeaderSignature = "X-Signature"
+)
+
+var (
+	ErrInvalidWebhookSignature = errors.New("webhook signature is invalid")
+	ErrExpiredWebhook          = errors.New("webhook timestamp is outside of the allowed window")
+)
+
+// ParseWebhook проверяет подпись запроса вебхука с событием изменения и возвращает событие. secret должен
+// совпадать с outbox.webhook.secret сервиса, запросы, подписанные раньше или позже чем на tolerance от текущего
+// времени, отклоняются. Одно событие может прийти несколько раз, повторы нужно отбрасывать по ChangeEvent.ID
+func ParseWebhook(r *http.Request, secret string, tolerance time.Duration) (*ChangeEvent, error) {
+	timestamp := r.Header.Get(HeaderTimestamp)
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidWebhookSignature
+	}
+	if d := time.Since(time.Unix(unix, 0)); d > tolerance || d < -tolerance {
+		return nil, ErrExpiredWebhook
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	signature, ok := strings.CutPrefix(r.Header.Get(HeaderSignature), "sha256=")
+	if !ok {
+		return nil, ErrInvalidWebhookSignature
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return nil, ErrInvalidWebhookSignature
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return nil, ErrInvalidWebhookSignature
+	}
+	var event ChangeEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
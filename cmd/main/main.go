@@ -1,6 +1,8 @@
 package main
 
 import (
+	"os"
+
 	"github.com/cantylv/authorization-service/config"
 	"github.com/cantylv/authorization-service/internal/app"
 	"go.uber.org/zap"
@@ -10,5 +12,9 @@ import (
 func main() {
 	logger := zap.Must(zap.NewProduction())
 	config.Read("./config/config.yaml", logger)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		app.Migrate(logger, os.Args[2:])
+		return
+	}
 	app.Run(logger)
 }
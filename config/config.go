@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -15,6 +16,8 @@ var (
 	rootPasswordDefault  = "Root1234"
 	rootFirstNameDefault = "Root"
 	rootLastNameDefault  = "Rootov"
+	// authSecretExample секрет из примеров конфигурации, использовать его для подписи токенов нельзя
+	authSecretExample = "youReallyNeedToChangeThis"
 )
 
 // readEnvAndSetDefault устанавливает переменные конфигурации viper по умолчанию. Используется для случая,
@@ -64,12 +67,210 @@ func readEnvAndSetDefault(logger *zap.Logger) {
 	}
 
 	viper.SetDefault("postgres.sslmode", "disable")
+	// пул соединений
+	viper.SetDefault("postgres.pool.max_conns", 10)
+	viper.SetDefault("postgres.pool.min_conns", 2)
+	viper.SetDefault("postgres.pool.max_conn_lifetime", time.Hour)
+	viper.SetDefault("postgres.pool.max_conn_idle_time", 30*time.Minute)
+	viper.SetDefault("postgres.pool.health_check_period", time.Minute)
+	viper.SetDefault("postgres.pool.connect_timeout", 5*time.Second)
+	viper.SetDefault("postgres.pool.statement_timeout", 5*time.Second)
+	// транзакции
+	viper.SetDefault("postgres.tx.max_attempts", 3)
+	viper.SetDefault("postgres.tx.retry_backoff", 20*time.Millisecond)
+
+	viper.SetDefault("postgres.migrate_on_start", true)
+	viper.SetDefault("postgres.migrate_timeout", time.Minute)
+	// AUTH
+	// секрет подписи токенов не имеет значения по умолчанию, без него сервис не запускается (см. checkAuthSecret)
+	viper.SetDefault("auth.secret", os.Getenv("AUTH_SECRET"))
+
+	if accessTTL := os.Getenv("AUTH_ACCESS_TTL"); accessTTL != "" {
+		ttl, err := time.ParseDuration(accessTTL)
+		if err != nil {
+			logger.Info("you've passed incorrect value of env variable 'AUTH_ACCESS_TTL', so it will be with default value 15m")
+			viper.SetDefault("auth.access_ttl", 15*time.Minute)
+		} else {
+			viper.SetDefault("auth.access_ttl", ttl)
+		}
+	} else {
+		viper.SetDefault("auth.access_ttl", 15*time.Minute)
+	}
+
+	if refreshTTL := os.Getenv("AUTH_REFRESH_TTL"); refreshTTL != "" {
+		ttl, err := time.ParseDuration(refreshTTL)
+		if err != nil {
+			logger.Info("you've passed incorrect value of env variable 'AUTH_REFRESH_TTL', so it will be with default value 720h")
+			viper.SetDefault("auth.refresh_ttl", 720*time.Hour)
+		} else {
+			viper.SetDefault("auth.refresh_ttl", ttl)
+		}
+	} else {
+		viper.SetDefault("auth.refresh_ttl", 720*time.Hour)
+	}
+	// OPENID CONNECT
+	// если издатель не задан, вход через OpenID провайдера отключен
+	viper.SetDefault("oidc.issuer", os.Getenv("OIDC_ISSUER"))
+	viper.SetDefault("oidc.client_id", os.Getenv("OIDC_CLIENT_ID"))
+	viper.SetDefault("oidc.client_secret", os.Getenv("OIDC_CLIENT_SECRET"))
+	viper.SetDefault("oidc.redirect_url", os.Getenv("OIDC_REDIRECT_URL"))
+
+	if stateTTL := os.Getenv("OIDC_STATE_TTL"); stateTTL != "" {
+		ttl, err := time.ParseDuration(stateTTL)
+		if err != nil {
+			logger.Info("you've passed incorrect value of env variable 'OIDC_STATE_TTL', so it will be with default value 10m")
+			viper.SetDefault("oidc.state_ttl", 10*time.Minute)
+		} else {
+			viper.SetDefault("oidc.state_ttl", ttl)
+		}
+	} else {
+		viper.SetDefault("oidc.state_ttl", 10*time.Minute)
+	}
+	// SWEEPER
+	// нулевой интервал отключает фоновую очистку истекших привелегий
+	if sweeperInterval := os.Getenv("SWEEPER_INTERVAL"); sweeperInterval != "" {
+		interval, err := time.ParseDuration(sweeperInterval)
+		if err != nil {
+			logger.Info("you've passed incorrect value of env variable 'SWEEPER_INTERVAL', so it will be with default value 1m")
+			viper.SetDefault("sweeper.interval", time.Minute)
+		} else {
+			viper.SetDefault("sweeper.interval", interval)
+		}
+	} else {
+		viper.SetDefault("sweeper.interval", time.Minute)
+	}
+	// ACCESS REQUEST
+	if maxDuration := os.Getenv("ACCESS_REQUEST_MAX_DURATION"); maxDuration != "" {
+		duration, err := time.ParseDuration(maxDuration)
+		if err != nil {
+			logger.Info("you've passed incorrect value of env variable 'ACCESS_REQUEST_MAX_DURATION', so it will be with default value 168h")
+			viper.SetDefault("access_request.max_duration", 168*time.Hour)
+		} else {
+			viper.SetDefault("access_request.max_duration", duration)
+		}
+	} else {
+		viper.SetDefault("access_request.max_duration", 168*time.Hour)
+	}
+	// INVITATION
+	if ttl := os.Getenv("INVITATION_TTL"); ttl != "" {
+		duration, err := time.ParseDuration(ttl)
+		if err != nil {
+			logger.Info("you've passed incorrect value of env variable 'INVITATION_TTL', so it will be with default value 72h")
+			viper.SetDefault("invitation.ttl", 72*time.Hour)
+		} else {
+			viper.SetDefault("invitation.ttl", duration)
+		}
+	} else {
+		viper.SetDefault("invitation.ttl", 72*time.Hour)
+	}
+	// DECISION LOG
+	// доля записываемых разрешений доступа, отказы записываются всегда
+	if sampleRate := os.Getenv("DECISION_LOG_SAMPLE_RATE"); sampleRate != "" {
+		rate, err := strconv.ParseFloat(sampleRate, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			logger.Info("you've passed incorrect value of env variable 'DECISION_LOG_SAMPLE_RATE', so it will be with default value 1")
+			viper.SetDefault("decision_log.sample_rate", 1.0)
+		} else {
+			viper.SetDefault("decision_log.sample_rate", rate)
+		}
+	} else {
+		viper.SetDefault("decision_log.sample_rate", 1.0)
+	}
+	// нулевой срок хранения отключает удаление старых записей журнала решений
+	if retention := os.Getenv("DECISION_LOG_RETENTION"); retention != "" {
+		duration, err := time.ParseDuration(retention)
+		if err != nil {
+			logger.Info("you've passed incorrect value of env variable 'DECISION_LOG_RETENTION', so it will be with default value 720h")
+			viper.SetDefault("decision_log.retention", 720*time.Hour)
+		} else {
+			viper.SetDefault("decision_log.retention", duration)
+		}
+	} else {
+		viper.SetDefault("decision_log.retention", 720*time.Hour)
+	}
+	viper.SetDefault("decision_log.buffer_size", 1024)
+	viper.SetDefault("decision_log.batch_size", 100)
+	viper.SetDefault("decision_log.flush_interval", time.Second)
+	// ACCESS CACHE
+	// нулевой размер отключает кэш проверок доступа
+	if size := os.Getenv("ACCESS_CACHE_SIZE"); size != "" {
+		cacheSize, err := strconv.Atoi(size)
+		if err != nil || cacheSize < 0 {
+			logger.Info("you've passed incorrect value of env variable 'ACCESS_CACHE_SIZE', so it will be with default value 10000")
+			viper.SetDefault("access_cache.size", 10000)
+		} else {
+			viper.SetDefault("access_cache.size", cacheSize)
+		}
+	} else {
+		viper.SetDefault("access_cache.size", 10000)
+	}
+	if ttl := os.Getenv("ACCESS_CACHE_TTL"); ttl != "" {
+		duration, err := time.ParseDuration(ttl)
+		if err != nil {
+			logger.Info("you've passed incorrect value of env variable 'ACCESS_CACHE_TTL', so it will be with default value 1m")
+			viper.SetDefault("access_cache.ttl", time.Minute)
+		} else {
+			viper.SetDefault("access_cache.ttl", duration)
+		}
+	} else {
+		viper.SetDefault("access_cache.ttl", time.Minute)
+	}
+	viper.SetDefault("access_cache.listen_retry", time.Second)
+	// OUTBOX
+	// нулевой интервал отключает доставку событий изменений подписчикам
+	if dispatchInterval := os.Getenv("OUTBOX_DISPATCH_INTERVAL"); dispatchInterval != "" {
+		interval, err := time.ParseDuration(dispatchInterval)
+		if err != nil {
+			logger.Info("you've passed incorrect value of env variable 'OUTBOX_DISPATCH_INTERVAL', so it will be with default value 1s")
+			viper.SetDefault("outbox.dispatch_interval", time.Second)
+		} else {
+			viper.SetDefault("outbox.dispatch_interval", interval)
+		}
+	} else {
+		viper.SetDefault("outbox.dispatch_interval", time.Second)
+	}
+	// адреса вебхуков через запятую
+	if webhookURLs := os.Getenv("OUTBOX_WEBHOOK_URLS"); webhookURLs != "" {
+		viper.SetDefault("outbox.webhook.urls", strings.Split(webhookURLs, ","))
+	} else {
+		viper.SetDefault("outbox.webhook.urls", []string{})
+	}
+	if webhookSecret := os.Getenv("OUTBOX_WEBHOOK_SECRET"); webhookSecret != "" {
+		viper.SetDefault("outbox.webhook.secret", webhookSecret)
+	} else {
+		viper.SetDefault("outbox.webhook.secret", "")
+	}
+	// путь к файлу или stdout, пустое значение отключает запись событий в файл
+	viper.SetDefault("outbox.file", os.Getenv("OUTBOX_FILE"))
+	if retention := os.Getenv("OUTBOX_RETENTION"); retention != "" {
+		duration, err := time.ParseDuration(retention)
+		if err != nil {
+			logger.Info("you've passed incorrect value of env variable 'OUTBOX_RETENTION', so it will be with default value 168h")
+			viper.SetDefault("outbox.retention", 168*time.Hour)
+		} else {
+			viper.SetDefault("outbox.retention", duration)
+		}
+	} else {
+		viper.SetDefault("outbox.retention", 168*time.Hour)
+	}
+	viper.SetDefault("outbox.webhook.timeout", 5*time.Second)
+	viper.SetDefault("outbox.batch_size", 100)
+	viper.SetDefault("outbox.lease", time.Minute)
+	viper.SetDefault("outbox.max_attempts", 10)
+	viper.SetDefault("outbox.retry_backoff", time.Second)
+	viper.SetDefault("outbox.max_backoff", 10*time.Minute)
 	// SERVER
 	if address := os.Getenv("PS_SERVER_ADDRESS"); address != "" {
 		viper.SetDefault("server.address", address)
 	} else {
 		viper.SetDefault("server.address", ":8010")
 	}
+	// пустой адрес gRPC сервера отключает gRPC API
+	if address, ok := os.LookupEnv("PS_GRPC_ADDRESS"); ok {
+		viper.SetDefault("grpc.address", address)
+	} else {
+		viper.SetDefault("grpc.address", ":9010")
+	}
 
 	if writeTimeout := os.Getenv("PS_SERVER_WRITE_TIMEOUT"); writeTimeout != "" {
 		timeout, err := time.ParseDuration(writeTimeout)
@@ -129,7 +330,33 @@ func Read(configFilePath string, logger *zap.Logger) {
 			logger.Fatal(fmt.Sprintf("fatal error config file: %v", err))
 		}
 		logger.Warn(fmt.Sprintf("configuration file is not found, programm will be executed within default configuration: %v", err))
+		checkAuthSecret(logger)
+		checkWebhookSecret(logger)
 		return
 	}
 	logger.Info("successful read of configuration")
+	checkAuthSecret(logger)
+	checkWebhookSecret(logger)
+}
+
+// checkAuthSecret останавливает сервис, если секрет подписи токенов не задан или совпадает с примером из репозитория:
+// с известным секретом любой может подписать токен доступа от имени любого пользователя, в том числе root.
+func checkAuthSecret(logger *zap.Logger) {
+	secret := viper.GetString("auth.secret")
+	if secret == "" || secret == authSecretExample {
+		logger.Fatal("secret for signing tokens is not set or is insecure, set env variable 'AUTH_SECRET' or 'auth.secret' in configuration file")
+	}
+}
+
+// checkWebhookSecret останавливает сервис, если заданы адреса вебхуков outbox, но не задан секрет подписи:
+// без секрета подписчик не может отличить события сервиса от поддельных.
+func checkWebhookSecret(logger *zap.Logger) {
+	if viper.GetString("outbox.webhook.secret") != "" {
+		return
+	}
+	for _, url := range viper.GetStringSlice("outbox.webhook.urls") {
+		if strings.TrimSpace(url) != "" {
+			logger.Fatal("secret for signing outbox webhooks is not set, set env variable 'OUTBOX_WEBHOOK_SECRET' or 'outbox.webhook.secret' in configuration file")
+		}
+	}
 }
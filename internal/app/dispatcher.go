@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/repo/outbox"
+	"github.com/cantylv/authorization-service/services/sink"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// runDispatcher раз в interval доставляет события из outbox всем получателям sinks, пока не будет отменен ctx.
+// Событие считается доставленным, только если его приняли все получатели, иначе доставка повторяется всем
+// получателям с экспоненциально растущей паузой от outbox.retry_backoff до outbox.max_backoff. После
+// outbox.max_attempts неудачных попыток событие помечается неотправленным и больше не доставляется.
+func runDispatcher(ctx context.Context, repo outbox.Repo, sinks []sink.Sink, interval time.Duration, logger *zap.Logger) {
+	batchSize := max(viper.GetInt("outbox.batch_size"), 1)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// разбираем outbox, пока не кончатся готовые к доставке события
+			for ctx.Err() == nil {
+				events, err := repo.Claim(ctx, batchSize, viper.GetDuration("outbox.lease"))
+				if err != nil {
+					if ctx.Err() == nil {
+						logger.Error(fmt.Sprintf("error while claiming outbox events: %v", err))
+					}
+					break
+				}
+				for _, event := range events {
+					dispatch(ctx, repo, sinks, event, logger)
+				}
+				if len(events) < batchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// dispatch доставляет событие всем получателям и сохраняет результат доставки. Если результат сохранить не
+// удалось, событие будет доставлено повторно после истечения outbox.lease.
+func dispatch(ctx context.Context, repo outbox.Repo, sinks []sink.Sink, event *ent.ChangeEvent, logger *zap.Logger) {
+	var errs []error
+	for _, s := range sinks {
+		if err := s.Send(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.Name(), err))
+		}
+	}
+	var err error
+	switch sendErr := errors.Join(errs...); {
+	case sendErr == nil:
+		err = repo.MarkDelivered(ctx, event.ID)
+	case event.Attempts >= viper.GetInt("outbox.max_attempts"):
+		logger.Error("outbox event delivery failed, no attempts left",
+			zap.Int64("event_id", event.ID), zap.String("event", event.Event), zap.Int("attempts", event.Attempts),
+			zap.Error(sendErr))
+		err = repo.MarkFailed(ctx, event.ID, sendErr.Error())
+	default:
+		delay := retryDelay(event.Attempts)
+		logger.Warn("outbox event delivery failed, it will be retried",
+			zap.Int64("event_id", event.ID), zap.String("event", event.Event), zap.Int("attempts", event.Attempts),
+			zap.Duration("retry_in", delay), zap.Error(sendErr))
+		err = repo.Retry(ctx, event.ID, sendErr.Error(), time.Now().Add(delay))
+	}
+	if err != nil && ctx.Err() == nil {
+		logger.Error(fmt.Sprintf("error while saving outbox event delivery result: %v", err), zap.Int64("event_id", event.ID))
+	}
+}
+
+// retryDelay пауза перед следующей попыткой доставки: outbox.retry_backoff, удваивающаяся с каждой
+// неудачной попыткой, но не больше outbox.max_backoff.
+func retryDelay(attempts int) time.Duration {
+	delay, maxDelay := viper.GetDuration("outbox.retry_backoff"), viper.GetDuration("outbox.max_backoff")
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
@@ -3,12 +3,20 @@ package app
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 
 	"github.com/cantylv/authorization-service/internal/delivery/route"
+	"github.com/cantylv/authorization-service/internal/repo/decision"
+	"github.com/cantylv/authorization-service/internal/repo/outbox"
+	"github.com/cantylv/authorization-service/internal/repo/sweeper"
+	uDecision "github.com/cantylv/authorization-service/internal/usecase/decision"
+	uPrivelege "github.com/cantylv/authorization-service/internal/usecase/privelege"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
 	"github.com/cantylv/authorization-service/services/postgres"
+	"github.com/cantylv/authorization-service/services/sink"
 	"github.com/gorilla/mux"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
@@ -18,14 +26,31 @@ import (
 func Run(logger *zap.Logger) {
 	// init psql
 	postgresClient := postgres.Init(logger)
-	defer func() {
-		err := postgresClient.Close(context.Background())
-		logger.Error(fmt.Sprintf("error while closing connection with psql: %v", err))
-	}()
+	defer postgresClient.Close()
 	// define handlers
 	r := mux.NewRouter()
+	// журнал решений о доступе пишется в фоне, чтобы не замедлять проверки доступа
+	decisionLogger := uDecision.NewLoggerLayer(decision.NewRepoLayer(postgresClient), logger)
+	decisionCtx, stopDecisionLogger := context.WithCancel(context.Background())
+	defer stopDecisionLogger()
+	decisionLoggerDone := make(chan struct{})
+	go func() {
+		decisionLogger.Run(decisionCtx)
+		close(decisionLoggerDone)
+	}()
+	// кэш проверок доступа сбрасывается по уведомлениям триггеров базы данных, в том числе об изменениях,
+	// сделанных другими репликами
+	accessCache := uPrivelege.NewAccessCache()
+	listenerCtx, stopListener := context.WithCancel(context.Background())
+	defer stopListener()
+	if accessCache.Enabled() {
+		go postgresClient.Listen(listenerCtx, mc.AccessCacheChannel, viper.GetDuration("access_cache.listen_retry"),
+			accessCache.Reset, accessCache.Invalidate, logger)
+	} else {
+		logger.Warn("access decision cache is disabled")
+	}
 	// run server
-	handler := route.InitHTTPHandlers(r, postgresClient, logger)
+	handler := route.InitHTTPHandlers(r, postgresClient, decisionLogger, accessCache, logger)
 	srv := &http.Server{
 		Handler:      handler,
 		Addr:         viper.GetString("server.address"),
@@ -34,6 +59,34 @@ func Run(logger *zap.Logger) {
 		IdleTimeout:  viper.GetDuration("server.idle_timeout"),
 	}
 
+	// фоновая очистка истекших привелегий
+	sweeperCtx, stopSweeper := context.WithCancel(context.Background())
+	defer stopSweeper()
+	if interval := viper.GetDuration("sweeper.interval"); interval > 0 {
+		go runSweeper(sweeperCtx, sweeper.NewRepoLayer(postgresClient), interval, viper.GetDuration("decision_log.retention"),
+			viper.GetDuration("outbox.retention"), logger)
+	} else {
+		logger.Warn("sweeper of expired grants is disabled")
+	}
+
+	// доставка событий изменений из outbox подписчикам
+	sinks, closeSinks, err := sink.FromConfig()
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("error while initializing outbox sinks: %v", err))
+	}
+	dispatcherCtx, stopDispatcher := context.WithCancel(context.Background())
+	defer stopDispatcher()
+	dispatcherDone := make(chan struct{})
+	if interval := viper.GetDuration("outbox.dispatch_interval"); interval > 0 && len(sinks) != 0 {
+		go func() {
+			runDispatcher(dispatcherCtx, outbox.NewRepoLayer(postgresClient), sinks, interval, logger)
+			close(dispatcherDone)
+		}()
+	} else {
+		logger.Warn("outbox dispatcher is disabled, change events are not delivered")
+		close(dispatcherDone)
+	}
+
 	go func() {
 		logger.Info(fmt.Sprintf("server has started at the address %s", viper.GetString("server.address")))
 		if err := srv.ListenAndServe(); err != nil {
@@ -41,14 +94,51 @@ func Run(logger *zap.Logger) {
 		}
 	}()
 
+	// gRPC API работает с теми же usecase, кэшем и журналом решений, что и HTTP API
+	grpcSrv := route.InitGRPCServer(postgresClient, decisionLogger, accessCache, logger)
+	grpcDone := make(chan struct{})
+	if address := viper.GetString("grpc.address"); address != "" {
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			logger.Fatal(fmt.Sprintf("error while listening grpc address %s: %v", address, err))
+		}
+		go func() {
+			logger.Info(fmt.Sprintf("grpc server has started at the address %s", address))
+			if err := grpcSrv.Serve(listener); err != nil {
+				logger.Warn(fmt.Sprintf("error after end of receiving grpc requests: %v", err))
+			}
+			close(grpcDone)
+		}()
+	} else {
+		logger.Warn("grpc server is disabled")
+		close(grpcDone)
+	}
+
 	// graceful shutdown
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
 	<-c
+	stopSweeper()
+	stopListener()
+	stopDispatcher()
+	<-dispatcherDone
+	if err := closeSinks(); err != nil {
+		logger.Error(fmt.Sprintf("error while closing outbox sinks: %v", err))
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("server.shutdown_duration"))
 	defer cancel()
-	err := srv.Shutdown(ctx)
+	// gRPC вызовы, не завершившиеся за server.shutdown_duration, прерываются
+	go func() {
+		<-ctx.Done()
+		grpcSrv.Stop()
+	}()
+	grpcSrv.GracefulStop()
+	<-grpcDone
+	err = srv.Shutdown(ctx)
+	// новых проверок доступа больше не будет, дописываем оставшиеся в буфере решения
+	stopDecisionLogger()
+	<-decisionLoggerDone
 	if err != nil {
 		logger.Error(fmt.Sprintf("server has shut down with an error: %v", err))
 		os.Exit(1)
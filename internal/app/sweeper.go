@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cantylv/authorization-service/internal/repo/sweeper"
+	"go.uber.org/zap"
+)
+
+// runSweeper периодически удаляет привелегии и участия в группах с истекшим сроком действия, помечает истекшие
+// приглашения в группы и удаляет записи журнала решений о доступе старше decisionRetention и события outbox старше
+// outboxRetention, пока не будет отменен ctx. Нулевой срок хранения отключает соответствующее удаление.
+// Проверка доступа не учитывает истекшие записи и без очистки, поэтому ошибка очистки только логируется.
+func runSweeper(ctx context.Context, repo sweeper.Repo, interval, decisionRetention, outboxRetention time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := repo.ExpireInvitations(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.Error(fmt.Sprintf("error while expiring group invitations: %v", err))
+				}
+			} else if expired != 0 {
+				logger.Info("expired group invitations were marked", zap.Int("count", expired))
+			}
+			if decisionRetention > 0 {
+				removed, err := repo.DeleteOldDecisions(ctx, time.Now().Add(-decisionRetention))
+				if err != nil {
+					if ctx.Err() == nil {
+						logger.Error(fmt.Sprintf("error while deleting old access decisions: %v", err))
+					}
+				} else if removed != 0 {
+					logger.Info("old access decisions were removed", zap.Int("count", removed))
+				}
+			}
+			if outboxRetention > 0 {
+				removed, err := repo.DeleteOldChangeEvents(ctx, time.Now().Add(-outboxRetention))
+				if err != nil {
+					if ctx.Err() == nil {
+						logger.Error(fmt.Sprintf("error while deleting old outbox events: %v", err))
+					}
+				} else if removed != 0 {
+					logger.Info("old outbox events were removed", zap.Int("count", removed))
+				}
+			}
+			grants, err := repo.DeleteExpired(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.Error(fmt.Sprintf("error while deleting expired grants: %v", err))
+				}
+				continue
+			}
+			for _, g := range grants {
+				logger.Info("expired grant was removed",
+					zap.String("table", g.Table),
+					zap.Int("row_id", g.RowID),
+					zap.String("user_id", g.UserID),
+					zap.Int("group_id", g.GroupID),
+					zap.Int("agent_id", g.AgentID),
+					zap.Int("action_id", g.ActionID),
+					zap.Time("valid_until", g.ValidUntil),
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package accessrequest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/internal/usecase/accessrequest"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+type AccessRequestHandlerManager struct {
+	logger               *zap.Logger
+	usecaseAccessRequest accessrequest.Usecase
+}
+
+// NewAccessRequestHandlerManager возвращает менеджер хендлеров, отвечающих за заявки на временный доступ к агентам:
+// создание, просмотр, одобрение, отклонение и отмену заявки.
+func NewAccessRequestHandlerManager(usecaseAccessRequest accessrequest.Usecase, logger *zap.Logger) *AccessRequestHandlerManager {
+	return &AccessRequestHandlerManager{
+		logger:               logger,
+		usecaseAccessRequest: usecaseAccessRequest,
+	}
+}
+
+// CreateAccessRequest создает заявку аутентифицированного пользователя на доступ к агенту
+func (h *AccessRequestHandlerManager) CreateAccessRequest(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	var requestData dto.CreateAccessRequestData
+	err = json.Unmarshal(body, &requestData)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	err = requestData.Validate()
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	accessRequest, err := h.usecaseAccessRequest.Create(r.Context(), &requestData)
+	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, me.ErrAgentNotExist) ||
+			errors.Is(err, me.ErrAgentActionNotExist) ||
+			errors.Is(err, me.ErrGroupNotExist) ||
+			errors.Is(err, me.ErrUserAlreadyInGroup) ||
+			errors.Is(err, me.ErrGroupHasNoAccess) ||
+			errors.Is(err, me.ErrUserAgentAlreadyExist) ||
+			errors.Is(err, me.ErrAccessRequestAlreadyExist) ||
+			errors.Is(err, me.ErrInvalidRequestDuration) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	f.Response(w, accessRequest, http.StatusOK)
+}
+
+// GetAccessRequests возвращает список заявок. Параметры запроса status, agent, group и email фильтруют список.
+func (h *AccessRequestHandlerManager) GetAccessRequests(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	query := r.URL.Query()
+	filter := dto.AccessRequestFilter{
+		Status: query.Get("status"),
+		Agent:  query.Get("agent"),
+		Group:  query.Get("group"),
+		Email:  query.Get("email"),
+	}
+	err = filter.Validate()
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	requests, err := h.usecaseAccessRequest.List(r.Context(), &filter)
+	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	if requests == nil {
+		requests = make([]*ent.AccessRequest, 0)
+	}
+	f.Response(w, requests, http.StatusOK)
+}
+
+// ApproveAccessRequest одобряет заявку и выдает доступ на запрошенный срок
+func (h *AccessRequestHandlerManager) ApproveAccessRequest(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.usecaseAccessRequest.Approve)
+}
+
+// RejectAccessRequest отклоняет заявку
+func (h *AccessRequestHandlerManager) RejectAccessRequest(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.usecaseAccessRequest.Reject)
+}
+
+// CancelAccessRequest отменяет заявку, доступно только автору заявки
+func (h *AccessRequestHandlerManager) CancelAccessRequest(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.usecaseAccessRequest.Cancel)
+}
+
+// changeStatus общая часть хендлеров, меняющих статус заявки с идентификатором из пути запроса
+func (h *AccessRequestHandlerManager) changeStatus(w http.ResponseWriter, r *http.Request,
+	change func(ctx context.Context, id int) (*ent.AccessRequest, error)) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	id, err := strconv.Atoi(pathVars["request_id"])
+	if err != nil || id < 1 {
+		h.logger.Info(me.ErrInvalidAccessRequestID.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidAccessRequestID.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	accessRequest, err := change(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, me.ErrAccessRequestNotExist) ||
+			errors.Is(err, me.ErrAccessRequestIsReviewed) ||
+			errors.Is(err, me.ErrUserAlreadyInGroup) ||
+			errors.Is(err, me.ErrGroupHasNoAccess) ||
+			errors.Is(err, me.ErrUserAgentAlreadyExist) ||
+			errors.Is(err, me.ErrUserActionAlreadyExist) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, me.ErrPermissionDenied) ||
+			errors.Is(err, me.ErrCantReviewOwnAccessRequest) ||
+			errors.Is(err, me.ErrOnlyRequesterCanCancel) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	f.Response(w, accessRequest, http.StatusOK)
+}
@@ -1,10 +1,11 @@
 package agent
 
 import (
+	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 
-	"github.com/asaskevich/govalidator"
 	ent "github.com/cantylv/authorization-service/internal/entity"
 	"github.com/cantylv/authorization-service/internal/entity/dto"
 	"github.com/cantylv/authorization-service/internal/usecase/agent"
@@ -20,8 +21,8 @@ type AgentHandlerManager struct {
 	usecaseAgent agent.Usecase
 }
 
-// NewAgentHandlerManager возвращает менеджер хендлеров, отвечающих за создание агентов. Работают только для root
-// пользователя.
+// NewAgentHandlerManager возвращает менеджер хендлеров, отвечающих за создание агентов. Работают только для пользователей
+// с соответствующими правами.
 func NewAgentHandlerManager(usecaseAgent agent.Usecase, logger *zap.Logger) *AgentHandlerManager {
 	return &AgentHandlerManager{
 		logger:       logger,
@@ -30,24 +31,44 @@ func NewAgentHandlerManager(usecaseAgent agent.Usecase, logger *zap.Logger) *Age
 }
 
 // AddAgent добавляет агента, который обрабатывает сооответствующие ему запросы
-// Создать агента может только root
+// Создать агента может пользователь с правом agent.create. В необязательном теле запроса передаются действия агента.
 func (h *AgentHandlerManager) CreateAgent(w http.ResponseWriter, r *http.Request) {
 	requestID, err := f.GetCtxRequestID(r)
 	if err != nil {
 		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
 	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	var agentData dto.CreateAgentData
+	if len(body) != 0 {
+		err = json.Unmarshal(body, &agentData)
+		if err != nil {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+	err = agentData.Validate()
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
 
 	pathVars := mux.Vars(r)
 	agentName := pathVars["agent_name"]
-	emailCreate := pathVars["email_create"]
-	if !govalidator.IsEmail(emailCreate) {
-		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
-		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
-		return
-	}
-	a, err := h.usecaseAgent.CreateAgent(r.Context(), emailCreate, agentName)
+	a, err := h.usecaseAgent.CreateAgent(r.Context(), agentName, agentData.Actions)
 	if err != nil {
-		if errors.Is(err, me.ErrOnlyRootCanAddAgent) {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, me.ErrPermissionDenied) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
 			return
@@ -65,7 +86,7 @@ func (h *AgentHandlerManager) CreateAgent(w http.ResponseWriter, r *http.Request
 }
 
 // DeleteAgent удаляет агента, который обрабатывает сооответствующие ему запросы
-// Удалить агента может только root
+// Удалить агента может пользователь с правом agent.delete
 func (h *AgentHandlerManager) DeleteAgent(w http.ResponseWriter, r *http.Request) {
 	requestID, err := f.GetCtxRequestID(r)
 	if err != nil {
@@ -74,15 +95,14 @@ func (h *AgentHandlerManager) DeleteAgent(w http.ResponseWriter, r *http.Request
 
 	pathVars := mux.Vars(r)
 	agentName := pathVars["agent_name"]
-	emailDelete := pathVars["email_delete"]
-	if !govalidator.IsEmail(emailDelete) {
-		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
-		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
-		return
-	}
-	err = h.usecaseAgent.DeleteAgent(r.Context(), emailDelete, agentName)
+	err = h.usecaseAgent.DeleteAgent(r.Context(), agentName)
 	if err != nil {
-		if errors.Is(err, me.ErrOnlyRootCanDeleteAgent) {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, me.ErrPermissionDenied) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
 			return
@@ -100,23 +120,21 @@ func (h *AgentHandlerManager) DeleteAgent(w http.ResponseWriter, r *http.Request
 }
 
 // GetAgents возвращает список всех агентов
-// Получить список может только root
+// Получить список может пользователь с правом agent.read
 func (h *AgentHandlerManager) GetAgents(w http.ResponseWriter, r *http.Request) {
 	requestID, err := f.GetCtxRequestID(r)
 	if err != nil {
 		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
 	}
 
-	pathVars := mux.Vars(r)
-	emailRead := pathVars["email_read"]
-	if !govalidator.IsEmail(emailRead) {
-		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
-		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
-		return
-	}
-	a, err := h.usecaseAgent.GetAgents(r.Context(), emailRead)
+	a, err := h.usecaseAgent.GetAgents(r.Context())
 	if err != nil {
-		if errors.Is(err, me.ErrOnlyRootCanGetAgents) {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, me.ErrPermissionDenied) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
 			return
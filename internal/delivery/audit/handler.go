@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/internal/usecase/audit"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"go.uber.org/zap"
+)
+
+type AuditHandlerManager struct {
+	logger       *zap.Logger
+	usecaseAudit audit.Usecase
+}
+
+// NewAuditHandlerManager возвращает менеджер хендлеров, отвечающих за журнал аудита.
+func NewAuditHandlerManager(usecaseAudit audit.Usecase, logger *zap.Logger) *AuditHandlerManager {
+	return &AuditHandlerManager{
+		logger:       logger,
+		usecaseAudit: usecaseAudit,
+	}
+}
+
+// GetAuditLog возвращает страницу журнала аудита с параметрами limit, offset и фильтрами actor, action, target_type,
+// target, request_id. Параметры from и to в формате RFC3339 ограничивают время записи. Требует права audit.read.
+func (h *AuditHandlerManager) GetAuditLog(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	page, err := f.GetQueryPagination(r)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	filter := dto.AuditFilter{
+		Pagination: page,
+		Actor:      query.Get("actor"),
+		Action:     query.Get("action"),
+		TargetType: query.Get("target_type"),
+		Target:     query.Get("target"),
+		RequestID:  query.Get("request_id"),
+	}
+	filter.From, err = parseQueryTime(query.Get("from"))
+	if err == nil {
+		filter.To, err = parseQueryTime(query.Get("to"))
+	}
+	if err == nil {
+		err = filter.Validate()
+	}
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	entries, err := h.usecaseAudit.List(r.Context(), &filter)
+	if err != nil {
+		h.responseError(w, requestID, err)
+		return
+	}
+	f.Response(w, entries, http.StatusOK)
+}
+
+// VerifyAuditLog пересчитывает цепочку хэшей журнала аудита и возвращает результат проверки. Требует права
+// audit.read.
+func (h *AuditHandlerManager) VerifyAuditLog(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	verification, err := h.usecaseAudit.Verify(r.Context())
+	if err != nil {
+		h.responseError(w, requestID, err)
+		return
+	}
+	f.Response(w, verification, http.StatusOK)
+}
+
+func (h *AuditHandlerManager) responseError(w http.ResponseWriter, requestID string, err error) {
+	if errors.Is(err, me.ErrUnauthorized) {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+		return
+	}
+	if errors.Is(err, me.ErrPermissionDenied) {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
+		return
+	}
+	h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+}
+
+// parseQueryTime разбирает время из параметра запроса, пустой параметр означает отсутствие ограничения.
+func parseQueryTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, me.ErrInvalidTimeRange
+	}
+	return &t, nil
+}
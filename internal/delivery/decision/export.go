@@ -0,0 +1,80 @@
+package decision
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+)
+
+// csvHeader столбцы выгрузки журнала решений в формате csv
+var csvHeader = []string{"id", "checked_at", "caller_id", "caller", "user", "agent", "action", "real_ip", "allowed",
+	"decision", "failed_check", "request_id"}
+
+// exporter пишет записи журнала решений в ответ в формате csv или jsonl. Заголовки ответа отправляются
+// при первой записи или при закрытии пустой выгрузки.
+type exporter struct {
+	w       http.ResponseWriter
+	format  string
+	csv     *csv.Writer
+	json    *json.Encoder
+	started bool
+}
+
+func newExporter(w http.ResponseWriter, format string) (*exporter, error) {
+	e := &exporter{w: w, format: format}
+	switch format {
+	case mc.ExportFormatCSV:
+		e.csv = csv.NewWriter(w)
+	case mc.ExportFormatJSONL:
+		e.json = json.NewEncoder(w)
+	default:
+		return nil, me.ErrInvalidExportFormat
+	}
+	return e, nil
+}
+
+func (e *exporter) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	filename := fmt.Sprintf("access_decisions_%s.%s", time.Now().UTC().Format("20060102T150405Z"), e.format)
+	e.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if e.csv != nil {
+		e.w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		e.w.WriteHeader(http.StatusOK)
+		return e.csv.Write(csvHeader)
+	}
+	e.w.Header().Set("Content-Type", "application/x-ndjson")
+	e.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (e *exporter) write(d *ent.AccessDecision) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	if e.json != nil {
+		return e.json.Encode(d)
+	}
+	return e.csv.Write([]string{strconv.FormatInt(d.ID, 10), d.CheckedAt.Format(time.RFC3339Nano), d.CallerID,
+		d.Caller, d.User, d.Agent, d.Action, d.RealIP, strconv.FormatBool(d.Allowed), d.Decision, d.FailedCheck, d.RequestID})
+}
+
+func (e *exporter) close() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	if e.csv != nil {
+		e.csv.Flush()
+		return e.csv.Error()
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package decision
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/internal/usecase/decision"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"go.uber.org/zap"
+)
+
+type DecisionHandlerManager struct {
+	logger          *zap.Logger
+	usecaseDecision decision.Usecase
+}
+
+// NewDecisionHandlerManager возвращает менеджер хендлеров, отвечающих за журнал решений о доступе.
+func NewDecisionHandlerManager(usecaseDecision decision.Usecase, logger *zap.Logger) *DecisionHandlerManager {
+	return &DecisionHandlerManager{
+		logger:          logger,
+		usecaseDecision: usecaseDecision,
+	}
+}
+
+// GetDecisions возвращает страницу журнала решений о доступе с параметрами limit, offset и фильтрами caller, user,
+// agent, action, real_ip, allowed. Параметры from и to в формате RFC3339 ограничивают время проверки.
+// Требует права decision_log.read.
+func (h *DecisionHandlerManager) GetDecisions(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	filter, err := parseFilter(r)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	decisions, err := h.usecaseDecision.List(r.Context(), filter)
+	if err != nil {
+		h.responseError(w, requestID, err)
+		return
+	}
+	f.Response(w, decisions, http.StatusOK)
+}
+
+// ExportDecisions выгружает все записи журнала решений о доступе, подходящие под те же фильтры, что и GetDecisions,
+// в формате format: csv или jsonl. Записи передаются клиенту по мере чтения из базы данных. Требует права
+// decision_log.read.
+func (h *DecisionHandlerManager) ExportDecisions(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	filter, err := parseFilter(r)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	exporter, err := newExporter(w, r.URL.Query().Get("format"))
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	// заголовки ответа отправляются с первой записью, чтобы до нее можно было ответить ошибкой
+	err = h.usecaseDecision.Export(r.Context(), filter, func(d *ent.AccessDecision) error {
+		return exporter.write(d)
+	})
+	if err == nil {
+		err = exporter.close()
+	}
+	if err != nil {
+		if !exporter.started {
+			h.responseError(w, requestID, err)
+			return
+		}
+		// часть выгрузки уже отправлена, сменить статус ответа нельзя
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+}
+
+func (h *DecisionHandlerManager) responseError(w http.ResponseWriter, requestID string, err error) {
+	if errors.Is(err, me.ErrUnauthorized) {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+		return
+	}
+	if errors.Is(err, me.ErrPermissionDenied) {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
+		return
+	}
+	h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+}
+
+// parseFilter разбирает и проверяет фильтр и страницу журнала решений из параметров запроса.
+func parseFilter(r *http.Request) (*dto.DecisionFilter, error) {
+	page, err := f.GetQueryPagination(r)
+	if err != nil {
+		return nil, err
+	}
+	query := r.URL.Query()
+	filter := &dto.DecisionFilter{
+		Pagination: page,
+		Caller:     query.Get("caller"),
+		User:       query.Get("user"),
+		Agent:      query.Get("agent"),
+		Action:     query.Get("action"),
+		RealIP:     query.Get("real_ip"),
+	}
+	if allowed := query.Get("allowed"); allowed != "" {
+		value, err := strconv.ParseBool(allowed)
+		if err != nil {
+			return nil, me.ErrInvalidAllowedFilter
+		}
+		filter.Allowed = &value
+	}
+	if filter.From, err = parseQueryTime(query.Get("from")); err != nil {
+		return nil, err
+	}
+	if filter.To, err = parseQueryTime(query.Get("to")); err != nil {
+		return nil, err
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// parseQueryTime разбирает время из параметра запроса, пустой параметр означает отсутствие ограничения.
+func parseQueryTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, me.ErrInvalidTimeRange
+	}
+	return &t, nil
+}
@@ -1,9 +1,12 @@
 package group
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/asaskevich/govalidator"
 	ent "github.com/cantylv/authorization-service/internal/entity"
@@ -22,7 +25,8 @@ type GroupHandlerManager struct {
 }
 
 // NewGroupHandlerManager возвращает менеджер хендлеров, отвечающих за создание групп пользователей, добавление
-// пользователей в группы и удаление из них. Заявка на создание группы и принятие/отклонение её root пользователем.
+// пользователей в группы и удаление из них. Заявка на создание группы и принятие/отклонение её пользователем
+// с правом group.approve_bid.
 func NewGroupHandlerManager(usecaseGroup group.Usecase, logger *zap.Logger) *GroupHandlerManager {
 	return &GroupHandlerManager{
 		logger:       logger,
@@ -30,7 +34,8 @@ func NewGroupHandlerManager(usecaseGroup group.Usecase, logger *zap.Logger) *Gro
 	}
 }
 
-// AddUserToGroup позволяет добавить пользователя в группу. Добавить в группу может только ответственный за группу пользователь.
+// AddUserToGroup позволяет добавить пользователя в группу без его согласия. Это может сделать только пользователь с правом
+// group.manage, ответственные за группу отправляют приглашения. Необязательное тело запроса задает срок участия в группе.
 func (h *GroupHandlerManager) AddUserToGroup(w http.ResponseWriter, r *http.Request) {
 	requestID, err := f.GetCtxRequestID(r)
 	if err != nil {
@@ -44,15 +49,35 @@ func (h *GroupHandlerManager) AddUserToGroup(w http.ResponseWriter, r *http.Requ
 		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
 		return
 	}
-	inviteUserEmail := pathVars["email_invite"]
-	if !govalidator.IsEmail(inviteUserEmail) {
-		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
-		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	var period dto.GrantPeriodData
+	if len(body) != 0 {
+		err = json.Unmarshal(body, &period)
+		if err != nil {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+	err = period.Validate()
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
 		return
 	}
 
-	groupName, err = h.usecaseGroup.AddUserToGroup(r.Context(), userEmail, inviteUserEmail, groupName)
+	groupName, err = h.usecaseGroup.AddUserToGroup(r.Context(), userEmail, groupName, &period)
 	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
 		if errors.Is(err, me.ErrGroupNotExist) ||
 			errors.Is(err, me.ErrUserEmailMustBeDiff) ||
 			errors.Is(err, me.ErrUserNotExist) ||
@@ -61,7 +86,7 @@ func (h *GroupHandlerManager) AddUserToGroup(w http.ResponseWriter, r *http.Requ
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
 			return
 		}
-		if errors.Is(err, me.ErrOnlyOwnerCanAddUserToGroup) {
+		if errors.Is(err, me.ErrPermissionDenied) || errors.Is(err, me.ErrDirectAddRequiresManage) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
 			return
@@ -89,14 +114,13 @@ func (h *GroupHandlerManager) GetUserGroups(w http.ResponseWriter, r *http.Reque
 		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
 		return
 	}
-	askUserEmail := pathVars["email_ask"]
-	if !govalidator.IsEmail(askUserEmail) {
-		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
-		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
-		return
-	}
-	groups, err := h.usecaseGroup.GetUserGroups(r.Context(), userEmail, askUserEmail)
+	groups, err := h.usecaseGroup.GetUserGroups(r.Context(), userEmail)
 	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
 		if errors.Is(err, me.ErrUserNotExist) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
@@ -125,14 +149,13 @@ func (h *GroupHandlerManager) KickOutUser(w http.ResponseWriter, r *http.Request
 		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
 		return
 	}
-	kickUserEmail := pathVars["email_kick"]
-	if !govalidator.IsEmail(kickUserEmail) {
-		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
-		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
-		return
-	}
-	groupName, err = h.usecaseGroup.KickUserFromGroup(r.Context(), userEmail, kickUserEmail, groupName)
+	groupName, err = h.usecaseGroup.KickUserFromGroup(r.Context(), userEmail, groupName)
 	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
 		if errors.Is(err, me.ErrGroupNotExist) ||
 			errors.Is(err, me.ErrDeleteRootFromGroup) ||
 			errors.Is(err, me.ErrUserNotExist) ||
@@ -142,7 +165,7 @@ func (h *GroupHandlerManager) KickOutUser(w http.ResponseWriter, r *http.Request
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
 			return
 		}
-		if errors.Is(err, me.ErrOnlyOwnerCanDeleteUserFromGroup) {
+		if errors.Is(err, me.ErrPermissionDenied) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
 			return
@@ -154,6 +177,35 @@ func (h *GroupHandlerManager) KickOutUser(w http.ResponseWriter, r *http.Request
 	f.Response(w, dto.ResponseDetail{Detail: fmt.Sprintf("user was succesful deleted from group '%s'", groupName)}, http.StatusOK)
 }
 
+// LeaveGroup позволяет пользователю самому покинуть группу, ответственные за группу получают уведомление
+func (h *GroupHandlerManager) LeaveGroup(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	groupName, err := h.usecaseGroup.LeaveGroup(r.Context(), mux.Vars(r)["group_name"])
+	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, me.ErrGroupNotExist) ||
+			errors.Is(err, me.ErrDeleteRootFromGroup) ||
+			errors.Is(err, me.ErrUserNotExist) ||
+			errors.Is(err, me.ErrUserIsNotInGroup) ||
+			errors.Is(err, me.ErrOwnerCantExitFromGroup) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	f.Response(w, dto.ResponseDetail{Detail: fmt.Sprintf("you have left group '%s'", groupName)}, http.StatusOK)
+}
+
 // RequestToCreateGroup создает заявку пользователя на создание группы пользователей, у которой будут
 // в будущем свои права на выполнение различных процессов.
 func (h *GroupHandlerManager) RequestToCreateGroup(w http.ResponseWriter, r *http.Request) {
@@ -163,14 +215,13 @@ func (h *GroupHandlerManager) RequestToCreateGroup(w http.ResponseWriter, r *htt
 	}
 	pathVars := mux.Vars(r)
 	groupName := pathVars["group_name"]
-	userEmail := pathVars["email_add"]
-	if !govalidator.IsEmail(userEmail) {
-		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
-		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
-		return
-	}
-	bid, err := h.usecaseGroup.MakeRequestToCreateGroup(r.Context(), userEmail, groupName)
+	bid, err := h.usecaseGroup.MakeRequestToCreateGroup(r.Context(), groupName)
 	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
 		if errors.Is(err, me.ErrUserNotExist) ||
 			errors.Is(err, me.ErrGroupAlreadyExist) ||
 			errors.Is(err, me.ErrBidAlreadyExist) {
@@ -199,24 +250,28 @@ func (h *GroupHandlerManager) ChangeBidStatus(w http.ResponseWriter, r *http.Req
 		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
 		return
 	}
-	userChangeStatus := pathVars["email_change_status"]
-	if !govalidator.IsEmail(userChangeStatus) {
-		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
-		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
+	bidStatus := r.URL.Query().Get("status")
+	review, ok := h.readBidReview(w, r, requestID)
+	if !ok {
 		return
 	}
-	bidStatus := r.URL.Query().Get("status")
-	bid, err := h.usecaseGroup.UpdateRequestStatus(r.Context(), userEmail, groupName, userChangeStatus, bidStatus)
+	bid, err := h.usecaseGroup.UpdateRequestStatus(r.Context(), userEmail, groupName, bidStatus, review.Comment)
 	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
 		if errors.Is(err, me.ErrInvalidStatus) ||
 			errors.Is(err, me.ErrUserNotExist) ||
 			errors.Is(err, me.ErrBidNotExist) ||
+			errors.Is(err, me.ErrBidIsReviewed) ||
 			errors.Is(err, me.ErrGroupAlreadyExist) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
 			return
 		}
-		if errors.Is(err, me.ErrOnlyRootCanChangeBidStatus) {
+		if errors.Is(err, me.ErrPermissionDenied) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
 			return
@@ -228,6 +283,247 @@ func (h *GroupHandlerManager) ChangeBidStatus(w http.ResponseWriter, r *http.Req
 	f.Response(w, bid, http.StatusOK)
 }
 
+// AttachSubgroup делает группу подгруппой другой группы, участники подгруппы наследуют привелегии родительской группы.
+// Привязать может ответственный за обе группы или пользователь с правом group.manage.
+func (h *GroupHandlerManager) AttachSubgroup(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	subgroupName := pathVars["subgroup_name"]
+	err = h.usecaseGroup.AttachSubgroup(r.Context(), groupName, subgroupName)
+	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, me.ErrGroupNotExist) ||
+			errors.Is(err, me.ErrGroupCycle) ||
+			errors.Is(err, me.ErrGroupAlreadyHasParent) ||
+			errors.Is(err, me.ErrSubgroupAlreadyAttached) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, me.ErrPermissionDenied) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	f.Response(w, dto.ResponseDetail{Detail: fmt.Sprintf("group '%s' was succesful attached to group '%s'", subgroupName, groupName)}, http.StatusOK)
+}
+
+// DetachSubgroup отвязывает подгруппу от родительской группы
+func (h *GroupHandlerManager) DetachSubgroup(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	subgroupName := pathVars["subgroup_name"]
+	err = h.usecaseGroup.DetachSubgroup(r.Context(), groupName, subgroupName)
+	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, me.ErrGroupNotExist) ||
+			errors.Is(err, me.ErrSubgroupNotAttached) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, me.ErrPermissionDenied) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	f.Response(w, dto.ResponseDetail{Detail: fmt.Sprintf("group '%s' was succesful detached from group '%s'", subgroupName, groupName)}, http.StatusOK)
+}
+
+// GetSubgroups возвращает подгруппы первого уровня
+func (h *GroupHandlerManager) GetSubgroups(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	groupName := mux.Vars(r)["group_name"]
+	groups, err := h.usecaseGroup.GetSubgroups(r.Context(), groupName)
+	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, me.ErrGroupNotExist) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, me.ErrPermissionDenied) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	f.Response(w, groups, http.StatusOK)
+}
+
+// GetBids возвращает страницу заявок на создание группы с фильтрами status, email, group и параметрами limit, offset
+func (h *GroupHandlerManager) GetBids(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	page, err := f.GetQueryPagination(r)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	filter := dto.BidFilter{
+		Pagination: page,
+		Status:     query.Get("status"),
+		Email:      query.Get("email"),
+		Group:      query.Get("group"),
+	}
+	err = filter.Validate()
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	bids, err := h.usecaseGroup.GetBids(r.Context(), &filter)
+	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	f.Response(w, bids, http.StatusOK)
+}
+
+// GetBid возвращает заявку на создание группы вместе с историей ее статусов
+func (h *GroupHandlerManager) GetBid(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	bidID, ok := h.readBidID(w, r, requestID)
+	if !ok {
+		return
+	}
+
+	bid, err := h.usecaseGroup.GetBid(r.Context(), bidID)
+	if err != nil {
+		h.bidError(w, err, requestID)
+		return
+	}
+	f.Response(w, bid, http.StatusOK)
+}
+
+// CancelBid отзывает нерассмотренную заявку на создание группы. Необязательное тело запроса содержит комментарий.
+func (h *GroupHandlerManager) CancelBid(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	bidID, ok := h.readBidID(w, r, requestID)
+	if !ok {
+		return
+	}
+	review, ok := h.readBidReview(w, r, requestID)
+	if !ok {
+		return
+	}
+
+	bid, err := h.usecaseGroup.CancelBid(r.Context(), bidID, review.Comment)
+	if err != nil {
+		h.bidError(w, err, requestID)
+		return
+	}
+	f.Response(w, bid, http.StatusOK)
+}
+
+func (h *GroupHandlerManager) bidError(w http.ResponseWriter, err error, requestID string) {
+	if errors.Is(err, me.ErrUnauthorized) {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+		return
+	}
+	if errors.Is(err, me.ErrBidNotExist) || errors.Is(err, me.ErrBidIsReviewed) {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, me.ErrPermissionDenied) || errors.Is(err, me.ErrOnlyBidAuthorCanCancel) {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
+		return
+	}
+	h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+}
+
+// readBidID читает идентификатор заявки из пути запроса. Если он некорректен, пишет ответ и возвращает false.
+func (h *GroupHandlerManager) readBidID(w http.ResponseWriter, r *http.Request, requestID string) (int, bool) {
+	bidID, err := strconv.Atoi(mux.Vars(r)["bid_id"])
+	if err != nil || bidID < 1 {
+		h.logger.Info(me.ErrInvalidBidID.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidBidID.Error()}, http.StatusBadRequest)
+		return 0, false
+	}
+	return bidID, true
+}
+
+// readBidReview читает необязательный комментарий к заявке. Если тело некорректно, пишет ответ и возвращает false.
+func (h *GroupHandlerManager) readBidReview(w http.ResponseWriter, r *http.Request, requestID string) (*dto.BidReviewData, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return nil, false
+	}
+	var review dto.BidReviewData
+	if len(body) != 0 {
+		err = json.Unmarshal(body, &review)
+		if err != nil {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+			return nil, false
+		}
+	}
+	err = review.Validate()
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return nil, false
+	}
+	return &review, true
+}
+
 func (h *GroupHandlerManager) ChangeOwner(w http.ResponseWriter, r *http.Request) {
 	requestID, err := f.GetCtxRequestID(r)
 	if err != nil {
@@ -241,14 +537,13 @@ func (h *GroupHandlerManager) ChangeOwner(w http.ResponseWriter, r *http.Request
 		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
 		return
 	}
-	userChangeOwner := pathVars["email_change_owner"]
-	if !govalidator.IsEmail(userChangeOwner) {
-		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
-		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
-		return
-	}
-	g, err := h.usecaseGroup.ChangeOwner(r.Context(), userEmail, groupName, userChangeOwner)
+	g, err := h.usecaseGroup.ChangeOwner(r.Context(), userEmail, groupName)
 	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
 		if errors.Is(err, me.ErrGroupNotExist) ||
 			errors.Is(err, me.ErrUserNotExist) ||
 			errors.Is(err, me.ErrUserIsAlreadyOwner) {
@@ -256,7 +551,7 @@ func (h *GroupHandlerManager) ChangeOwner(w http.ResponseWriter, r *http.Request
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
 			return
 		}
-		if errors.Is(err, me.ErrOnlyOwnerCanAppointNewOwner) || errors.Is(err, me.ErrOnlyRootCanBeOwnerOfUsersGroup) {
+		if errors.Is(err, me.ErrPermissionDenied) || errors.Is(err, me.ErrOnlyRootCanBeOwnerOfUsersGroup) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
 			return
@@ -267,3 +562,123 @@ func (h *GroupHandlerManager) ChangeOwner(w http.ResponseWriter, r *http.Request
 	}
 	f.Response(w, g, http.StatusOK)
 }
+
+// GetMembers возвращает страницу участников группы, параметры запроса limit и offset необязательны
+func (h *GroupHandlerManager) GetMembers(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	page, err := f.GetQueryPagination(r)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	members, err := h.usecaseGroup.GetMembers(r.Context(), mux.Vars(r)["group_name"], &page)
+	if err != nil {
+		h.groupAdminError(w, err, requestID)
+		return
+	}
+	f.Response(w, members, http.StatusOK)
+}
+
+// RenameGroup меняет название группы
+func (h *GroupHandlerManager) RenameGroup(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	g, err := h.usecaseGroup.RenameGroup(r.Context(), pathVars["group_name"], pathVars["new_group_name"])
+	if err != nil {
+		h.groupAdminError(w, err, requestID)
+		return
+	}
+	f.Response(w, g, http.StatusOK)
+}
+
+// DeleteGroup удаляет группу вместе с ее участием, привелегиями, запретами и ролями
+func (h *GroupHandlerManager) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	groupName := mux.Vars(r)["group_name"]
+	err = h.usecaseGroup.DeleteGroup(r.Context(), groupName)
+	if err != nil {
+		h.groupAdminError(w, err, requestID)
+		return
+	}
+	f.Response(w, dto.ResponseDetail{Detail: fmt.Sprintf("group '%s' was succesful deleted", groupName)}, http.StatusOK)
+}
+
+// AddGroupOwner делает пользователя совладельцем группы
+func (h *GroupHandlerManager) AddGroupOwner(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	userEmail := pathVars["email"]
+	if !govalidator.IsEmail(userEmail) {
+		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
+		return
+	}
+	err = h.usecaseGroup.AddGroupOwner(r.Context(), userEmail, groupName)
+	if err != nil {
+		h.groupAdminError(w, err, requestID)
+		return
+	}
+	f.Response(w, dto.ResponseDetail{Detail: fmt.Sprintf("user '%s' is now an owner of group '%s'", userEmail, groupName)}, http.StatusOK)
+}
+
+// RemoveGroupOwner снимает с пользователя ответственность за группу, участником группы он остается
+func (h *GroupHandlerManager) RemoveGroupOwner(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	userEmail := pathVars["email"]
+	if !govalidator.IsEmail(userEmail) {
+		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
+		return
+	}
+	err = h.usecaseGroup.RemoveGroupOwner(r.Context(), userEmail, groupName)
+	if err != nil {
+		h.groupAdminError(w, err, requestID)
+		return
+	}
+	f.Response(w, dto.ResponseDetail{Detail: fmt.Sprintf("user '%s' is no longer an owner of group '%s'", userEmail, groupName)}, http.StatusOK)
+}
+
+func (h *GroupHandlerManager) groupAdminError(w http.ResponseWriter, err error, requestID string) {
+	if errors.Is(err, me.ErrUnauthorized) {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+		return
+	}
+	if errors.Is(err, me.ErrGroupNotExist) ||
+		errors.Is(err, me.ErrUserNotExist) ||
+		errors.Is(err, me.ErrGroupAlreadyExist) ||
+		errors.Is(err, me.ErrInvalidGroupName) ||
+		errors.Is(err, me.ErrUserIsAlreadyOwner) ||
+		errors.Is(err, me.ErrUserIsNotOwner) ||
+		errors.Is(err, me.ErrLastGroupOwner) {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, me.ErrPermissionDenied) || errors.Is(err, me.ErrUsersGroupIsFixed) {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
+		return
+	}
+	h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+}
@@ -0,0 +1,188 @@
+package invitation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/asaskevich/govalidator"
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/internal/usecase/invitation"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+type InvitationHandlerManager struct {
+	logger            *zap.Logger
+	usecaseInvitation invitation.Usecase
+}
+
+// NewInvitationHandlerManager возвращает менеджер хендлеров, отвечающих за приглашения в группы:
+// создание, просмотр, принятие, отклонение и отзыв приглашения.
+func NewInvitationHandlerManager(usecaseInvitation invitation.Usecase, logger *zap.Logger) *InvitationHandlerManager {
+	return &InvitationHandlerManager{
+		logger:            logger,
+		usecaseInvitation: usecaseInvitation,
+	}
+}
+
+// CreateInvitation приглашает пользователя в группу. Необязательное тело запроса задает срок участия в группе
+// после принятия приглашения.
+func (h *InvitationHandlerManager) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	userEmail := pathVars["email"]
+	if !govalidator.IsEmail(userEmail) {
+		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	var period dto.GrantPeriodData
+	if len(body) != 0 {
+		err = json.Unmarshal(body, &period)
+		if err != nil {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+	err = period.Validate()
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	inv, err := h.usecaseInvitation.Create(r.Context(), groupName, userEmail, &period)
+	if err != nil {
+		h.responseError(w, requestID, err)
+		return
+	}
+	f.Response(w, inv, http.StatusOK)
+}
+
+// GetInvitations возвращает страницу приглашений с фильтрами status, group и параметрами limit, offset.
+// Пользователь видит адресованные ему приглашения и приглашения в группы, за которые он отвечает.
+func (h *InvitationHandlerManager) GetInvitations(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	page, err := f.GetQueryPagination(r)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	filter := dto.InvitationFilter{
+		Pagination: page,
+		Status:     query.Get("status"),
+		Group:      query.Get("group"),
+	}
+	err = filter.Validate()
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	invitations, err := h.usecaseInvitation.List(r.Context(), &filter)
+	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	if invitations == nil {
+		invitations = make([]*ent.Invitation, 0)
+	}
+	f.Response(w, invitations, http.StatusOK)
+}
+
+// AcceptInvitation принимает приглашение, пользователь становится участником группы
+func (h *InvitationHandlerManager) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.usecaseInvitation.Accept)
+}
+
+// DeclineInvitation отклоняет приглашение
+func (h *InvitationHandlerManager) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.usecaseInvitation.Decline)
+}
+
+// RevokeInvitation отзывает приглашение, доступно пригласившему и ответственному за группу
+func (h *InvitationHandlerManager) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.usecaseInvitation.Revoke)
+}
+
+// changeStatus общая часть хендлеров, меняющих статус приглашения с идентификатором из пути запроса
+func (h *InvitationHandlerManager) changeStatus(w http.ResponseWriter, r *http.Request,
+	change func(ctx context.Context, id int) (*ent.Invitation, error)) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	id, err := strconv.Atoi(pathVars["invitation_id"])
+	if err != nil || id < 1 {
+		h.logger.Info(me.ErrInvalidInvitationID.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidInvitationID.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	inv, err := change(r.Context(), id)
+	if err != nil {
+		h.responseError(w, requestID, err)
+		return
+	}
+	f.Response(w, inv, http.StatusOK)
+}
+
+// responseError сопоставляет ошибку usecase со статусом ответа
+func (h *InvitationHandlerManager) responseError(w http.ResponseWriter, requestID string, err error) {
+	if errors.Is(err, me.ErrUnauthorized) {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+		return
+	}
+	if errors.Is(err, me.ErrGroupNotExist) ||
+		errors.Is(err, me.ErrUserNotExist) ||
+		errors.Is(err, me.ErrUserEmailMustBeDiff) ||
+		errors.Is(err, me.ErrUserAlreadyInGroup) ||
+		errors.Is(err, me.ErrInvitationNotExist) ||
+		errors.Is(err, me.ErrInvitationAlreadyExist) ||
+		errors.Is(err, me.ErrInvitationIsResolved) {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, me.ErrPermissionDenied) ||
+		errors.Is(err, me.ErrOnlyInviteeCanRespond) {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
+		return
+	}
+	h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+}
@@ -0,0 +1,102 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/internal/usecase/notification"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+type NotificationHandlerManager struct {
+	logger              *zap.Logger
+	usecaseNotification notification.Usecase
+}
+
+// NewNotificationHandlerManager возвращает менеджер хендлеров, отвечающих за уведомления пользователя.
+func NewNotificationHandlerManager(usecaseNotification notification.Usecase, logger *zap.Logger) *NotificationHandlerManager {
+	return &NotificationHandlerManager{
+		logger:              logger,
+		usecaseNotification: usecaseNotification,
+	}
+}
+
+// GetNotifications возвращает страницу уведомлений пользователя с параметрами limit, offset. Параметр unread=true
+// оставляет только непрочитанные уведомления.
+func (h *NotificationHandlerManager) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	page, err := f.GetQueryPagination(r)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	filter := dto.NotificationFilter{Pagination: page}
+	if unread := r.URL.Query().Get("unread"); unread != "" {
+		filter.Unread, err = strconv.ParseBool(unread)
+		if err != nil {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+
+	notifications, err := h.usecaseNotification.List(r.Context(), &filter)
+	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	if notifications == nil {
+		notifications = make([]*ent.Notification, 0)
+	}
+	f.Response(w, notifications, http.StatusOK)
+}
+
+// ReadNotification отмечает уведомление прочитанным
+func (h *NotificationHandlerManager) ReadNotification(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	id, err := strconv.ParseInt(mux.Vars(r)["notification_id"], 10, 64)
+	if err != nil || id < 1 {
+		h.logger.Info(me.ErrInvalidNotificationID.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidNotificationID.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	err = h.usecaseNotification.MarkRead(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, me.ErrNotificationNotExist) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	f.Response(w, dto.ResponseDetail{Detail: fmt.Sprintf("notification %d was marked as read", id)}, http.StatusOK)
+}
@@ -0,0 +1,54 @@
+package privelege
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+)
+
+// accessorsCSVHeader столбцы выгрузки пользователей с доступом к агенту, группы перечисляются через ';'
+var accessorsCSVHeader = []string{"email", "action", "source", "groups"}
+
+// accessorsExporter пишет пользователей с доступом к агенту в ответ в формате csv. Заголовки ответа отправляются
+// при первой записи или при закрытии пустой выгрузки.
+type accessorsExporter struct {
+	w         http.ResponseWriter
+	csv       *csv.Writer
+	agentName string
+	started   bool
+}
+
+func newAccessorsExporter(w http.ResponseWriter, agentName string) *accessorsExporter {
+	return &accessorsExporter{w: w, csv: csv.NewWriter(w), agentName: agentName}
+}
+
+func (e *accessorsExporter) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	filename := fmt.Sprintf("agent_%s_users_%s.csv", e.agentName, time.Now().UTC().Format("20060102T150405Z"))
+	e.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	e.w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	e.w.WriteHeader(http.StatusOK)
+	return e.csv.Write(accessorsCSVHeader)
+}
+
+func (e *accessorsExporter) write(a *ent.AgentAccessor) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	return e.csv.Write([]string{a.Email, a.Action, a.Source, strings.Join(a.Groups, ";")})
+}
+
+func (e *accessorsExporter) close() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	e.csv.Flush()
+	return e.csv.Error()
+}
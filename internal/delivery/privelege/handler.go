@@ -2,7 +2,9 @@ package privelege
 
 import (
 	// "github.com/cantylv/authorization-service/internal/usecase/role"
+	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 
 	"github.com/asaskevich/govalidator"
@@ -38,22 +40,52 @@ func (h *PrivelegeHandlerManager) AddAgentToGroup(w http.ResponseWriter, r *http
 	pathVars := mux.Vars(r)
 	groupName := pathVars["group_name"]
 	agentName := pathVars["agent_name"]
-	emailAdd := pathVars["email_add"]
-	if !govalidator.IsEmail(emailAdd) {
-		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
-		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
+	action := pathVars["action"]
+	if action != "" {
+		if err := dto.ValidateActionName(action); err != nil {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	var period dto.GrantPeriodData
+	if len(body) != 0 {
+		err = json.Unmarshal(body, &period)
+		if err != nil {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+	err = period.Validate()
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
 		return
 	}
-	err = h.ucPrivelege.AddAgentToGroup(r.Context(), agentName, groupName, emailAdd)
+	err = h.ucPrivelege.AddAgentToGroup(r.Context(), agentName, action, groupName, &period)
 	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
 		if errors.Is(err, me.ErrAgentNotExist) ||
 			errors.Is(err, me.ErrGroupNotExist) ||
-			errors.Is(err, me.ErrGroupAgentAlreadyExist) {
+			errors.Is(err, me.ErrAgentActionNotExist) ||
+			errors.Is(err, me.ErrGroupAgentAlreadyExist) ||
+			errors.Is(err, me.ErrGroupActionAlreadyExist) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
 			return
 		}
-		if errors.Is(err, me.ErrOnlyRootCanAddAgent) {
+		if errors.Is(err, me.ErrPermissionDenied) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
 			return
@@ -74,22 +106,31 @@ func (h *PrivelegeHandlerManager) DeleteAgentFromGroup(w http.ResponseWriter, r
 	pathVars := mux.Vars(r)
 	groupName := pathVars["group_name"]
 	agentName := pathVars["agent_name"]
-	emailDelete := pathVars["email_delete"]
-	if !govalidator.IsEmail(emailDelete) {
-		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
-		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
-		return
+	action := pathVars["action"]
+	if action != "" {
+		if err := dto.ValidateActionName(action); err != nil {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
 	}
-	err = h.ucPrivelege.DeleteAgentFromGroup(r.Context(), agentName, groupName, emailDelete)
+	err = h.ucPrivelege.DeleteAgentFromGroup(r.Context(), agentName, action, groupName)
 	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
 		if errors.Is(err, me.ErrAgentNotExist) ||
 			errors.Is(err, me.ErrGroupNotExist) ||
-			errors.Is(err, me.ErrGroupAgentNotExist) {
+			errors.Is(err, me.ErrAgentActionNotExist) ||
+			errors.Is(err, me.ErrGroupAgentNotExist) ||
+			errors.Is(err, me.ErrGroupActionNotExist) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
 			return
 		}
-		if errors.Is(err, me.ErrOnlyRootCanDeleteAgent) {
+		if errors.Is(err, me.ErrPermissionDenied) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
 			return
@@ -109,21 +150,20 @@ func (h *PrivelegeHandlerManager) GetGroupAgents(w http.ResponseWriter, r *http.
 	}
 	pathVars := mux.Vars(r)
 	groupName := pathVars["group_name"]
-	emailAsk := pathVars["email_ask"]
-	if !govalidator.IsEmail(emailAsk) {
-		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
-		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
-		return
-	}
-	agents, err := h.ucPrivelege.GetGroupAgents(r.Context(), groupName, emailAsk)
+	agents, err := h.ucPrivelege.GetGroupAgents(r.Context(), groupName)
 	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
 		if errors.Is(err, me.ErrGroupNotExist) ||
 			errors.Is(err, me.ErrUserNotExist) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
 			return
 		}
-		if errors.Is(err, me.ErrUserIsNotOwner) {
+		if errors.Is(err, me.ErrPermissionDenied) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
 			return
@@ -151,22 +191,52 @@ func (h *PrivelegeHandlerManager) AddAgentToUser(w http.ResponseWriter, r *http.
 		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
 		return
 	}
-	emailAdd := pathVars["email_add"]
-	if !govalidator.IsEmail(emailAdd) {
-		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
-		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
+	action := pathVars["action"]
+	if action != "" {
+		if err := dto.ValidateActionName(action); err != nil {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	var period dto.GrantPeriodData
+	if len(body) != 0 {
+		err = json.Unmarshal(body, &period)
+		if err != nil {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+	err = period.Validate()
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
 		return
 	}
-	err = h.ucPrivelege.AddAgentToUser(r.Context(), agentName, email, emailAdd)
+	err = h.ucPrivelege.AddAgentToUser(r.Context(), agentName, action, email, &period)
 	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
 		if errors.Is(err, me.ErrAgentNotExist) ||
 			errors.Is(err, me.ErrUserNotExist) ||
-			errors.Is(err, me.ErrUserAgentAlreadyExist) {
+			errors.Is(err, me.ErrAgentActionNotExist) ||
+			errors.Is(err, me.ErrUserAgentAlreadyExist) ||
+			errors.Is(err, me.ErrUserActionAlreadyExist) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
 			return
 		}
-		if errors.Is(err, me.ErrOnlyRootCanAddAgent) {
+		if errors.Is(err, me.ErrPermissionDenied) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
 			return
@@ -192,22 +262,31 @@ func (h *PrivelegeHandlerManager) DeleteAgentFromUser(w http.ResponseWriter, r *
 		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
 		return
 	}
-	emailDelete := pathVars["email_delete"]
-	if !govalidator.IsEmail(emailDelete) {
-		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
-		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
-		return
+	action := pathVars["action"]
+	if action != "" {
+		if err := dto.ValidateActionName(action); err != nil {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
 	}
-	err = h.ucPrivelege.DeleteAgentFromUser(r.Context(), agentName, email, emailDelete)
+	err = h.ucPrivelege.DeleteAgentFromUser(r.Context(), agentName, action, email)
 	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
 		if errors.Is(err, me.ErrAgentNotExist) ||
 			errors.Is(err, me.ErrUserNotExist) ||
-			errors.Is(err, me.ErrUserAgentNotExist) {
+			errors.Is(err, me.ErrAgentActionNotExist) ||
+			errors.Is(err, me.ErrUserAgentNotExist) ||
+			errors.Is(err, me.ErrUserActionNotExist) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
 			return
 		}
-		if errors.Is(err, me.ErrOnlyRootCanDeleteAgent) {
+		if errors.Is(err, me.ErrPermissionDenied) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
 			return
@@ -232,20 +311,19 @@ func (h *PrivelegeHandlerManager) GetUserAgents(w http.ResponseWriter, r *http.R
 		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
 		return
 	}
-	emailAsk := pathVars["email_ask"]
-	if !govalidator.IsEmail(emailAsk) {
-		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
-		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
-		return
-	}
-	agents, err := h.ucPrivelege.GetUserAgents(r.Context(), email, emailAsk)
+	agents, err := h.ucPrivelege.GetUserAgents(r.Context(), email)
 	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
 		if errors.Is(err, me.ErrUserNotExist) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
 			return
 		}
-		if errors.Is(err, me.ErrGetUserAgents) {
+		if errors.Is(err, me.ErrPermissionDenied) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
 			return
@@ -260,6 +338,74 @@ func (h *PrivelegeHandlerManager) GetUserAgents(w http.ResponseWriter, r *http.R
 	f.Response(w, agents, http.StatusOK)
 }
 
+// GetAgentAccessors возвращает страницу пользователей с действующим доступом к агенту или к его действиям
+// с параметрами limit и offset. Требует права privilege.read_accessors.
+func (h *PrivelegeHandlerManager) GetAgentAccessors(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	page, err := f.GetQueryPagination(r)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	accessors, err := h.ucPrivelege.GetAgentAccessors(r.Context(), mux.Vars(r)["agent_name"], &page)
+	if err != nil {
+		h.accessorsError(w, requestID, err)
+		return
+	}
+	f.Response(w, accessors, http.StatusOK)
+}
+
+// ExportAgentAccessors выгружает в формате csv всех пользователей с действующим доступом к агенту или к его
+// действиям. Требует права privilege.read_accessors.
+func (h *PrivelegeHandlerManager) ExportAgentAccessors(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	agentName := mux.Vars(r)["agent_name"]
+	exporter := newAccessorsExporter(w, agentName)
+
+	// заголовки ответа отправляются с первой записью, чтобы до нее можно было ответить ошибкой
+	err = h.ucPrivelege.ExportAgentAccessors(r.Context(), agentName, func(a *ent.AgentAccessor) error {
+		return exporter.write(a)
+	})
+	if err == nil {
+		err = exporter.close()
+	}
+	if err != nil {
+		if !exporter.started {
+			h.accessorsError(w, requestID, err)
+			return
+		}
+		// часть выгрузки уже отправлена, сменить статус ответа нельзя
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+}
+
+func (h *PrivelegeHandlerManager) accessorsError(w http.ResponseWriter, requestID string, err error) {
+	if errors.Is(err, me.ErrUnauthorized) {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+		return
+	}
+	if errors.Is(err, me.ErrPermissionDenied) {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
+		return
+	}
+	if errors.Is(err, me.ErrAgentNotExist) {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+}
+
 func (h *PrivelegeHandlerManager) CanUserExecute(w http.ResponseWriter, r *http.Request) {
 	requestID, err := f.GetCtxRequestID(r)
 	if err != nil {
@@ -273,9 +419,18 @@ func (h *PrivelegeHandlerManager) CanUserExecute(w http.ResponseWriter, r *http.
 		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
 		return
 	}
-	canExecute, err := h.ucPrivelege.CanExecute(r.Context(), userEmail, agentName)
+	action := pathVars["action"]
+	if action != "" {
+		if err := dto.ValidateActionName(action); err != nil {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+	canExecute, err := h.ucPrivelege.CanExecute(r.Context(), userEmail, agentName, action)
 	if err != nil {
 		if errors.Is(err, me.ErrAgentNotExist) ||
+			errors.Is(err, me.ErrAgentActionNotExist) ||
 			errors.Is(err, me.ErrUserNotExist) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
@@ -291,3 +446,295 @@ func (h *PrivelegeHandlerManager) CanUserExecute(w http.ResponseWriter, r *http.
 	}
 	f.Response(w, map[string]bool{"can_execute": false}, http.StatusOK)
 }
+
+// CanUserExecuteBatch возвращает решения о доступе для набора проверок (email, агент, действие) в порядке проверок
+func (h *PrivelegeHandlerManager) CanUserExecuteBatch(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	var requestData dto.AccessCheckBatchData
+	err = json.Unmarshal(body, &requestData)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	err = requestData.Validate()
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	results, err := h.ucPrivelege.CanExecuteBatch(r.Context(), requestData.Checks)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	f.Response(w, results, http.StatusOK)
+}
+
+// AddDenyToGroup запрещает группе доступ к агенту или к его действию
+func (h *PrivelegeHandlerManager) AddDenyToGroup(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	agentName := pathVars["agent_name"]
+	action := pathVars["action"]
+	if action != "" {
+		if err := dto.ValidateActionName(action); err != nil {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+	err = h.ucPrivelege.AddDenyToGroup(r.Context(), agentName, action, groupName)
+	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, me.ErrAgentNotExist) ||
+			errors.Is(err, me.ErrGroupNotExist) ||
+			errors.Is(err, me.ErrAgentActionNotExist) ||
+			errors.Is(err, me.ErrGroupDenyAlreadyExist) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, me.ErrPermissionDenied) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+
+	f.Response(w, dto.ResponseDetail{Detail: "access to agent was succesful denied for group"}, http.StatusOK)
+}
+
+// DeleteDenyFromGroup снимает с группы запрет доступа к агенту или к его действию
+func (h *PrivelegeHandlerManager) DeleteDenyFromGroup(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	agentName := pathVars["agent_name"]
+	action := pathVars["action"]
+	if action != "" {
+		if err := dto.ValidateActionName(action); err != nil {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+	err = h.ucPrivelege.DeleteDenyFromGroup(r.Context(), agentName, action, groupName)
+	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, me.ErrAgentNotExist) ||
+			errors.Is(err, me.ErrGroupNotExist) ||
+			errors.Is(err, me.ErrAgentActionNotExist) ||
+			errors.Is(err, me.ErrGroupDenyNotExist) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, me.ErrPermissionDenied) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+
+	f.Response(w, dto.ResponseDetail{Detail: "deny was succesful deleted from group"}, http.StatusOK)
+}
+
+// AddDenyToUser запрещает пользователю доступ к агенту или к его действию
+func (h *PrivelegeHandlerManager) AddDenyToUser(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	agentName := pathVars["agent_name"]
+	email := pathVars["email"]
+	if !govalidator.IsEmail(email) {
+		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
+		return
+	}
+	action := pathVars["action"]
+	if action != "" {
+		if err := dto.ValidateActionName(action); err != nil {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+	err = h.ucPrivelege.AddDenyToUser(r.Context(), agentName, action, email)
+	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, me.ErrAgentNotExist) ||
+			errors.Is(err, me.ErrUserNotExist) ||
+			errors.Is(err, me.ErrAgentActionNotExist) ||
+			errors.Is(err, me.ErrUserDenyAlreadyExist) ||
+			errors.Is(err, me.ErrCantDenyRoot) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, me.ErrPermissionDenied) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+
+	f.Response(w, dto.ResponseDetail{Detail: "access to agent was succesful denied for user"}, http.StatusOK)
+}
+
+// DeleteDenyFromUser снимает с пользователя запрет доступа к агенту или к его действию
+func (h *PrivelegeHandlerManager) DeleteDenyFromUser(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	agentName := pathVars["agent_name"]
+	email := pathVars["email"]
+	if !govalidator.IsEmail(email) {
+		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
+		return
+	}
+	action := pathVars["action"]
+	if action != "" {
+		if err := dto.ValidateActionName(action); err != nil {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+	err = h.ucPrivelege.DeleteDenyFromUser(r.Context(), agentName, action, email)
+	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, me.ErrAgentNotExist) ||
+			errors.Is(err, me.ErrUserNotExist) ||
+			errors.Is(err, me.ErrAgentActionNotExist) ||
+			errors.Is(err, me.ErrUserDenyNotExist) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, me.ErrPermissionDenied) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+
+	f.Response(w, dto.ResponseDetail{Detail: "deny was succesful deleted from user"}, http.StatusOK)
+}
+
+// ExplainAccess возвращает вывод решения о доступе пользователя к агенту: сработавшее правило и подходящие
+// строки привелегий и запретов. Несуществующий пользователь, агент или действие отражаются в самом объяснении.
+func (h *PrivelegeHandlerManager) ExplainAccess(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	agentName := pathVars["agent_name"]
+	userEmail := pathVars["email"]
+	if !govalidator.IsEmail(userEmail) {
+		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
+		return
+	}
+	action := pathVars["action"]
+	if action != "" {
+		if err := dto.ValidateActionName(action); err != nil {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+	explanation, err := h.ucPrivelege.ExplainAccess(r.Context(), userEmail, agentName, action)
+	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, me.ErrPermissionDenied) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	f.Response(w, explanation, http.StatusOK)
+}
+
+// GetAccessCacheStats возвращает счетчики кэша решений о доступе экземпляра сервиса, обработавшего запрос.
+func (h *PrivelegeHandlerManager) GetAccessCacheStats(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	stats, err := h.ucPrivelege.GetAccessCacheStats(r.Context())
+	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, me.ErrPermissionDenied) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	f.Response(w, stats, http.StatusOK)
+}
@@ -0,0 +1,39 @@
+package accessrequest
+
+import (
+	dAccessRequest "github.com/cantylv/authorization-service/internal/delivery/accessrequest"
+	rAccessRequest "github.com/cantylv/authorization-service/internal/repo/accessrequest"
+	rAgent "github.com/cantylv/authorization-service/internal/repo/agent"
+	rAudit "github.com/cantylv/authorization-service/internal/repo/audit"
+	rGroup "github.com/cantylv/authorization-service/internal/repo/group"
+	rOutbox "github.com/cantylv/authorization-service/internal/repo/outbox"
+	rPrivelege "github.com/cantylv/authorization-service/internal/repo/privelege"
+	rRole "github.com/cantylv/authorization-service/internal/repo/role"
+	rUser "github.com/cantylv/authorization-service/internal/repo/user"
+	uAccessRequest "github.com/cantylv/authorization-service/internal/usecase/accessrequest"
+	uAudit "github.com/cantylv/authorization-service/internal/usecase/audit"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
+	"github.com/cantylv/authorization-service/services/postgres"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// InitHandlers инициализирует обработчики запросов, отвечающих за заявки на временный доступ к агентам.
+// Одобренная заявка выдает пользователю доступ к агенту или участие в группе до истечения запрошенного срока.
+func InitHandlers(r *mux.Router, postgresClient *postgres.Client, logger *zap.Logger) {
+	accessPolicy := policy.NewPolicyLayer(rRole.NewRepoLayer(postgresClient))
+	auditRecorder := uAudit.NewRecorderLayer(rAudit.NewRepoLayer(postgresClient), rOutbox.NewRepoLayer(postgresClient))
+	usecaseAccessRequest := uAccessRequest.NewUsecaseLayer(postgresClient, accessPolicy, auditRecorder,
+		rAccessRequest.NewRepoLayer(postgresClient),
+		rAgent.NewRepoLayer(postgresClient),
+		rGroup.NewRepoLayer(postgresClient),
+		rPrivelege.NewRepoLayer(postgresClient),
+		rUser.NewRepoLayer(postgresClient),
+	)
+	accessRequestHandlerManager := dAccessRequest.NewAccessRequestHandlerManager(usecaseAccessRequest, logger)
+	r.HandleFunc("/access_requests", accessRequestHandlerManager.CreateAccessRequest).Methods("POST")                       // создает заявку на доступ
+	r.HandleFunc("/access_requests", accessRequestHandlerManager.GetAccessRequests).Methods("GET")                          // возвращает список заявок с фильтрами
+	r.HandleFunc("/access_requests/{request_id}/approve", accessRequestHandlerManager.ApproveAccessRequest).Methods("POST") // одобряет заявку и выдает временный доступ
+	r.HandleFunc("/access_requests/{request_id}/reject", accessRequestHandlerManager.RejectAccessRequest).Methods("POST")   // отклоняет заявку
+	r.HandleFunc("/access_requests/{request_id}/cancel", accessRequestHandlerManager.CancelAccessRequest).Methods("POST")   // отменяет заявку, доступно автору
+}
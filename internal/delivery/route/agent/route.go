@@ -3,18 +3,25 @@ package agent
 import (
 	"github.com/cantylv/authorization-service/internal/delivery/agent"
 	rAgent "github.com/cantylv/authorization-service/internal/repo/agent"
+	rAudit "github.com/cantylv/authorization-service/internal/repo/audit"
+	rOutbox "github.com/cantylv/authorization-service/internal/repo/outbox"
+	rRole "github.com/cantylv/authorization-service/internal/repo/role"
 	ucAgent "github.com/cantylv/authorization-service/internal/usecase/agent"
+	ucAudit "github.com/cantylv/authorization-service/internal/usecase/audit"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
+	"github.com/cantylv/authorization-service/services/postgres"
 	"github.com/gorilla/mux"
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
 // InitHandlers инициализирует обработчики запросов, отвечающих crd agent
-func InitHandlers(r *mux.Router, postgresClient *pgx.Conn, logger *zap.Logger) {
+func InitHandlers(r *mux.Router, postgresClient *postgres.Client, logger *zap.Logger) {
 	repoAgent := rAgent.NewRepoLayer(postgresClient)
-	usecaseAgent := ucAgent.NewUsecaseLayer(repoAgent)
+	accessPolicy := policy.NewPolicyLayer(rRole.NewRepoLayer(postgresClient))
+	auditRecorder := ucAudit.NewRecorderLayer(rAudit.NewRepoLayer(postgresClient), rOutbox.NewRepoLayer(postgresClient))
+	usecaseAgent := ucAgent.NewUsecaseLayer(postgresClient, accessPolicy, auditRecorder, repoAgent)
 	agentHandlerManager := agent.NewAgentHandlerManager(usecaseAgent, logger)
-	r.HandleFunc("/agents/{agent_name}/who_creates/{email_create}", agentHandlerManager.CreateAgent).Methods("POST")   // создает агента
-	r.HandleFunc("/agents/{agent_name}/who_deletes/{email_delete}", agentHandlerManager.DeleteAgent).Methods("DELETE") // удаляет агента
-	r.HandleFunc("/agents/who_reads/{email_read}", agentHandlerManager.GetAgents).Methods("GET")                       // возвращает список доступных агентов
+	r.HandleFunc("/agents/{agent_name}", agentHandlerManager.CreateAgent).Methods("POST")   // создает агента
+	r.HandleFunc("/agents/{agent_name}", agentHandlerManager.DeleteAgent).Methods("DELETE") // удаляет агента
+	r.HandleFunc("/agents", agentHandlerManager.GetAgents).Methods("GET")                   // возвращает список доступных агентов
 }
@@ -0,0 +1,22 @@
+package audit
+
+import (
+	dAudit "github.com/cantylv/authorization-service/internal/delivery/audit"
+	rAudit "github.com/cantylv/authorization-service/internal/repo/audit"
+	rRole "github.com/cantylv/authorization-service/internal/repo/role"
+	uAudit "github.com/cantylv/authorization-service/internal/usecase/audit"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
+	"github.com/cantylv/authorization-service/services/postgres"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// InitHandlers инициализирует обработчики запросов, отвечающих за журнал аудита. Журнал доступен пользователям
+// с правом audit.read.
+func InitHandlers(r *mux.Router, postgresClient *postgres.Client, logger *zap.Logger) {
+	accessPolicy := policy.NewPolicyLayer(rRole.NewRepoLayer(postgresClient))
+	usecaseAudit := uAudit.NewUsecaseLayer(postgresClient, accessPolicy, rAudit.NewRepoLayer(postgresClient))
+	auditHandlerManager := dAudit.NewAuditHandlerManager(usecaseAudit, logger)
+	r.HandleFunc("/audit", auditHandlerManager.GetAuditLog).Methods("GET")           // возвращает страницу журнала аудита с фильтрами
+	r.HandleFunc("/audit/verify", auditHandlerManager.VerifyAuditLog).Methods("GET") // проверяет цепочку хэшей журнала аудита
+}
@@ -0,0 +1,22 @@
+package decision
+
+import (
+	dDecision "github.com/cantylv/authorization-service/internal/delivery/decision"
+	rDecision "github.com/cantylv/authorization-service/internal/repo/decision"
+	rRole "github.com/cantylv/authorization-service/internal/repo/role"
+	uDecision "github.com/cantylv/authorization-service/internal/usecase/decision"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
+	"github.com/cantylv/authorization-service/services/postgres"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// InitHandlers инициализирует обработчики запросов, отвечающих за журнал решений о доступе. Журнал доступен
+// пользователям с правом decision_log.read.
+func InitHandlers(r *mux.Router, postgresClient *postgres.Client, logger *zap.Logger) {
+	accessPolicy := policy.NewPolicyLayer(rRole.NewRepoLayer(postgresClient))
+	usecaseDecision := uDecision.NewUsecaseLayer(accessPolicy, rDecision.NewRepoLayer(postgresClient))
+	decisionHandlerManager := dDecision.NewDecisionHandlerManager(usecaseDecision, logger)
+	r.HandleFunc("/decisions", decisionHandlerManager.GetDecisions).Methods("GET")           // возвращает страницу журнала решений о доступе с фильтрами
+	r.HandleFunc("/decisions/export", decisionHandlerManager.ExportDecisions).Methods("GET") // выгружает журнал решений в csv или jsonl
+}
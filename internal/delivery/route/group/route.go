@@ -2,24 +2,45 @@ package group
 
 import (
 	dGroup "github.com/cantylv/authorization-service/internal/delivery/group"
+	repoAudit "github.com/cantylv/authorization-service/internal/repo/audit"
 	repoGroup "github.com/cantylv/authorization-service/internal/repo/group"
+	repoNotification "github.com/cantylv/authorization-service/internal/repo/notification"
+	repoOutbox "github.com/cantylv/authorization-service/internal/repo/outbox"
+	repoRole "github.com/cantylv/authorization-service/internal/repo/role"
 	repoUser "github.com/cantylv/authorization-service/internal/repo/user"
+	"github.com/cantylv/authorization-service/internal/usecase/audit"
 	"github.com/cantylv/authorization-service/internal/usecase/group"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
+	"github.com/cantylv/authorization-service/services/postgres"
 	"github.com/gorilla/mux"
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
 // InitHandlers инициализирует обработчики запросов, отвечающих за права пользователя к ресурсу
-func InitHandlers(r *mux.Router, postgresClient *pgx.Conn, logger *zap.Logger) {
+func InitHandlers(r *mux.Router, postgresClient *postgres.Client, logger *zap.Logger) {
 	repoUser := repoUser.NewRepoLayer(postgresClient)
 	repoGroup := repoGroup.NewRepoLayer(postgresClient)
-	usecaseGroup := group.NewUsecaseLayer(repoUser, repoGroup)
+	accessPolicy := policy.NewPolicyLayer(repoRole.NewRepoLayer(postgresClient))
+	auditRecorder := audit.NewRecorderLayer(repoAudit.NewRepoLayer(postgresClient), repoOutbox.NewRepoLayer(postgresClient))
+	usecaseGroup := group.NewUsecaseLayer(postgresClient, accessPolicy, auditRecorder, repoUser, repoGroup,
+		repoNotification.NewRepoLayer(postgresClient))
 	userHandlerManager := dGroup.NewGroupHandlerManager(usecaseGroup, logger)
-	r.HandleFunc("/groups/{group_name}/add_user/{email}/who_invites/{email_invite}", userHandlerManager.AddUserToGroup).Methods("POST")           // добавляет пользователя в группу
-	r.HandleFunc("/users/{email}/groups/who_asks/{email_ask}", userHandlerManager.GetUserGroups).Methods("GET")                                   // возвращает список групп пользователя
-	r.HandleFunc("/groups/{group_name}/kick_user/{email}/who_kicks/{email_kick}", userHandlerManager.KickOutUser).Methods("POST")                 // удаляет пользователя из группы
-	r.HandleFunc("/groups/{group_name}/who_adds/{email_add}", userHandlerManager.RequestToCreateGroup).Methods("POST")                            // добавляет заявку на создание группы
-	r.HandleFunc("/users/{email}/groups/{group_name}/who_change_status/{email_change_status}", userHandlerManager.ChangeBidStatus).Methods("PUT") // подтверждает/отклоняет заявку на создание группы ? доступна только root
-	r.HandleFunc("/groups/{group_name}/users/{email}/who_change_owner/{email_change_owner}", userHandlerManager.ChangeOwner).Methods("PUT")       // изменяет ответственного за группу
+	r.HandleFunc("/groups/{group_name}/add_user/{email}", userHandlerManager.AddUserToGroup).Methods("POST")                // добавляет пользователя в группу
+	r.HandleFunc("/users/{email}/groups", userHandlerManager.GetUserGroups).Methods("GET")                                  // возвращает список групп пользователя
+	r.HandleFunc("/groups/{group_name}/kick_user/{email}", userHandlerManager.KickOutUser).Methods("POST")                  // удаляет пользователя из группы
+	r.HandleFunc("/groups/{group_name}/leave", userHandlerManager.LeaveGroup).Methods("POST")                               // пользователь сам выходит из группы
+	r.HandleFunc("/groups/{group_name}", userHandlerManager.RequestToCreateGroup).Methods("POST")                           // добавляет заявку на создание группы
+	r.HandleFunc("/users/{email}/groups/{group_name}", userHandlerManager.ChangeBidStatus).Methods("PUT")                   // подтверждает/отклоняет заявку на создание группы ? доступна с правом group.approve_bid
+	r.HandleFunc("/groups/{group_name}/add_subgroup/{subgroup_name}", userHandlerManager.AttachSubgroup).Methods("POST")    // делает группу подгруппой
+	r.HandleFunc("/groups/{group_name}/remove_subgroup/{subgroup_name}", userHandlerManager.DetachSubgroup).Methods("POST") // отвязывает подгруппу
+	r.HandleFunc("/groups/{group_name}/subgroups", userHandlerManager.GetSubgroups).Methods("GET")                          // возвращает подгруппы первого уровня
+	r.HandleFunc("/bids", userHandlerManager.GetBids).Methods("GET")                                                        // возвращает страницу заявок на создание группы
+	r.HandleFunc("/bids/{bid_id}", userHandlerManager.GetBid).Methods("GET")                                                // возвращает заявку и историю ее статусов
+	r.HandleFunc("/bids/{bid_id}/cancel", userHandlerManager.CancelBid).Methods("POST")                                     // отзывает заявку ее автором
+	r.HandleFunc("/groups/{group_name}/change_owner/{email}", userHandlerManager.ChangeOwner).Methods("PUT")                // изменяет ответственного за группу
+	r.HandleFunc("/groups/{group_name}/add_owner/{email}", userHandlerManager.AddGroupOwner).Methods("POST")                // добавляет совладельца группы
+	r.HandleFunc("/groups/{group_name}/remove_owner/{email}", userHandlerManager.RemoveGroupOwner).Methods("POST")          // снимает совладельца группы
+	r.HandleFunc("/groups/{group_name}/members", userHandlerManager.GetMembers).Methods("GET")                              // возвращает страницу участников группы
+	r.HandleFunc("/groups/{group_name}/rename/{new_group_name}", userHandlerManager.RenameGroup).Methods("PUT")             // переименовывает группу
+	r.HandleFunc("/groups/{group_name}", userHandlerManager.DeleteGroup).Methods("DELETE")                                  // удаляет группу
 }
@@ -0,0 +1,56 @@
+package route
+
+import (
+	pb "github.com/cantylv/authorization-service/client/privelegepb"
+	"github.com/cantylv/authorization-service/internal/delivery/rpc"
+	"github.com/cantylv/authorization-service/internal/middlewares"
+	rAgent "github.com/cantylv/authorization-service/internal/repo/agent"
+	rAudit "github.com/cantylv/authorization-service/internal/repo/audit"
+	rGroup "github.com/cantylv/authorization-service/internal/repo/group"
+	rNotification "github.com/cantylv/authorization-service/internal/repo/notification"
+	rOidc "github.com/cantylv/authorization-service/internal/repo/oidc"
+	rOutbox "github.com/cantylv/authorization-service/internal/repo/outbox"
+	rPrivelege "github.com/cantylv/authorization-service/internal/repo/privelege"
+	rRole "github.com/cantylv/authorization-service/internal/repo/role"
+	rToken "github.com/cantylv/authorization-service/internal/repo/token"
+	rUser "github.com/cantylv/authorization-service/internal/repo/user"
+	uAgent "github.com/cantylv/authorization-service/internal/usecase/agent"
+	uAudit "github.com/cantylv/authorization-service/internal/usecase/audit"
+	uDecision "github.com/cantylv/authorization-service/internal/usecase/decision"
+	uGroup "github.com/cantylv/authorization-service/internal/usecase/group"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
+	uPrivelege "github.com/cantylv/authorization-service/internal/usecase/privelege"
+	uUser "github.com/cantylv/authorization-service/internal/usecase/user"
+	"github.com/cantylv/authorization-service/services/oidc"
+	"github.com/cantylv/authorization-service/services/postgres"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// InitGRPCServer создает gRPC сервер с сервисами пользователей, групп, агентов, привелегий и проверок доступа.
+// Сервисы вызывают те же usecase, что и HTTP обработчики, а проверки доступа используют общие с HTTP API
+// decisionLogger и accessCache.
+func InitGRPCServer(postgresClient *postgres.Client, decisionLogger uDecision.Logger, accessCache *uPrivelege.AccessCache, logger *zap.Logger) *grpc.Server {
+	repoUser := rUser.NewRepoLayer(postgresClient)
+	repoGroup := rGroup.NewRepoLayer(postgresClient)
+	repoAgent := rAgent.NewRepoLayer(postgresClient)
+	repoPrivelege := rPrivelege.NewRepoLayer(postgresClient)
+	accessPolicy := policy.NewPolicyLayer(rRole.NewRepoLayer(postgresClient))
+	auditRecorder := uAudit.NewRecorderLayer(rAudit.NewRepoLayer(postgresClient), rOutbox.NewRepoLayer(postgresClient))
+
+	ucUser := uUser.NewUsecaseLayer(postgresClient, accessPolicy, auditRecorder, repoUser, repoGroup, repoPrivelege,
+		rToken.NewRepoLayer(postgresClient), rOidc.NewRepoLayer(postgresClient), oidc.NewProviderLayer())
+	ucGroup := uGroup.NewUsecaseLayer(postgresClient, accessPolicy, auditRecorder, repoUser, repoGroup,
+		rNotification.NewRepoLayer(postgresClient))
+	ucAgent := uAgent.NewUsecaseLayer(postgresClient, accessPolicy, auditRecorder, repoAgent)
+	ucPrivelege := uPrivelege.NewUsecaseLayer(postgresClient, accessPolicy, auditRecorder, decisionLogger, accessCache,
+		repoAgent, repoPrivelege, repoUser, repoGroup)
+
+	srv := grpc.NewServer(middlewares.InitGRPC(logger))
+	pb.RegisterUserServiceServer(srv, rpc.NewUserServer(ucUser, logger))
+	pb.RegisterGroupServiceServer(srv, rpc.NewGroupServer(ucGroup, logger))
+	pb.RegisterAgentServiceServer(srv, rpc.NewAgentServer(ucAgent, logger))
+	pb.RegisterPrivelegeServiceServer(srv, rpc.NewPrivelegeServer(ucPrivelege, logger))
+	pb.RegisterAccessServiceServer(srv, rpc.NewAccessServer(ucPrivelege, logger))
+	return srv
+}
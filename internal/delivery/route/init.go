@@ -3,24 +3,40 @@ package route
 import (
 	"net/http"
 
+	"github.com/cantylv/authorization-service/internal/delivery/route/accessrequest"
 	"github.com/cantylv/authorization-service/internal/delivery/route/agent"
+	"github.com/cantylv/authorization-service/internal/delivery/route/audit"
+	"github.com/cantylv/authorization-service/internal/delivery/route/decision"
 	"github.com/cantylv/authorization-service/internal/delivery/route/group"
+	"github.com/cantylv/authorization-service/internal/delivery/route/invitation"
+	"github.com/cantylv/authorization-service/internal/delivery/route/notification"
 	"github.com/cantylv/authorization-service/internal/delivery/route/ping"
 	"github.com/cantylv/authorization-service/internal/delivery/route/privelege"
+	"github.com/cantylv/authorization-service/internal/delivery/route/role"
 	"github.com/cantylv/authorization-service/internal/delivery/route/user"
 	"github.com/cantylv/authorization-service/internal/middlewares"
+	uDecision "github.com/cantylv/authorization-service/internal/usecase/decision"
+	uPrivelege "github.com/cantylv/authorization-service/internal/usecase/privelege"
+	"github.com/cantylv/authorization-service/services/postgres"
 	"github.com/gorilla/mux"
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
 // InitHTTPHandlers инициализирует обработчики запросов, а также добавляет цепочку middlewares в обработку запроса.
-func InitHTTPHandlers(r *mux.Router, postgresClient *pgx.Conn, logger *zap.Logger) http.Handler {
+// decisionLogger записывает решения проверок доступа, его фоновая запись запускается вызывающим. accessCache кэширует
+// проверки доступа, сбрасывать его по уведомлениям базы данных должен вызывающий.
+func InitHTTPHandlers(r *mux.Router, postgresClient *postgres.Client, decisionLogger uDecision.Logger, accessCache *uPrivelege.AccessCache, logger *zap.Logger) http.Handler {
 	s := r.PathPrefix("/api/v1").Subrouter()
 	ping.InitHandlers(s)
 	agent.InitHandlers(s, postgresClient, logger)
 	user.InitHandlers(s, postgresClient, logger)
 	group.InitHandlers(s, postgresClient, logger)
-	privelege.InitHandlers(s, postgresClient, logger)
+	privelege.InitHandlers(s, postgresClient, decisionLogger, accessCache, logger)
+	role.InitHandlers(s, postgresClient, logger)
+	accessrequest.InitHandlers(s, postgresClient, logger)
+	invitation.InitHandlers(s, postgresClient, logger)
+	notification.InitHandlers(s, postgresClient, logger)
+	audit.InitHandlers(s, postgresClient, logger)
+	decision.InitHandlers(s, postgresClient, logger)
 	return middlewares.Init(s, logger)
 }
@@ -0,0 +1,37 @@
+package invitation
+
+import (
+	dInvitation "github.com/cantylv/authorization-service/internal/delivery/invitation"
+	rAudit "github.com/cantylv/authorization-service/internal/repo/audit"
+	rGroup "github.com/cantylv/authorization-service/internal/repo/group"
+	rInvitation "github.com/cantylv/authorization-service/internal/repo/invitation"
+	rNotification "github.com/cantylv/authorization-service/internal/repo/notification"
+	rOutbox "github.com/cantylv/authorization-service/internal/repo/outbox"
+	rRole "github.com/cantylv/authorization-service/internal/repo/role"
+	rUser "github.com/cantylv/authorization-service/internal/repo/user"
+	uAudit "github.com/cantylv/authorization-service/internal/usecase/audit"
+	uInvitation "github.com/cantylv/authorization-service/internal/usecase/invitation"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
+	"github.com/cantylv/authorization-service/services/postgres"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// InitHandlers инициализирует обработчики запросов, отвечающих за приглашения в группы.
+// Участником группы пользователь становится только после принятия приглашения.
+func InitHandlers(r *mux.Router, postgresClient *postgres.Client, logger *zap.Logger) {
+	accessPolicy := policy.NewPolicyLayer(rRole.NewRepoLayer(postgresClient))
+	auditRecorder := uAudit.NewRecorderLayer(rAudit.NewRepoLayer(postgresClient), rOutbox.NewRepoLayer(postgresClient))
+	usecaseInvitation := uInvitation.NewUsecaseLayer(postgresClient, accessPolicy, auditRecorder,
+		rInvitation.NewRepoLayer(postgresClient),
+		rGroup.NewRepoLayer(postgresClient),
+		rUser.NewRepoLayer(postgresClient),
+		rNotification.NewRepoLayer(postgresClient),
+	)
+	invitationHandlerManager := dInvitation.NewInvitationHandlerManager(usecaseInvitation, logger)
+	r.HandleFunc("/groups/{group_name}/invitations/{email}", invitationHandlerManager.CreateInvitation).Methods("POST") // приглашает пользователя в группу
+	r.HandleFunc("/invitations", invitationHandlerManager.GetInvitations).Methods("GET")                                // возвращает страницу приглашений с фильтрами
+	r.HandleFunc("/invitations/{invitation_id}/accept", invitationHandlerManager.AcceptInvitation).Methods("POST")      // принимает приглашение, доступно приглашенному
+	r.HandleFunc("/invitations/{invitation_id}/decline", invitationHandlerManager.DeclineInvitation).Methods("POST")    // отклоняет приглашение, доступно приглашенному
+	r.HandleFunc("/invitations/{invitation_id}/revoke", invitationHandlerManager.RevokeInvitation).Methods("POST")      // отзывает приглашение
+}
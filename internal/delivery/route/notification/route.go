@@ -0,0 +1,18 @@
+package notification
+
+import (
+	dNotification "github.com/cantylv/authorization-service/internal/delivery/notification"
+	rNotification "github.com/cantylv/authorization-service/internal/repo/notification"
+	uNotification "github.com/cantylv/authorization-service/internal/usecase/notification"
+	"github.com/cantylv/authorization-service/services/postgres"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// InitHandlers инициализирует обработчики запросов, отвечающих за уведомления пользователя о событиях в группах.
+func InitHandlers(r *mux.Router, postgresClient *postgres.Client, logger *zap.Logger) {
+	usecaseNotification := uNotification.NewUsecaseLayer(rNotification.NewRepoLayer(postgresClient))
+	notificationHandlerManager := dNotification.NewNotificationHandlerManager(usecaseNotification, logger)
+	r.HandleFunc("/notifications", notificationHandlerManager.GetNotifications).Methods("GET")                         // возвращает страницу уведомлений пользователя
+	r.HandleFunc("/notifications/{notification_id}/read", notificationHandlerManager.ReadNotification).Methods("POST") // отмечает уведомление прочитанным
+}
@@ -3,32 +3,66 @@ package privelege
 import (
 	dPrivelege "github.com/cantylv/authorization-service/internal/delivery/privelege"
 	rAgent "github.com/cantylv/authorization-service/internal/repo/agent"
+	rAudit "github.com/cantylv/authorization-service/internal/repo/audit"
 	rGroup "github.com/cantylv/authorization-service/internal/repo/group"
+	rOutbox "github.com/cantylv/authorization-service/internal/repo/outbox"
 	rPrivelege "github.com/cantylv/authorization-service/internal/repo/privelege"
+	rRole "github.com/cantylv/authorization-service/internal/repo/role"
 	rUser "github.com/cantylv/authorization-service/internal/repo/user"
+	uAudit "github.com/cantylv/authorization-service/internal/usecase/audit"
+	uDecision "github.com/cantylv/authorization-service/internal/usecase/decision"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
 	uPrivelege "github.com/cantylv/authorization-service/internal/usecase/privelege"
+	"github.com/cantylv/authorization-service/services/postgres"
 	"github.com/gorilla/mux"
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
 // InitHandlers инициализирует обработчики запросов, отвечающих за права пользователя к ресурсу.
 // Пользователи принадлежат группам, в свою очередь права присваиваются группам, поэтому пользователь, находящийся
-// в какой-то группе наследует ее права.
-func InitHandlers(r *mux.Router, postgresClient *pgx.Conn, logger *zap.Logger) {
+// в какой-то группе наследует ее права. Проверки доступа записываются в журнал решений decisionLogger
+// и кэшируются в accessCache.
+func InitHandlers(r *mux.Router, postgresClient *postgres.Client, decisionLogger uDecision.Logger, accessCache *uPrivelege.AccessCache, logger *zap.Logger) {
 	repoAgent := rAgent.NewRepoLayer(postgresClient)
 	repoPrivelege := rPrivelege.NewRepoLayer(postgresClient)
 	repoUser := rUser.NewRepoLayer(postgresClient)
 	repoGroup := rGroup.NewRepoLayer(postgresClient)
-	usecasePrivelege := uPrivelege.NewUsecaseLayer(repoAgent, repoPrivelege, repoUser, repoGroup)
+	accessPolicy := policy.NewPolicyLayer(rRole.NewRepoLayer(postgresClient))
+	auditRecorder := uAudit.NewRecorderLayer(rAudit.NewRepoLayer(postgresClient), rOutbox.NewRepoLayer(postgresClient))
+	usecasePrivelege := uPrivelege.NewUsecaseLayer(postgresClient, accessPolicy, auditRecorder, decisionLogger, accessCache, repoAgent, repoPrivelege, repoUser, repoGroup)
 	privelegeHandlerManager := dPrivelege.NewPrivelegeHandlerManager(usecasePrivelege, logger)
 	// привелегии, которые назначаются группам
-	r.HandleFunc("/groups/{group_name}/priveleges/new/agents/{agent_name}/who_adds/{email_add}", privelegeHandlerManager.AddAgentToGroup).Methods("POST")                 // добавляет группе нового агента
-	r.HandleFunc("/groups/{group_name}/priveleges/delete/agents/{agent_name}/who_deletes/{email_delete}", privelegeHandlerManager.DeleteAgentFromGroup).Methods("DELETE") // удаляет у группы агента
-	r.HandleFunc("/groups/{group_name}/priveleges/who_asks/{email_ask}", privelegeHandlerManager.GetGroupAgents).Methods("GET")                                           // возвращает список агентов группы
+	r.HandleFunc("/groups/{group_name}/priveleges/new/agents/{agent_name}", privelegeHandlerManager.AddAgentToGroup).Methods("POST")           // добавляет группе нового агента
+	r.HandleFunc("/groups/{group_name}/priveleges/delete/agents/{agent_name}", privelegeHandlerManager.DeleteAgentFromGroup).Methods("DELETE") // удаляет у группы агента
+	r.HandleFunc("/groups/{group_name}/priveleges", privelegeHandlerManager.GetGroupAgents).Methods("GET")                                     // возвращает список агентов группы
+	// привелегии группы на отдельные действия агента
+	r.HandleFunc("/groups/{group_name}/priveleges/new/agents/{agent_name}/actions/{action}", privelegeHandlerManager.AddAgentToGroup).Methods("POST")
+	r.HandleFunc("/groups/{group_name}/priveleges/delete/agents/{agent_name}/actions/{action}", privelegeHandlerManager.DeleteAgentFromGroup).Methods("DELETE")
 	// привелегии, которые назначаются конкретному пользователю
-	r.HandleFunc("/users/{email}/priveleges/new/agents/{agent_name}/who_adds/{email_add}", privelegeHandlerManager.AddAgentToUser).Methods("POST")                 // добавляет пользователю нового агента
-	r.HandleFunc("/users/{email}/priveleges/delete/agents/{agent_name}/who_deletes/{email_delete}", privelegeHandlerManager.DeleteAgentFromUser).Methods("DELETE") // удаляет у пользователя агента
-	r.HandleFunc("/users/{email}/priveleges/who_asks/{email_ask}", privelegeHandlerManager.GetUserAgents).Methods("GET")                                           // возвращает список агентов пользователя (агенты полученные от группы и пользователя )
-	r.HandleFunc("/users/{email}/check_access/agents/{agent_name}", privelegeHandlerManager.CanUserExecute).Methods("GET")                                         // проверяет, можно ли пользователю пользоваться агентом
+	r.HandleFunc("/users/{email}/priveleges/new/agents/{agent_name}", privelegeHandlerManager.AddAgentToUser).Methods("POST")           // добавляет пользователю нового агента
+	r.HandleFunc("/users/{email}/priveleges/delete/agents/{agent_name}", privelegeHandlerManager.DeleteAgentFromUser).Methods("DELETE") // удаляет у пользователя агента
+	r.HandleFunc("/users/{email}/priveleges", privelegeHandlerManager.GetUserAgents).Methods("GET")                                     // возвращает список агентов пользователя (агенты полученные от группы и пользователя )
+	r.HandleFunc("/users/{email}/check_access/agents/{agent_name}", privelegeHandlerManager.CanUserExecute).Methods("GET")              // проверяет, можно ли пользователю пользоваться агентом
+	// запреты имеют приоритет: запрет пользователя > разрешение пользователя > запрет группы > разрешение группы
+	r.HandleFunc("/groups/{group_name}/priveleges/deny/agents/{agent_name}", privelegeHandlerManager.AddDenyToGroup).Methods("POST")                         // запрещает группе доступ к агенту
+	r.HandleFunc("/groups/{group_name}/priveleges/deny/agents/{agent_name}", privelegeHandlerManager.DeleteDenyFromGroup).Methods("DELETE")                  // снимает запрет с группы
+	r.HandleFunc("/groups/{group_name}/priveleges/deny/agents/{agent_name}/actions/{action}", privelegeHandlerManager.AddDenyToGroup).Methods("POST")        // запрещает группе действие агента
+	r.HandleFunc("/groups/{group_name}/priveleges/deny/agents/{agent_name}/actions/{action}", privelegeHandlerManager.DeleteDenyFromGroup).Methods("DELETE") // снимает запрет действия с группы
+	r.HandleFunc("/users/{email}/priveleges/deny/agents/{agent_name}", privelegeHandlerManager.AddDenyToUser).Methods("POST")                                // запрещает пользователю доступ к агенту
+	r.HandleFunc("/users/{email}/priveleges/deny/agents/{agent_name}", privelegeHandlerManager.DeleteDenyFromUser).Methods("DELETE")                         // снимает запрет с пользователя
+	r.HandleFunc("/users/{email}/priveleges/deny/agents/{agent_name}/actions/{action}", privelegeHandlerManager.AddDenyToUser).Methods("POST")               // запрещает пользователю действие агента
+	r.HandleFunc("/users/{email}/priveleges/deny/agents/{agent_name}/actions/{action}", privelegeHandlerManager.DeleteDenyFromUser).Methods("DELETE")        // снимает запрет действия с пользователя
+	// объяснение решения о доступе
+	r.HandleFunc("/users/{email}/check_access/agents/{agent_name}/explain", privelegeHandlerManager.ExplainAccess).Methods("GET")
+	r.HandleFunc("/users/{email}/check_access/agents/{agent_name}/actions/{action}/explain", privelegeHandlerManager.ExplainAccess).Methods("GET")
+	// привелегии пользователя на отдельные действия агента
+	r.HandleFunc("/users/{email}/priveleges/new/agents/{agent_name}/actions/{action}", privelegeHandlerManager.AddAgentToUser).Methods("POST")
+	r.HandleFunc("/users/{email}/priveleges/delete/agents/{agent_name}/actions/{action}", privelegeHandlerManager.DeleteAgentFromUser).Methods("DELETE")
+	r.HandleFunc("/users/{email}/check_access/agents/{agent_name}/actions/{action}", privelegeHandlerManager.CanUserExecute).Methods("GET") // проверяет, можно ли пользователю выполнить действие агента
+	r.HandleFunc("/priveleges/check_access", privelegeHandlerManager.CanUserExecuteBatch).Methods("POST")                                   // проверяет набор пар пользователь - агент или действие агента одним запросом
+	// пользователи с доступом к агенту
+	r.HandleFunc("/agents/{agent_name}/priveleges", privelegeHandlerManager.GetAgentAccessors).Methods("GET")           // возвращает страницу пользователей с доступом к агенту и его действиям
+	r.HandleFunc("/agents/{agent_name}/priveleges/export", privelegeHandlerManager.ExportAgentAccessors).Methods("GET") // выгружает пользователей с доступом к агенту в csv
+	// кэш решений о доступе
+	r.HandleFunc("/priveleges/cache/stats", privelegeHandlerManager.GetAccessCacheStats).Methods("GET") // возвращает счетчики попаданий и промахов кэша проверок доступа
 }
@@ -0,0 +1,38 @@
+package role
+
+import (
+	dRole "github.com/cantylv/authorization-service/internal/delivery/role"
+	rAudit "github.com/cantylv/authorization-service/internal/repo/audit"
+	rGroup "github.com/cantylv/authorization-service/internal/repo/group"
+	rOutbox "github.com/cantylv/authorization-service/internal/repo/outbox"
+	rRole "github.com/cantylv/authorization-service/internal/repo/role"
+	rUser "github.com/cantylv/authorization-service/internal/repo/user"
+	uAudit "github.com/cantylv/authorization-service/internal/usecase/audit"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
+	uRole "github.com/cantylv/authorization-service/internal/usecase/role"
+	"github.com/cantylv/authorization-service/services/postgres"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// InitHandlers инициализирует обработчики запросов, отвечающих за роли. Роль объединяет набор прав и назначается
+// пользователям или группам, участники группы наследуют ее роли.
+func InitHandlers(r *mux.Router, postgresClient *postgres.Client, logger *zap.Logger) {
+	repoRole := rRole.NewRepoLayer(postgresClient)
+	repoUser := rUser.NewRepoLayer(postgresClient)
+	repoGroup := rGroup.NewRepoLayer(postgresClient)
+	accessPolicy := policy.NewPolicyLayer(repoRole)
+	auditRecorder := uAudit.NewRecorderLayer(rAudit.NewRepoLayer(postgresClient), rOutbox.NewRepoLayer(postgresClient))
+	usecaseRole := uRole.NewUsecaseLayer(postgresClient, accessPolicy, auditRecorder, repoRole, repoUser, repoGroup)
+	roleHandlerManager := dRole.NewRoleHandlerManager(usecaseRole, logger)
+	r.HandleFunc("/permissions", roleHandlerManager.GetPermissions).Methods("GET")                                   // возвращает список прав
+	r.HandleFunc("/roles", roleHandlerManager.GetRoles).Methods("GET")                                               // возвращает список ролей
+	r.HandleFunc("/roles", roleHandlerManager.CreateRole).Methods("POST")                                            // создает роль
+	r.HandleFunc("/roles/{role_name}", roleHandlerManager.DeleteRole).Methods("DELETE")                              // удаляет роль
+	r.HandleFunc("/roles/{role_name}/permissions", roleHandlerManager.SetRolePermissions).Methods("PUT")             // заменяет набор прав роли
+	r.HandleFunc("/roles/{role_name}/users/{email}", roleHandlerManager.AssignRoleToUser).Methods("POST")            // назначает роль пользователю
+	r.HandleFunc("/roles/{role_name}/users/{email}", roleHandlerManager.RevokeRoleFromUser).Methods("DELETE")        // отзывает роль у пользователя
+	r.HandleFunc("/roles/{role_name}/groups/{group_name}", roleHandlerManager.AssignRoleToGroup).Methods("POST")     // назначает роль группе
+	r.HandleFunc("/roles/{role_name}/groups/{group_name}", roleHandlerManager.RevokeRoleFromGroup).Methods("DELETE") // отзывает роль у группы
+	r.HandleFunc("/users/{email}/permissions", roleHandlerManager.GetUserPermissions).Methods("GET")                 // возвращает права пользователя
+}
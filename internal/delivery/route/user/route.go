@@ -1,26 +1,45 @@
 package user
 
 import (
-	"net/http"
-
 	"github.com/cantylv/authorization-service/internal/delivery/user"
+	rAudit "github.com/cantylv/authorization-service/internal/repo/audit"
 	rGroup "github.com/cantylv/authorization-service/internal/repo/group"
+	rOidc "github.com/cantylv/authorization-service/internal/repo/oidc"
+	rOutbox "github.com/cantylv/authorization-service/internal/repo/outbox"
+	rPrivelege "github.com/cantylv/authorization-service/internal/repo/privelege"
+	rRole "github.com/cantylv/authorization-service/internal/repo/role"
+	rToken "github.com/cantylv/authorization-service/internal/repo/token"
 	rUser "github.com/cantylv/authorization-service/internal/repo/user"
+	uAudit "github.com/cantylv/authorization-service/internal/usecase/audit"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
 	uUser "github.com/cantylv/authorization-service/internal/usecase/user"
+	"github.com/cantylv/authorization-service/services/oidc"
+	"github.com/cantylv/authorization-service/services/postgres"
 	"github.com/gorilla/mux"
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
 // InitHandlers инициализирует обработчики запросов для работы с пользователями (получение, удаление, создание).
-func InitHandlers(r *mux.Router, postgresClient *pgx.Conn, logger *zap.Logger) {
+func InitHandlers(r *mux.Router, postgresClient *postgres.Client, logger *zap.Logger) {
 	repoUser := rUser.NewRepoLayer(postgresClient)
 	repoGroup := rGroup.NewRepoLayer(postgresClient)
-	ucUser := uUser.NewUsecaseLayer(repoUser, repoGroup)
+	repoPrivelege := rPrivelege.NewRepoLayer(postgresClient)
+	repoToken := rToken.NewRepoLayer(postgresClient)
+	repoOidc := rOidc.NewRepoLayer(postgresClient)
+	accessPolicy := policy.NewPolicyLayer(rRole.NewRepoLayer(postgresClient))
+	auditRecorder := uAudit.NewRecorderLayer(rAudit.NewRepoLayer(postgresClient), rOutbox.NewRepoLayer(postgresClient))
+	ucUser := uUser.NewUsecaseLayer(postgresClient, accessPolicy, auditRecorder, repoUser, repoGroup, repoPrivelege, repoToken, repoOidc, oidc.NewProviderLayer())
 	userHandlerManager := user.NewUserHandlerManager(ucUser, logger)
 	// ручки, отвечающие за создание, получение и удаление пользователя
-	r.HandleFunc("/users", userHandlerManager.Create).Methods("POST")                                      // создание пользователя
-	r.HandleFunc("/users/{email}", userHandlerManager.Read).Methods("GET")                                 // чтение данных пользователя
-	r.HandleFunc("/users/{email}/who_deletes/{email_delete}", userHandlerManager.Delete).Methods("DELETE") // удаление пользователя
-	r.HandleFunc("/openid/callback", func(http.ResponseWriter, *http.Request) {}).Methods("POST")          // callback URL для openID провайдера
+	r.HandleFunc("/users", userHandlerManager.Create).Methods("POST")           // создание пользователя
+	r.HandleFunc("/users/{email}", userHandlerManager.Read).Methods("GET")      // чтение данных пользователя
+	r.HandleFunc("/users/{email}", userHandlerManager.Delete).Methods("DELETE") // удаление пользователя
+	// ручки, отвечающие за вход через OpenID провайдера (authorization code flow с PKCE)
+	r.HandleFunc("/openid/login", userHandlerManager.OpenIDLogin).Methods("GET")               // перенаправление на страницу входа провайдера
+	r.HandleFunc("/openid/callback", userHandlerManager.OpenIDCallback).Methods("GET", "POST") // callback URL для openID провайдера
+	// ручки, отвечающие за аутентификацию пользователя
+	r.HandleFunc("/auth/login", userHandlerManager.Login).Methods("POST")     // вход по почте и паролю, выдает пару токенов
+	r.HandleFunc("/auth/refresh", userHandlerManager.Refresh).Methods("POST") // обмен refresh токена на новую пару токенов
+	r.HandleFunc("/auth/logout", userHandlerManager.Logout).Methods("POST")   // отзыв refresh токена
+	r.HandleFunc("/auth/whoami", userHandlerManager.WhoAmI).Methods("GET")    // данные владельца токена доступа
 }
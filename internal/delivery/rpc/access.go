@@ -0,0 +1,73 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/asaskevich/govalidator"
+	pb "github.com/cantylv/authorization-service/client/privelegepb"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/internal/usecase/privelege"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"go.uber.org/zap"
+)
+
+type AccessServer struct {
+	pb.UnimplementedAccessServiceServer
+	ucPrivelege privelege.Usecase
+	logger      *zap.Logger
+}
+
+// NewAccessServer возвращает gRPC сервис проверок доступа. Проверки используют тот же кэш решений и журнал решений,
+// что и HTTP обработчики.
+func NewAccessServer(ucPrivelege privelege.Usecase, logger *zap.Logger) *AccessServer {
+	return &AccessServer{
+		ucPrivelege: ucPrivelege,
+		logger:      logger,
+	}
+}
+
+func (s *AccessServer) CanExecute(ctx context.Context, req *pb.AccessCheck) (*pb.CanExecuteResponse, error) {
+	requestID := f.GetRequestIDFromCtx(ctx)
+	if err := validateAccessCheck(req); err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	canExecute, err := s.ucPrivelege.CanExecute(ctx, req.GetEmail(), req.GetAgent(), req.GetAction())
+	if err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	return &pb.CanExecuteResponse{CanExecute: canExecute}, nil
+}
+
+// CanExecuteBatch возвращает решения о доступе для набора проверок в порядке проверок.
+func (s *AccessServer) CanExecuteBatch(ctx context.Context, req *pb.CanExecuteBatchRequest) (*pb.CanExecuteBatchResponse, error) {
+	requestID := f.GetRequestIDFromCtx(ctx)
+	requestData := dto.AccessCheckBatchData{Checks: newAccessCheckItems(req.GetChecks())}
+	if err := requestData.Validate(); err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	results, err := s.ucPrivelege.CanExecuteBatch(ctx, requestData.Checks)
+	if err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	return &pb.CanExecuteBatchResponse{Results: newAccessCheckResults(results)}, nil
+}
+
+func (s *AccessServer) ExplainAccess(ctx context.Context, req *pb.AccessCheck) (*pb.AccessExplanation, error) {
+	requestID := f.GetRequestIDFromCtx(ctx)
+	if err := validateAccessCheck(req); err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	explanation, err := s.ucPrivelege.ExplainAccess(ctx, req.GetEmail(), req.GetAgent(), req.GetAction())
+	if err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	return newAccessExplanation(explanation), nil
+}
+
+func validateAccessCheck(req *pb.AccessCheck) error {
+	if !govalidator.IsEmail(req.GetEmail()) {
+		return me.ErrInvalidEmail
+	}
+	return validateAction(req.GetAction())
+}
@@ -0,0 +1,53 @@
+package rpc
+
+import (
+	"context"
+
+	pb "github.com/cantylv/authorization-service/client/privelegepb"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/internal/usecase/agent"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	"go.uber.org/zap"
+)
+
+type AgentServer struct {
+	pb.UnimplementedAgentServiceServer
+	ucAgent agent.Usecase
+	logger  *zap.Logger
+}
+
+// NewAgentServer возвращает gRPC сервис агентов, вызывающий тот же usecase, что и HTTP обработчики.
+func NewAgentServer(ucAgent agent.Usecase, logger *zap.Logger) *AgentServer {
+	return &AgentServer{
+		ucAgent: ucAgent,
+		logger:  logger,
+	}
+}
+
+func (s *AgentServer) CreateAgent(ctx context.Context, req *pb.CreateAgentRequest) (*pb.Agent, error) {
+	requestID := f.GetRequestIDFromCtx(ctx)
+	agentData := dto.CreateAgentData{Actions: req.GetActions()}
+	if err := agentData.Validate(); err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	a, err := s.ucAgent.CreateAgent(ctx, req.GetName(), agentData.Actions)
+	if err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	return newAgent(a), nil
+}
+
+func (s *AgentServer) DeleteAgent(ctx context.Context, req *pb.DeleteAgentRequest) (*pb.DeleteAgentResponse, error) {
+	if err := s.ucAgent.DeleteAgent(ctx, req.GetName()); err != nil {
+		return nil, statusError(err, f.GetRequestIDFromCtx(ctx), s.logger)
+	}
+	return &pb.DeleteAgentResponse{}, nil
+}
+
+func (s *AgentServer) ListAgents(ctx context.Context, _ *pb.ListAgentsRequest) (*pb.ListAgentsResponse, error) {
+	agents, err := s.ucAgent.GetAgents(ctx)
+	if err != nil {
+		return nil, statusError(err, f.GetRequestIDFromCtx(ctx), s.logger)
+	}
+	return &pb.ListAgentsResponse{Agents: newAgents(agents)}, nil
+}
@@ -0,0 +1,84 @@
+package rpc
+
+import (
+	"errors"
+
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorCodes сопоставляет ошибки usecase и dto статусам gRPC. Ошибки, которые HTTP API возвращает со статусом 400,
+// разделены на InvalidArgument, NotFound, AlreadyExists и FailedPrecondition, остальные ошибки считаются внутренними.
+var errorCodes = []struct {
+	code codes.Code
+	errs []error
+}{
+	{codes.Unauthenticated, []error{me.ErrUnauthorized, me.ErrInvalidToken}},
+	{codes.PermissionDenied, []error{
+		me.ErrPermissionDenied,
+		me.ErrCantDeleteRoot,
+		me.ErrDeleteRootFromGroup,
+		me.ErrCantDenyRoot,
+		me.ErrDirectAddRequiresManage,
+	}},
+	{codes.InvalidArgument, []error{
+		me.ErrInvalidData,
+		me.ErrInvalidEmail,
+		me.ErrInvalidFirstName,
+		me.ErrInvalidLastName,
+		me.ErrPasswordTooLong,
+		me.ErrPasswordTooShort,
+		me.ErrPasswordFormat,
+		me.ErrInvalidActionName,
+		me.ErrInvalidGrantPeriod,
+		me.ErrInvalidPagination,
+		me.ErrInvalidAccessCheckBatch,
+		me.ErrUserEmailMustBeDiff,
+	}},
+	{codes.NotFound, []error{
+		me.ErrUserNotExist,
+		me.ErrGroupNotExist,
+		me.ErrAgentNotExist,
+		me.ErrAgentActionNotExist,
+		me.ErrGroupAgentNotExist,
+		me.ErrUserAgentNotExist,
+		me.ErrGroupActionNotExist,
+		me.ErrUserActionNotExist,
+		me.ErrGroupDenyNotExist,
+		me.ErrUserDenyNotExist,
+		me.ErrUserIsNotInGroup,
+	}},
+	{codes.AlreadyExists, []error{
+		me.ErrUserAlreadyExist,
+		me.ErrAgentAlreadyExist,
+		me.ErrUserAlreadyInGroup,
+		me.ErrGroupAgentAlreadyExist,
+		me.ErrUserAgentAlreadyExist,
+		me.ErrGroupActionAlreadyExist,
+		me.ErrUserActionAlreadyExist,
+		me.ErrGroupDenyAlreadyExist,
+		me.ErrUserDenyAlreadyExist,
+	}},
+	{codes.FailedPrecondition, []error{
+		me.ErrUserIsResponsible,
+		me.ErrOwnerCantExitFromGroup,
+	}},
+}
+
+// statusError возвращает статус gRPC для ошибки usecase. Внутренние ошибки логируются, а клиенту возвращается
+// общее сообщение, как и в HTTP API.
+func statusError(err error, requestID string, logger *zap.Logger) error {
+	for _, group := range errorCodes {
+		for _, target := range group.errs {
+			if errors.Is(err, target) {
+				logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+				return status.Error(group.code, err.Error())
+			}
+		}
+	}
+	logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	return status.Error(codes.Internal, me.ErrInternal.Error())
+}
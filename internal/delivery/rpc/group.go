@@ -0,0 +1,80 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/asaskevich/govalidator"
+	pb "github.com/cantylv/authorization-service/client/privelegepb"
+	"github.com/cantylv/authorization-service/internal/usecase/group"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"go.uber.org/zap"
+)
+
+type GroupServer struct {
+	pb.UnimplementedGroupServiceServer
+	ucGroup group.Usecase
+	logger  *zap.Logger
+}
+
+// NewGroupServer возвращает gRPC сервис групп, вызывающий тот же usecase, что и HTTP обработчики.
+func NewGroupServer(ucGroup group.Usecase, logger *zap.Logger) *GroupServer {
+	return &GroupServer{
+		ucGroup: ucGroup,
+		logger:  logger,
+	}
+}
+
+func (s *GroupServer) AddUserToGroup(ctx context.Context, req *pb.AddUserToGroupRequest) (*pb.AddUserToGroupResponse, error) {
+	requestID := f.GetRequestIDFromCtx(ctx)
+	if !govalidator.IsEmail(req.GetEmail()) {
+		return nil, statusError(me.ErrInvalidEmail, requestID, s.logger)
+	}
+	period := newPeriod(req.GetPeriod())
+	if err := period.Validate(); err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	groupName, err := s.ucGroup.AddUserToGroup(ctx, req.GetEmail(), req.GetGroup(), period)
+	if err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	return &pb.AddUserToGroupResponse{Group: groupName}, nil
+}
+
+func (s *GroupServer) KickUserFromGroup(ctx context.Context, req *pb.KickUserFromGroupRequest) (*pb.KickUserFromGroupResponse, error) {
+	requestID := f.GetRequestIDFromCtx(ctx)
+	if !govalidator.IsEmail(req.GetEmail()) {
+		return nil, statusError(me.ErrInvalidEmail, requestID, s.logger)
+	}
+	groupName, err := s.ucGroup.KickUserFromGroup(ctx, req.GetEmail(), req.GetGroup())
+	if err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	return &pb.KickUserFromGroupResponse{Group: groupName}, nil
+}
+
+// ListUserGroups возвращает группы пользователя, общие с группами того, кто выполняет запрос.
+func (s *GroupServer) ListUserGroups(ctx context.Context, req *pb.ListUserGroupsRequest) (*pb.ListUserGroupsResponse, error) {
+	requestID := f.GetRequestIDFromCtx(ctx)
+	if !govalidator.IsEmail(req.GetEmail()) {
+		return nil, statusError(me.ErrInvalidEmail, requestID, s.logger)
+	}
+	groups, err := s.ucGroup.GetUserGroups(ctx, req.GetEmail())
+	if err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	return &pb.ListUserGroupsResponse{Groups: newGroups(groups)}, nil
+}
+
+func (s *GroupServer) ListGroupMembers(ctx context.Context, req *pb.ListGroupMembersRequest) (*pb.ListGroupMembersResponse, error) {
+	requestID := f.GetRequestIDFromCtx(ctx)
+	page := newPagination(req.GetPage())
+	if err := page.Validate(); err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	members, err := s.ucGroup.GetMembers(ctx, req.GetGroup(), page)
+	if err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	return &pb.ListGroupMembersResponse{Members: newGroupMembers(members)}, nil
+}
@@ -0,0 +1,144 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/asaskevich/govalidator"
+	pb "github.com/cantylv/authorization-service/client/privelegepb"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/internal/usecase/privelege"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"go.uber.org/zap"
+)
+
+type PrivelegeServer struct {
+	pb.UnimplementedPrivelegeServiceServer
+	ucPrivelege privelege.Usecase
+	logger      *zap.Logger
+}
+
+// NewPrivelegeServer возвращает gRPC сервис привелегий и запретов групп и пользователей, вызывающий тот же usecase,
+// что и HTTP обработчики.
+func NewPrivelegeServer(ucPrivelege privelege.Usecase, logger *zap.Logger) *PrivelegeServer {
+	return &PrivelegeServer{
+		ucPrivelege: ucPrivelege,
+		logger:      logger,
+	}
+}
+
+func (s *PrivelegeServer) GrantToGroup(ctx context.Context, req *pb.GroupPrivelegeRequest) (*pb.PrivelegeResponse, error) {
+	requestID := f.GetRequestIDFromCtx(ctx)
+	if err := validateAction(req.GetAction()); err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	period := newPeriod(req.GetPeriod())
+	if err := period.Validate(); err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	if err := s.ucPrivelege.AddAgentToGroup(ctx, req.GetAgent(), req.GetAction(), req.GetGroup(), period); err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	return &pb.PrivelegeResponse{}, nil
+}
+
+func (s *PrivelegeServer) RevokeFromGroup(ctx context.Context, req *pb.GroupPrivelegeRequest) (*pb.PrivelegeResponse, error) {
+	return s.groupCall(ctx, req, s.ucPrivelege.DeleteAgentFromGroup)
+}
+
+func (s *PrivelegeServer) DenyToGroup(ctx context.Context, req *pb.GroupPrivelegeRequest) (*pb.PrivelegeResponse, error) {
+	return s.groupCall(ctx, req, s.ucPrivelege.AddDenyToGroup)
+}
+
+func (s *PrivelegeServer) RemoveDenyFromGroup(ctx context.Context, req *pb.GroupPrivelegeRequest) (*pb.PrivelegeResponse, error) {
+	return s.groupCall(ctx, req, s.ucPrivelege.DeleteDenyFromGroup)
+}
+
+func (s *PrivelegeServer) GrantToUser(ctx context.Context, req *pb.UserPrivelegeRequest) (*pb.PrivelegeResponse, error) {
+	requestID := f.GetRequestIDFromCtx(ctx)
+	if err := validateUserPrivelege(req); err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	period := newPeriod(req.GetPeriod())
+	if err := period.Validate(); err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	if err := s.ucPrivelege.AddAgentToUser(ctx, req.GetAgent(), req.GetAction(), req.GetEmail(), period); err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	return &pb.PrivelegeResponse{}, nil
+}
+
+func (s *PrivelegeServer) RevokeFromUser(ctx context.Context, req *pb.UserPrivelegeRequest) (*pb.PrivelegeResponse, error) {
+	return s.userCall(ctx, req, s.ucPrivelege.DeleteAgentFromUser)
+}
+
+func (s *PrivelegeServer) DenyToUser(ctx context.Context, req *pb.UserPrivelegeRequest) (*pb.PrivelegeResponse, error) {
+	return s.userCall(ctx, req, s.ucPrivelege.AddDenyToUser)
+}
+
+func (s *PrivelegeServer) RemoveDenyFromUser(ctx context.Context, req *pb.UserPrivelegeRequest) (*pb.PrivelegeResponse, error) {
+	return s.userCall(ctx, req, s.ucPrivelege.DeleteDenyFromUser)
+}
+
+func (s *PrivelegeServer) ListGroupAgents(ctx context.Context, req *pb.ListGroupAgentsRequest) (*pb.ListAgentsResponse, error) {
+	agents, err := s.ucPrivelege.GetGroupAgents(ctx, req.GetGroup())
+	if err != nil {
+		return nil, statusError(err, f.GetRequestIDFromCtx(ctx), s.logger)
+	}
+	return &pb.ListAgentsResponse{Agents: newAgents(agents)}, nil
+}
+
+func (s *PrivelegeServer) ListUserAgents(ctx context.Context, req *pb.ListUserAgentsRequest) (*pb.ListAgentsResponse, error) {
+	requestID := f.GetRequestIDFromCtx(ctx)
+	if !govalidator.IsEmail(req.GetEmail()) {
+		return nil, statusError(me.ErrInvalidEmail, requestID, s.logger)
+	}
+	agents, err := s.ucPrivelege.GetUserAgents(ctx, req.GetEmail())
+	if err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	return &pb.ListAgentsResponse{Agents: newAgents(agents)}, nil
+}
+
+// groupCall проверяет действие и вызывает метод usecase, изменяющий привелегии или запреты группы без срока действия.
+func (s *PrivelegeServer) groupCall(ctx context.Context, req *pb.GroupPrivelegeRequest,
+	call func(ctx context.Context, agentName, action, groupName string) error) (*pb.PrivelegeResponse, error) {
+	requestID := f.GetRequestIDFromCtx(ctx)
+	if err := validateAction(req.GetAction()); err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	if err := call(ctx, req.GetAgent(), req.GetAction(), req.GetGroup()); err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	return &pb.PrivelegeResponse{}, nil
+}
+
+// userCall проверяет почту и действие и вызывает метод usecase, изменяющий привелегии или запреты пользователя
+// без срока действия.
+func (s *PrivelegeServer) userCall(ctx context.Context, req *pb.UserPrivelegeRequest,
+	call func(ctx context.Context, agentName, action, email string) error) (*pb.PrivelegeResponse, error) {
+	requestID := f.GetRequestIDFromCtx(ctx)
+	if err := validateUserPrivelege(req); err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	if err := call(ctx, req.GetAgent(), req.GetAction(), req.GetEmail()); err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	return &pb.PrivelegeResponse{}, nil
+}
+
+func validateUserPrivelege(req *pb.UserPrivelegeRequest) error {
+	if !govalidator.IsEmail(req.GetEmail()) {
+		return me.ErrInvalidEmail
+	}
+	return validateAction(req.GetAction())
+}
+
+// validateAction проверяет название действия, пустое действие означает агента целиком.
+func validateAction(action string) error {
+	if action == "" {
+		return nil
+	}
+	return dto.ValidateActionName(action)
+}
@@ -0,0 +1,137 @@
+package rpc
+
+import (
+	pb "github.com/cantylv/authorization-service/client/privelegepb"
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newPeriod(period *pb.GrantPeriod) *dto.GrantPeriodData {
+	var data dto.GrantPeriodData
+	if period.GetValidFrom() != nil {
+		validFrom := period.GetValidFrom().AsTime()
+		data.ValidFrom = &validFrom
+	}
+	if period.GetValidUntil() != nil {
+		validUntil := period.GetValidUntil().AsTime()
+		data.ValidUntil = &validUntil
+	}
+	return &data
+}
+
+func newPagination(page *pb.Pagination) *dto.Pagination {
+	return &dto.Pagination{
+		Limit:  int(page.GetLimit()),
+		Offset: int(page.GetOffset()),
+	}
+}
+
+func newUser(u *ent.User) *pb.User {
+	return &pb.User{
+		Id:        u.ID,
+		Email:     u.Email,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+	}
+}
+
+func newGroups(groups []*ent.Group) []*pb.Group {
+	result := make([]*pb.Group, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, &pb.Group{
+			Id:      int64(g.ID),
+			Name:    g.Name,
+			OwnerId: g.OwnerID,
+		})
+	}
+	return result
+}
+
+func newGroupMembers(members []*ent.GroupMember) []*pb.GroupMember {
+	result := make([]*pb.GroupMember, 0, len(members))
+	for _, m := range members {
+		member := &pb.GroupMember{
+			Id:        m.ID,
+			Email:     m.Email,
+			FirstName: m.FirstName,
+			LastName:  m.LastName,
+			IsOwner:   m.IsOwner,
+			ValidFrom: timestamppb.New(m.ValidFrom),
+		}
+		if m.ValidUntil != nil {
+			member.ValidUntil = timestamppb.New(*m.ValidUntil)
+		}
+		result = append(result, member)
+	}
+	return result
+}
+
+func newAgent(a *ent.Agent) *pb.Agent {
+	return &pb.Agent{
+		Id:      int64(a.ID),
+		Name:    a.Name,
+		Actions: a.Actions,
+	}
+}
+
+func newAgents(agents []*ent.Agent) []*pb.Agent {
+	result := make([]*pb.Agent, 0, len(agents))
+	for _, a := range agents {
+		result = append(result, newAgent(a))
+	}
+	return result
+}
+
+func newAccessCheckItems(checks []*pb.AccessCheck) []dto.AccessCheckItem {
+	result := make([]dto.AccessCheckItem, 0, len(checks))
+	for _, check := range checks {
+		result = append(result, dto.AccessCheckItem{
+			Email:  check.GetEmail(),
+			Agent:  check.GetAgent(),
+			Action: check.GetAction(),
+		})
+	}
+	return result
+}
+
+func newAccessCheckResults(results []*ent.AccessCheckResult) []*pb.AccessCheckResult {
+	response := make([]*pb.AccessCheckResult, 0, len(results))
+	for _, r := range results {
+		response = append(response, &pb.AccessCheckResult{
+			Email:       r.Email,
+			Agent:       r.Agent,
+			Action:      r.Action,
+			CanExecute:  r.CanExecute,
+			Decision:    r.Decision,
+			FailedCheck: r.FailedCheck,
+		})
+	}
+	return response
+}
+
+func newAccessExplanation(e *ent.AccessExplanation) *pb.AccessExplanation {
+	rules := make([]*pb.AccessRule, 0, len(e.Rules))
+	for _, r := range e.Rules {
+		rules = append(rules, &pb.AccessRule{
+			Effect:          r.Effect,
+			Source:          r.Source,
+			Group:           r.Group,
+			ViaGroup:        r.ViaGroup,
+			Action:          r.Action,
+			Table:           r.Table,
+			RowId:           int64(r.RowID),
+			ParticipationId: int64(r.ParticipationID),
+			Applied:         r.Applied,
+		})
+	}
+	return &pb.AccessExplanation{
+		Email:       e.Email,
+		Agent:       e.Agent,
+		Action:      e.Action,
+		CanExecute:  e.CanExecute,
+		Decision:    e.Decision,
+		FailedCheck: e.FailedCheck,
+		Rules:       rules,
+	}
+}
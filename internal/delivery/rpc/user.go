@@ -0,0 +1,69 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/asaskevich/govalidator"
+	pb "github.com/cantylv/authorization-service/client/privelegepb"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/internal/usecase/user"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"go.uber.org/zap"
+)
+
+type UserServer struct {
+	pb.UnimplementedUserServiceServer
+	ucUser user.Usecase
+	logger *zap.Logger
+}
+
+// NewUserServer возвращает gRPC сервис пользователей, вызывающий тот же usecase, что и HTTP обработчики.
+func NewUserServer(ucUser user.Usecase, logger *zap.Logger) *UserServer {
+	return &UserServer{
+		ucUser: ucUser,
+		logger: logger,
+	}
+}
+
+func (s *UserServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+	requestID := f.GetRequestIDFromCtx(ctx)
+	signForm := dto.CreateData{
+		Email:     req.GetEmail(),
+		Password:  req.GetPassword(),
+		FirstName: req.GetFirstName(),
+		LastName:  req.GetLastName(),
+	}
+	if err := signForm.Validate(); err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	u, err := s.ucUser.Create(ctx, &signForm)
+	if err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	return newUser(u), nil
+}
+
+func (s *UserServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	requestID := f.GetRequestIDFromCtx(ctx)
+	if !govalidator.IsEmail(req.GetEmail()) {
+		return nil, statusError(me.ErrInvalidEmail, requestID, s.logger)
+	}
+	u, err := s.ucUser.Read(ctx, req.GetEmail())
+	if err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	return newUser(u), nil
+}
+
+// DeleteUser удаляет пользователя. Удалить пользователя может пользователь с правом user.delete или он сам.
+func (s *UserServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error) {
+	requestID := f.GetRequestIDFromCtx(ctx)
+	if !govalidator.IsEmail(req.GetEmail()) {
+		return nil, statusError(me.ErrInvalidEmail, requestID, s.logger)
+	}
+	if err := s.ucUser.Delete(ctx, req.GetEmail()); err != nil {
+		return nil, statusError(err, requestID, s.logger)
+	}
+	return &pb.DeleteUserResponse{}, nil
+}
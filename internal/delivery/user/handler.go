@@ -99,8 +99,8 @@ func (h *UserHandlerManager) Read(w http.ResponseWriter, r *http.Request) {
 }
 
 // Delete метод удаление пользователя, в случае успеха возвращает сообщение о том, что пользователь был удален.
-// Требует идентификации в запросе, так как инициируется авторизованным пользователем.
-// Удалить пользователя может только root. Конечно, пользователь может удалить самого себя.
+// Требует аутентификации, так как инициируется авторизованным пользователем.
+// Удалить пользователя может пользователь с правом user.delete. Конечно, пользователь может удалить самого себя.
 func (h *UserHandlerManager) Delete(w http.ResponseWriter, r *http.Request) {
 	requestID, err := f.GetCtxRequestID(r)
 	if err != nil {
@@ -112,20 +112,19 @@ func (h *UserHandlerManager) Delete(w http.ResponseWriter, r *http.Request) {
 		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
 		return
 	}
-	userEmailDelete := mux.Vars(r)["email_delete"]
-	if !govalidator.IsEmail(userEmailDelete) {
-		h.logger.Info(me.ErrInvalidEmail.Error(), zap.String(mc.RequestID, requestID))
-		f.Response(w, dto.ResponseError{Error: me.ErrInvalidEmail.Error()}, http.StatusBadRequest)
-		return
-	}
-	err = h.ucUser.Delete(r.Context(), userEmail, userEmailDelete)
+	err = h.ucUser.Delete(r.Context(), userEmail)
 	if err != nil {
 		if errors.Is(err, me.ErrUserNotExist) || errors.Is(err, me.ErrUserIsResponsible) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
 			return
 		}
-		if errors.Is(err, me.ErrOnlyRootCanDeleteUser) || errors.Is(err, me.ErrCantDeleteRoot) {
+		if errors.Is(err, me.ErrUnauthorized) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, me.ErrPermissionDenied) || errors.Is(err, me.ErrCantDeleteRoot) {
 			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
 			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
 			return
@@ -136,3 +135,214 @@ func (h *UserHandlerManager) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 	f.Response(w, dto.ResponseDetail{Detail: "user was succesful deleted"}, http.StatusOK)
 }
+
+// Login метод входа в систему по почте и паролю, в случае успеха возвращает пару токенов. Токен доступа
+// необходимо передавать в заголовке 'Authorization: Bearer <token>', refresh токен нужен для получения новой пары.
+func (h *UserHandlerManager) Login(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	var loginForm dto.LoginData
+	err = json.Unmarshal(body, &loginForm)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	err = loginForm.Validate()
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	token, err := h.ucUser.Login(r.Context(), &loginForm)
+	if err != nil {
+		if errors.Is(err, me.ErrInvalidCredentials) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	f.Response(w, token, http.StatusOK)
+}
+
+// Refresh метод обменивает refresh токен на новую пару токенов, предъявленный refresh токен отзывается.
+func (h *UserHandlerManager) Refresh(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	var refreshForm dto.RefreshData
+	err = json.Unmarshal(body, &refreshForm)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	err = refreshForm.Validate()
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	token, err := h.ucUser.Refresh(r.Context(), &refreshForm)
+	if err != nil {
+		if errors.Is(err, me.ErrInvalidRefreshToken) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	f.Response(w, token, http.StatusOK)
+}
+
+// Logout метод отзывает refresh токен пользователя.
+func (h *UserHandlerManager) Logout(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	var refreshForm dto.RefreshData
+	err = json.Unmarshal(body, &refreshForm)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	err = refreshForm.Validate()
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	err = h.ucUser.Logout(r.Context(), &refreshForm)
+	if err != nil {
+		if errors.Is(err, me.ErrInvalidRefreshToken) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	f.Response(w, dto.ResponseDetail{Detail: "user was succesful logged out"}, http.StatusOK)
+}
+
+// WhoAmI метод возвращает данные пользователя, которому принадлежит токен доступа.
+func (h *UserHandlerManager) WhoAmI(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	u, err := h.ucUser.WhoAmI(r.Context())
+	if err != nil {
+		if errors.Is(err, me.ErrUnauthorized) || errors.Is(err, me.ErrUserNotExist) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: me.ErrUnauthorized.Error()}, http.StatusUnauthorized)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	f.Response(w, getUserWithoutPassword(u), http.StatusOK)
+}
+
+// OpenIDLogin метод начинает вход через OpenID провайдера, перенаправляет пользователя на страницу входа провайдера.
+func (h *UserHandlerManager) OpenIDLogin(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	authURL, err := h.ucUser.OpenIDLogin(r.Context())
+	if err != nil {
+		if errors.Is(err, me.ErrOpenIDDisabled) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusNotFound)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// OpenIDCallback метод завершает вход через OpenID провайдера, в случае успеха возвращает пару токенов.
+// Провайдер передает параметры в query string, либо в теле формы (response_mode=form_post).
+func (h *UserHandlerManager) OpenIDCallback(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	callbackData := dto.OpenIDCallbackData{
+		Code:             r.FormValue("code"),
+		State:            r.FormValue("state"),
+		Error:            r.FormValue("error"),
+		ErrorDescription: r.FormValue("error_description"),
+	}
+	err = callbackData.Validate()
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID),
+			zap.String("error", callbackData.Error), zap.String("error_description", callbackData.ErrorDescription))
+		if errors.Is(err, me.ErrOpenIDAuthentication) {
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	token, err := h.ucUser.OpenIDCallback(r.Context(), &callbackData)
+	if err != nil {
+		if errors.Is(err, me.ErrInvalidOpenIDState) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, me.ErrOpenIDAuthentication) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: me.ErrOpenIDAuthentication.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, me.ErrOpenIDEmailNotVerify) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, me.ErrOpenIDDisabled) {
+			h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: err.Error()}, http.StatusNotFound)
+			return
+		}
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInternal.Error()}, http.StatusInternalServerError)
+		return
+	}
+	f.Response(w, token, http.StatusOK)
+}
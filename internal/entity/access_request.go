@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// AccessRequest заявка пользователя на временный доступ к агенту или к одному из его действий. Если указана группа,
+// то пользователь просит участие в ней, иначе прямой доступ. Одобренная заявка выдает доступ до ValidUntil.
+type AccessRequest struct {
+	ID            int        `json:"id"`
+	UserID        string     `json:"-"`
+	Email         string     `json:"email"`
+	AgentID       int        `json:"-"`
+	Agent         string     `json:"agent"`
+	ActionID      *int       `json:"-"`
+	Action        string     `json:"action,omitempty"`
+	GroupID       *int       `json:"-"`
+	Group         string     `json:"group,omitempty"`
+	Justification string     `json:"justification"`
+	Duration      string     `json:"duration"`
+	Status        string     `json:"status"`
+	Reviewer      string     `json:"reviewer,omitempty"`
+	ValidUntil    *time.Time `json:"valid_until,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
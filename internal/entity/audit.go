@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEntry запись журнала аудита: кто (Actor), что сделал (Action) и с каким объектом (TargetType, Target).
+// Before и After содержат состояние объекта до и после изменения, Hash считается от PrevHash и всех полей записи.
+type AuditEntry struct {
+	ID         int64           `json:"id"`
+	ActorID    *string         `json:"actor_id,omitempty"`
+	Actor      string          `json:"actor,omitempty"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	Target     string          `json:"target"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	PrevHash   string          `json:"prev_hash"`
+	Hash       string          `json:"hash"`
+}
+
+// AuditVerification результат проверки цепочки хэшей журнала аудита. FirstInvalidID - первая запись, хэш или
+// связь с предыдущей записью которой не сходится, LastHash можно сохранить вне сервиса и сравнить при следующей проверке.
+type AuditVerification struct {
+	Valid          bool   `json:"valid"`
+	Checked        int64  `json:"checked"`
+	FirstInvalidID *int64 `json:"first_invalid_id,omitempty"`
+	LastHash       string `json:"last_hash"`
+}
+
+// AuditMember состояние участия пользователя в группе или его ответственности за группу.
+type AuditMember struct {
+	User       string     `json:"user"`
+	ValidFrom  *time.Time `json:"valid_from,omitempty"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+}
+
+// AuditGrant состояние разрешения или запрета на агента. Пустое действие означает агента целиком.
+type AuditGrant struct {
+	Agent      string     `json:"agent"`
+	Action     string     `json:"action,omitempty"`
+	ValidFrom  *time.Time `json:"valid_from,omitempty"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+}
+
+// AuditParent родительская группа подгруппы.
+type AuditParent struct {
+	Parent string `json:"parent"`
+}
+
+// AuditUser состояние пользователя без пароля.
+type AuditUser struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// AuditRole назначение роли пользователю или группе.
+type AuditRole struct {
+	Role string `json:"role"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// AccessDecision запись журнала решений о доступе: кто (Caller) спрашивал о доступе пользователя User к агенту
+// Agent, с какого адреса (RealIP из заголовка X-Real-IP) и какое решение было принято. Если проверка не дошла
+// до правил, FailedCheck указывает непройденную проверку.
+type AccessDecision struct {
+	ID          int64     `json:"id"`
+	CheckedAt   time.Time `json:"checked_at"`
+	CallerID    string    `json:"caller_id,omitempty"`
+	Caller      string    `json:"caller,omitempty"`
+	User        string    `json:"user"`
+	Agent       string    `json:"agent"`
+	Action      string    `json:"action,omitempty"`
+	RealIP      string    `json:"real_ip,omitempty"`
+	Allowed     bool      `json:"allowed"`
+	Decision    string    `json:"decision"`
+	FailedCheck string    `json:"failed_check,omitempty"`
+	RequestID   string    `json:"request_id,omitempty"`
+}
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"unicode/utf8"
+
+	"github.com/asaskevich/govalidator"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+)
+
+// INPUT DATAFLOW
+// AccessCheckBatchData набор проверок доступа, выполняемых одним запросом. Пустое действие означает проверку
+// доступа к агенту целиком.
+type AccessCheckBatchData struct {
+	Checks []AccessCheckItem `json:"checks"`
+}
+
+type AccessCheckItem struct {
+	Email  string `json:"email"`
+	Agent  string `json:"agent"`
+	Action string `json:"action"`
+}
+
+func (h *AccessCheckBatchData) Validate() error {
+	if len(h.Checks) == 0 || len(h.Checks) > mc.MaxAccessCheckBatch {
+		return me.ErrInvalidAccessCheckBatch
+	}
+	for _, check := range h.Checks {
+		if !govalidator.IsEmail(check.Email) {
+			return me.ErrInvalidEmail
+		}
+		if l := utf8.RuneCountInString(check.Agent); l < 2 || l > 50 {
+			return me.ErrInvalidData
+		}
+		if check.Action != "" {
+			if err := ValidateActionName(check.Action); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
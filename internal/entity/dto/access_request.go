@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/asaskevich/govalidator"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+)
+
+// INPUT DATAFLOW
+// CreateAccessRequestData заявка на доступ к агенту. Action и Group необязательны, Duration задается в формате
+// Go, например '4h' или '30m'.
+type CreateAccessRequestData struct {
+	Agent         string `json:"agent"`
+	Action        string `json:"action"`
+	Group         string `json:"group"`
+	Justification string `json:"justification"`
+	Duration      string `json:"duration"`
+
+	duration time.Duration
+}
+
+func (h *CreateAccessRequestData) Validate() error {
+	if l := utf8.RuneCountInString(h.Agent); l < 2 || l > 50 {
+		return me.ErrInvalidData
+	}
+	if h.Action != "" {
+		if err := ValidateActionName(h.Action); err != nil {
+			return err
+		}
+	}
+	if l := utf8.RuneCountInString(h.Group); h.Group != "" && (l < 2 || l > 30) {
+		return me.ErrInvalidData
+	}
+	if l := utf8.RuneCountInString(h.Justification); l < 10 || l > 500 {
+		return me.ErrInvalidJustification
+	}
+	d, err := time.ParseDuration(h.Duration)
+	if err != nil || d <= 0 {
+		return me.ErrInvalidRequestDuration
+	}
+	h.duration = d
+	return nil
+}
+
+// RequestedDuration возвращает запрошенный срок доступа. Вызывается после Validate.
+func (h *CreateAccessRequestData) RequestedDuration() time.Duration {
+	return h.duration
+}
+
+// AccessRequestFilter фильтр списка заявок на доступ, пустые поля не учитываются.
+type AccessRequestFilter struct {
+	Status string
+	Agent  string
+	Group  string
+	Email  string
+}
+
+func (h *AccessRequestFilter) Validate() error {
+	switch h.Status {
+	case "", mc.AccessRequestInProgress, mc.AccessRequestApproved, mc.AccessRequestRejected, mc.AccessRequestCanceled:
+	default:
+		return me.ErrInvalidRequestStatus
+	}
+	if h.Email != "" && !govalidator.IsEmail(h.Email) {
+		return me.ErrInvalidEmail
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"regexp"
+
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+)
+
+var actionNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// INPUT DATAFLOW
+// CreateAgentData действия, которые предоставляет создаваемый агент. Тело запроса необязательно.
+type CreateAgentData struct {
+	Actions []string `json:"actions"`
+}
+
+func (h *CreateAgentData) Validate() error {
+	for _, action := range h.Actions {
+		if err := ValidateActionName(action); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ValidateActionName(action string) error {
+	if !actionNameRegexp.MatchString(action) {
+		return me.ErrInvalidActionName
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+)
+
+// INPUT DATAFLOW
+// AuditFilter фильтр и страница журнала аудита, пустые поля не учитываются. From и To ограничивают время записи
+// полуинтервалом [From, To).
+type AuditFilter struct {
+	Pagination
+	Actor      string
+	Action     string
+	TargetType string
+	Target     string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+}
+
+// Validate проверяет фильтр и страницу.
+func (h *AuditFilter) Validate() error {
+	switch h.TargetType {
+	case "", mc.AuditTargetAgent, mc.AuditTargetGroup, mc.AuditTargetUser, mc.AuditTargetBid, mc.AuditTargetRole:
+	default:
+		return me.ErrInvalidAuditTarget
+	}
+	if h.Actor != "" && !govalidator.IsEmail(h.Actor) {
+		return me.ErrInvalidEmail
+	}
+	if h.From != nil && h.To != nil && !h.To.After(*h.From) {
+		return me.ErrInvalidTimeRange
+	}
+	return h.Pagination.Validate()
+}
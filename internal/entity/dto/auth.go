@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"github.com/asaskevich/govalidator"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+)
+
+// INPUT DATAFLOW
+type LoginData struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+func (h *LoginData) Validate() error {
+	if !govalidator.IsEmail(h.Email) {
+		return me.ErrInvalidEmail
+	}
+	if h.Password == "" {
+		return me.ErrInvalidCredentials
+	}
+	return nil
+}
+
+type RefreshData struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (h *RefreshData) Validate() error {
+	if h.RefreshToken == "" {
+		return me.ErrInvalidRefreshToken
+	}
+	return nil
+}
+
+// OpenIDCallbackData параметры, с которыми OpenID провайдер возвращает пользователя на callback URL.
+type OpenIDCallbackData struct {
+	Code             string
+	State            string
+	Error            string
+	ErrorDescription string
+}
+
+func (h *OpenIDCallbackData) Validate() error {
+	if h.Error != "" {
+		return me.ErrOpenIDAuthentication
+	}
+	if h.Code == "" || h.State == "" {
+		return me.ErrInvalidOpenIDState
+	}
+	return nil
+}
+
+// OUTPUT DATAFLOW
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
@@ -1,8 +1,68 @@
 package dto
 
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/asaskevich/govalidator"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+)
+
 type Bid struct {
-	ID        int    `json:"id"`
-	GroupName string `json:"group_name"`
-	UserId    string    `json:"user_id"`
-	Status    string `json:"status"`
+	ID            int              `json:"id"`
+	GroupName     string           `json:"group_name"`
+	UserId        string           `json:"user_id"`
+	Email         string           `json:"email,omitempty"`
+	Status        string           `json:"status"`
+	Reviewer      string           `json:"reviewer,omitempty"`
+	ReviewComment string           `json:"review_comment,omitempty"`
+	ReviewedAt    *time.Time       `json:"reviewed_at,omitempty"`
+	CreatedAt     *time.Time       `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time       `json:"updated_at,omitempty"`
+	History       []*BidTransition `json:"history,omitempty"`
+}
+
+// BidTransition переход заявки между статусами. У записи о создании заявки нет status_from,
+// Actor пустой, если пользователь удален или автор решения неизвестен.
+type BidTransition struct {
+	StatusFrom string    `json:"status_from,omitempty"`
+	StatusTo   string    `json:"status_to"`
+	Actor      string    `json:"actor,omitempty"`
+	Comment    string    `json:"comment,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// INPUT DATAFLOW
+// BidReviewData комментарий к рассмотрению или отмене заявки. Тело запроса необязательно.
+type BidReviewData struct {
+	Comment string `json:"comment"`
+}
+
+func (h *BidReviewData) Validate() error {
+	if utf8.RuneCountInString(h.Comment) > 500 {
+		return me.ErrInvalidReviewComment
+	}
+	return nil
+}
+
+// BidFilter фильтр и страница списка заявок на создание группы, пустые поля не учитываются.
+type BidFilter struct {
+	Pagination
+	Status string
+	Email  string
+	Group  string
+}
+
+// Validate проверяет фильтр и страницу.
+func (h *BidFilter) Validate() error {
+	switch h.Status {
+	case "", mc.BidInProgress, mc.BidApproved, mc.BidRejected, mc.BidCancelled:
+	default:
+		return me.ErrInvalidBidStatus
+	}
+	if h.Email != "" && !govalidator.IsEmail(h.Email) {
+		return me.ErrInvalidEmail
+	}
+	return h.Pagination.Validate()
 }
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+)
+
+// INPUT DATAFLOW
+// DecisionFilter фильтр и страница журнала решений о доступе, пустые поля не учитываются. From и To ограничивают
+// время проверки полуинтервалом [From, To).
+type DecisionFilter struct {
+	Pagination
+	Caller  string
+	User    string
+	Agent   string
+	Action  string
+	RealIP  string
+	Allowed *bool
+	From    *time.Time
+	To      *time.Time
+}
+
+// Validate проверяет фильтр и страницу.
+func (h *DecisionFilter) Validate() error {
+	if h.Caller != "" && !govalidator.IsEmail(h.Caller) {
+		return me.ErrInvalidEmail
+	}
+	if h.User != "" && !govalidator.IsEmail(h.User) {
+		return me.ErrInvalidEmail
+	}
+	if h.From != nil && h.To != nil && !h.To.After(*h.From) {
+		return me.ErrInvalidTimeRange
+	}
+	return h.Pagination.Validate()
+}
@@ -0,0 +1,24 @@
+package dto
+
+import (
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+)
+
+// INPUT DATAFLOW
+// InvitationFilter фильтр и страница списка приглашений, пустые поля не учитываются.
+type InvitationFilter struct {
+	Pagination
+	Status string
+	Group  string
+}
+
+// Validate проверяет фильтр и страницу.
+func (h *InvitationFilter) Validate() error {
+	switch h.Status {
+	case "", mc.InvitationPending, mc.InvitationAccepted, mc.InvitationDeclined, mc.InvitationRevoked, mc.InvitationExpired:
+	default:
+		return me.ErrInvalidInvitationStatus
+	}
+	return h.Pagination.Validate()
+}
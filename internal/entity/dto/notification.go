@@ -0,0 +1,8 @@
+package dto
+
+// INPUT DATAFLOW
+// NotificationFilter страница списка уведомлений, Unread оставляет только непрочитанные.
+type NotificationFilter struct {
+	Pagination
+	Unread bool
+}
@@ -0,0 +1,25 @@
+package dto
+
+import (
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+)
+
+// INPUT DATAFLOW
+// Pagination страница списка, передается параметрами запроса limit и offset. Limit = 0 означает размер
+// страницы по умолчанию.
+type Pagination struct {
+	Limit  int
+	Offset int
+}
+
+// Validate проверяет страницу и подставляет размер страницы по умолчанию.
+func (h *Pagination) Validate() error {
+	if h.Limit == 0 {
+		h.Limit = mc.DefaultPageLimit
+	}
+	if h.Limit < 0 || h.Limit > mc.MaxPageLimit || h.Offset < 0 {
+		return me.ErrInvalidPagination
+	}
+	return nil
+}
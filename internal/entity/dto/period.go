@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"time"
+
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+)
+
+// INPUT DATAFLOW
+// GrantPeriodData срок действия привелегии или участия в группе. Тело запроса необязательно: без valid_from запись
+// действует с момента создания, без valid_until бессрочно.
+type GrantPeriodData struct {
+	ValidFrom  *time.Time `json:"valid_from"`
+	ValidUntil *time.Time `json:"valid_until"`
+}
+
+func (h *GrantPeriodData) Validate() error {
+	if h.ValidUntil == nil {
+		return nil
+	}
+	if !h.ValidUntil.After(time.Now()) {
+		return me.ErrInvalidGrantPeriod
+	}
+	if h.ValidFrom != nil && !h.ValidUntil.After(*h.ValidFrom) {
+		return me.ErrInvalidGrantPeriod
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"regexp"
+
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+)
+
+var roleNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_.-]{1,49}$`)
+
+// INPUT DATAFLOW
+type CreateRoleData struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+func (h *CreateRoleData) Validate() error {
+	if !roleNameRegexp.MatchString(h.Name) {
+		return me.ErrInvalidRoleName
+	}
+	return nil
+}
+
+type RolePermissionsData struct {
+	Permissions []string `json:"permissions"`
+}
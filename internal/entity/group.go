@@ -1,7 +1,24 @@
 package entity
 
+import "time"
+
+// Group группа пользователей. OwnerID основной ответственный за группу, кроме него у группы могут быть
+// совладельцы (т. group_owner). ParentID заполняется только при чтении одной группы, участники группы
+// наследуют привелегии всех родительских групп.
 type Group struct {
-	ID      int    `json:"id"`
-	Name    string `json:"name"`
-	OwnerID string `json:"owner_id"`
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	OwnerID  string `json:"owner_id"`
+	ParentID *int   `json:"-"`
+}
+
+// GroupMember участник группы. IsOwner показывает, является ли участник одним из ответственных за группу.
+type GroupMember struct {
+	ID         string     `json:"id"`
+	Email      string     `json:"email"`
+	FirstName  string     `json:"first_name"`
+	LastName   string     `json:"last_name"`
+	IsOwner    bool       `json:"is_owner"`
+	ValidFrom  time.Time  `json:"valid_from"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
 }
@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// OpenIDState незавершенная попытка входа через OpenID провайдера. Живет до возврата пользователя
+// на callback URL, либо до истечения срока 'oidc.state_ttl'.
+type OpenIDState struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
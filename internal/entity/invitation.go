@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// Invitation приглашение пользователя в группу. Приглашенный становится участником группы только после принятия
+// приглашения, ValidFrom и ValidUntil задают срок будущего участия. Ожидающее ответа приглашение истекает в ExpiresAt.
+type Invitation struct {
+	ID          int        `json:"id"`
+	GroupID     int        `json:"-"`
+	Group       string     `json:"group"`
+	UserID      string     `json:"-"`
+	Email       string     `json:"email"`
+	InviterID   *string    `json:"-"`
+	Inviter     string     `json:"inviter,omitempty"`
+	Status      string     `json:"status"`
+	ValidFrom   *time.Time `json:"valid_from,omitempty"`
+	ValidUntil  *time.Time `json:"valid_until,omitempty"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
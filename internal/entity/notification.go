@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Notification уведомление пользователя о событии. Payload содержит данные события, его формат зависит от Event.
+type Notification struct {
+	ID        int64           `json:"id"`
+	Event     string          `json:"event"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+	ReadAt    *time.Time      `json:"read_at,omitempty"`
+}
+
+// GroupEvent данные событий участия в группе: User - участник или приглашенный пользователь, Actor - пользователь,
+// который совершил действие.
+type GroupEvent struct {
+	Group        string `json:"group"`
+	User         string `json:"user"`
+	Actor        string `json:"actor,omitempty"`
+	InvitationID int    `json:"invitation_id,omitempty"`
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ChangeEvent событие изменения, влияющего на доступ к агентам, которое доставляется подписчикам через outbox.
+// Event совпадает с действием журнала аудита (например privilege.revoke) или равен grant.expired для привелегий
+// и участий, удаленных по истечении срока. Before и After содержат состояние объекта до и после изменения.
+type ChangeEvent struct {
+	ID         int64           `json:"id"`
+	Event      string          `json:"event"`
+	TargetType string          `json:"target_type"`
+	Target     string          `json:"target"`
+	Actor      string          `json:"actor,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	// Attempts номер текущей попытки доставки, подписчикам не передается
+	Attempts int `json:"-"`
+}
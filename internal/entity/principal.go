@@ -0,0 +1,10 @@
+package entity
+
+// Principal аутентифицированный пользователь, от имени которого выполняется запрос.
+// Groups и Agents берутся из токена доступа и отражают права на момент его выпуска.
+type Principal struct {
+	ID     string
+	Email  string
+	Groups []string
+	Agents []string
+}
@@ -1,18 +1,129 @@
 package entity
 
+import "time"
+
 type GroupPrivelege struct {
-	ID      int `json:"id"`
-	GroupID int `json:"group_id"`
-	AgentID int `json:"agent_id"`
+	ID         int        `json:"id"`
+	GroupID    int        `json:"group_id"`
+	AgentID    int        `json:"agent_id"`
+	ValidFrom  time.Time  `json:"valid_from"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
 }
 
 type UserPrivelege struct {
+	ID         int        `json:"id"`
+	UserID     string     `json:"user_id"`
+	AgentID    int        `json:"agent_id"`
+	ValidFrom  time.Time  `json:"valid_from"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+}
+
+// AccessCheck результат проверки доступа пользователя к агенту или к одному из его действий.
+// Содержит наличие подходящих запретов и разрешений на каждом уровне, итоговое решение принимает usecase.
+// NextChange - ближайший момент, когда решение может измениться по сроку действия привелегий или участий.
+type AccessCheck struct {
+	UserExists   bool
+	AgentExists  bool
+	ActionExists bool
+	UserDeny     bool
+	UserAllow    bool
+	GroupDeny    bool
+	GroupAllow   bool
+	UserID       string
+	AgentID      int
+	NextChange   *time.Time
+}
+
+// AccessCacheStats счетчики кэша решений о доступе с момента запуска сервиса. Invalidations - количество записей,
+// сброшенных по уведомлениям об изменении прав, Evictions - вытесненных при переполнении кэша.
+type AccessCacheStats struct {
+	Enabled       bool    `json:"enabled"`
+	Size          int     `json:"size"`
+	Capacity      int     `json:"capacity"`
+	TTL           string  `json:"ttl"`
+	Hits          int64   `json:"hits"`
+	Misses        int64   `json:"misses"`
+	HitRatio      float64 `json:"hit_ratio"`
+	Evictions     int64   `json:"evictions"`
+	Invalidations int64   `json:"invalidations"`
+}
+
+// AccessExplanation объясняет решение о доступе: Decision указывает сработавшее правило, Rules содержит все
+// подходящие под проверку разрешения и запреты в порядке приоритета. Если проверка не дошла до правил,
+// FailedCheck указывает, какая именно проверка не прошла.
+type AccessExplanation struct {
+	Email       string        `json:"email"`
+	Agent       string        `json:"agent"`
+	Action      string        `json:"action,omitempty"`
+	CanExecute  bool          `json:"can_execute"`
+	Decision    string        `json:"decision"`
+	FailedCheck string        `json:"failed_check,omitempty"`
+	Rules       []*AccessRule `json:"rules"`
+}
+
+// AccessCheckResult решение о доступе из пакетной проверки. Если проверка не дошла до правил, Decision равно
+// check_failed, а FailedCheck указывает, какая именно проверка не прошла.
+type AccessCheckResult struct {
+	Email       string `json:"email"`
+	Agent       string `json:"agent"`
+	Action      string `json:"action,omitempty"`
+	CanExecute  bool   `json:"can_execute"`
+	Decision    string `json:"decision"`
+	FailedCheck string `json:"failed_check,omitempty"`
+}
+
+// AgentAccessor пользователь с действующим доступом к агенту (Action пустое) или к его действию. Source равно user,
+// если доступ дает индивидуальная привелегия, и group, если он унаследован от групп Groups, в которых пользователь
+// состоит напрямую или через дочерние группы.
+type AgentAccessor struct {
+	Email  string   `json:"email"`
+	Action string   `json:"action,omitempty"`
+	Source string   `json:"source"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// AccessRule строка привелегий или запретов, подходящая под проверку доступа. Для правил группы
+// ParticipationID указывает запись в participation, через которую пользователь получил правило группы,
+// а ViaGroup группу этого участия, если правило унаследовано от родительской группы Group.
+// Пустой Action означает правило на агента целиком.
+type AccessRule struct {
+	Effect          string `json:"effect"`
+	Source          string `json:"source"`
+	Group           string `json:"group,omitempty"`
+	ViaGroup        string `json:"via_group,omitempty"`
+	Action          string `json:"action,omitempty"`
+	Table           string `json:"table"`
+	RowID           int    `json:"row_id"`
+	ParticipationID int    `json:"participation_id,omitempty"`
+	Applied         bool   `json:"applied"`
+}
+
+// Agent агент серверной архитектуры. Actions содержит действия агента, а в списках агентов пользователя
+// или группы только те действия, к которым есть доступ.
+type Agent struct {
+	ID      int      `json:"id"`
+	Name    string   `json:"name"`
+	Actions []string `json:"actions"`
+}
+
+// AgentAction действие, которое предоставляет агент, например 'read' у агента 'archive'.
+type AgentAction struct {
 	ID      int    `json:"id"`
-	UserID  string `json:"user_id"`
 	AgentID int    `json:"agent_id"`
+	Name    string `json:"name"`
 }
 
-type Agent struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+// ExpiredGrant запись о привелегии или участии в группе, удаленной фоновой очисткой после истечения срока действия.
+// Table указывает таблицу, из которой была удалена запись, RowID ее идентификатор в этой таблице.
+type ExpiredGrant struct {
+	ID         int       `json:"id"`
+	Table      string    `json:"table"`
+	RowID      int       `json:"row_id"`
+	UserID     string    `json:"user_id,omitempty"`
+	GroupID    int       `json:"group_id,omitempty"`
+	AgentID    int       `json:"agent_id,omitempty"`
+	ActionID   int       `json:"action_id,omitempty"`
+	ValidFrom  time.Time `json:"valid_from"`
+	ValidUntil time.Time `json:"valid_until"`
+	RemovedAt  time.Time `json:"removed_at"`
 }
@@ -0,0 +1,16 @@
+package entity
+
+// Permission право на выполнение действия в сервисе, например 'agent.create'.
+type Permission struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Role именованный набор прав. Роль назначается пользователям и группам.
+type Role struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	IsSystem    bool     `json:"is_system"`
+	Permissions []string `json:"permissions"`
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// RefreshToken запись о выданном refresh токене. Сам токен не хранится, только его хэш.
+type RefreshToken struct {
+	ID         string
+	UserID     string
+	TokenHash  string
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+	ReplacedBy *string
+}
@@ -37,7 +37,9 @@ type AccessLogEnd struct {
 func Access(h http.Handler, logger *zap.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		requestId := uuid.NewV4().String()
+		realIp := r.Header.Get(mc.XRealIP)
 		ctx := context.WithValue(r.Context(), mc.AccessKey(mc.RequestID), requestId)
+		ctx = context.WithValue(ctx, mc.AccessKey(mc.RealIP), realIp)
 		r = r.WithContext(ctx)
 
 		rec := recorder.NewResponseWriter(w)
@@ -45,7 +47,7 @@ func Access(h http.Handler, logger *zap.Logger) http.Handler {
 		timeNow := time.Now()
 		startLog := AccessLogStart{
 			UserAgent:      r.UserAgent(),
-			RealIp:         r.Header.Get(mc.XRealIP),
+			RealIp:         realIp,
 			ContentLength:  r.ContentLength,
 			URI:            r.RequestURI,
 			Method:         r.Method,
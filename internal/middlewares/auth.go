@@ -0,0 +1,43 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"go.uber.org/zap"
+)
+
+// Auth middleware, который аутентифицирует пользователя по заголовку 'Authorization: Bearer <token>'.
+// Если заголовка нет, запрос обрабатывается анонимно, а решение о доступе принимает usecase. Если токен
+// невалиден, возвращается статус 401.
+func Auth(h http.Handler, logger *zap.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get(mc.Authorization)
+		if header == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		requestID, err := f.GetCtxRequestID(r)
+		if err != nil {
+			logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+		}
+		tokenString, ok := strings.CutPrefix(header, mc.BearerPrefix)
+		if !ok {
+			logger.Info(me.ErrInvalidToken.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: me.ErrInvalidToken.Error()}, http.StatusUnauthorized)
+			return
+		}
+		principal, err := f.ParseAccessToken(tokenString)
+		if err != nil {
+			logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			f.Response(w, dto.ResponseError{Error: me.ErrInvalidToken.Error()}, http.StatusUnauthorized)
+			return
+		}
+		r = r.WithContext(f.SetCtxPrincipal(r.Context(), principal))
+		h.ServeHTTP(w, r)
+	})
+}
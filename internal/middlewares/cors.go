@@ -11,6 +11,7 @@ func Cors(h http.Handler) http.Handler {
 		// Нужен для Postman | в реальной жизни для версии продукта мы должны устанавливать доменные имена вместо "*".
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "POST, DELETE, GET, OPTIONS, HEAD")
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
 		// Preflight-request обработка.
 		if r.Method == http.MethodOptions {
 			return
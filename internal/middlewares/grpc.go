@@ -0,0 +1,104 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/satori/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// InitGRPC возвращает цепочку перехватчиков gRPC сервера, повторяющую цепочку middlewares HTTP сервера.
+func InitGRPC(logger *zap.Logger) grpc.ServerOption {
+	return grpc.ChainUnaryInterceptor(
+		AccessGRPC(logger),
+		RecoverGRPC(logger),
+		AuthGRPC(logger),
+	)
+}
+
+// AccessGRPC перехватчик, который кладет в контекст идентификатор запроса и IP-адрес клиента из метаданных
+// 'x-real-ip', а также регистрирует начало и конец обработки вызова.
+func AccessGRPC(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		requestId := uuid.NewV4().String()
+		realIp := getMetadata(ctx, mc.XRealIP)
+		ctx = context.WithValue(ctx, mc.AccessKey(mc.RequestID), requestId)
+		ctx = context.WithValue(ctx, mc.AccessKey(mc.RealIP), realIp)
+
+		timeNow := time.Now()
+		logger.Info("init request",
+			zap.String("user-agent", getMetadata(ctx, "user-agent")),
+			zap.String("real-ip", realIp),
+			zap.String("method", info.FullMethod),
+			zap.String("start-time-human", f.FormatTime(timeNow)),
+			zap.String(mc.RequestID, requestId),
+		)
+		resp, err := handler(ctx, req)
+
+		timeEnd := time.Now()
+		logger.Info("end of request",
+			zap.Int64("latensy-ms", timeEnd.Sub(timeNow).Milliseconds()),
+			zap.String("response-status", status.Code(err).String()),
+			zap.String("end-time-human", f.FormatTime(timeEnd)),
+			zap.String(mc.RequestID, requestId),
+		)
+		return resp, err
+	}
+}
+
+// RecoverGRPC перехватчик для обработки паники, возникающей в работе сервера. В случае паники возвращается
+// статус Internal c сообщением об ошибке внутри сервера.
+func RecoverGRPC(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error(fmt.Sprintf("error while handling request: %v", r), zap.String(mc.RequestID, f.GetRequestIDFromCtx(ctx)))
+				resp, err = nil, status.Error(codes.Internal, me.ErrInternal.Error())
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// AuthGRPC перехватчик, который аутентифицирует пользователя по метаданным 'authorization: Bearer <token>'.
+// Если метаданных нет, вызов обрабатывается анонимно, а решение о доступе принимает usecase. Если токен
+// невалиден, возвращается статус Unauthenticated.
+func AuthGRPC(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		header := getMetadata(ctx, mc.Authorization)
+		if header == "" {
+			return handler(ctx, req)
+		}
+		requestID := f.GetRequestIDFromCtx(ctx)
+		tokenString, ok := strings.CutPrefix(header, mc.BearerPrefix)
+		if !ok {
+			logger.Info(me.ErrInvalidToken.Error(), zap.String(mc.RequestID, requestID))
+			return nil, status.Error(codes.Unauthenticated, me.ErrInvalidToken.Error())
+		}
+		principal, err := f.ParseAccessToken(tokenString)
+		if err != nil {
+			logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+			return nil, status.Error(codes.Unauthenticated, me.ErrInvalidToken.Error())
+		}
+		return handler(f.SetCtxPrincipal(ctx, principal), req)
+	}
+}
+
+// getMetadata возвращает первое значение ключа из входящих метаданных вызова или пустую строку.
+func getMetadata(ctx context.Context, key string) string {
+	values := metadata.ValueFromIncomingContext(ctx, key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
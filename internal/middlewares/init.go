@@ -10,6 +10,7 @@ import (
 // Init инициализирует цепочку middlewares. 
 func Init(r *mux.Router, logger *zap.Logger) (h http.Handler) {
 	h = Cors(r)
+	h = Auth(h, logger)
 	h = Recover(h, logger)
 	h = Access(h, logger)
 	return h
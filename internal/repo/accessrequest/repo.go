@@ -0,0 +1,129 @@
+package accessrequest
+
+import (
+	"context"
+	"time"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/cantylv/authorization-service/services/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type Repo interface {
+	Create(ctx context.Context, userID string, agentID int, actionID, groupID *int, justification string, duration time.Duration) (int, error)
+	Get(ctx context.Context, id int) (*ent.AccessRequest, error)
+	List(ctx context.Context, filter *dto.AccessRequestFilter, viewerID *string) ([]*ent.AccessRequest, error)
+	SetStatus(ctx context.Context, id int, status string, reviewerID *string, validUntil *time.Time) error
+}
+
+var _ Repo = (*RepoLayer)(nil)
+
+type RepoLayer struct {
+	dbConn postgres.DB
+}
+
+// NewRepoLayer возвращает структуру уровня repository. Позволяет работать с заявками на доступ к агентам.
+func NewRepoLayer(dbConn postgres.DB) *RepoLayer {
+	return &RepoLayer{
+		dbConn: dbConn,
+	}
+}
+
+var (
+	// нерассмотренная заявка на тот же доступ уже есть, если вставка не произошла
+	sqlRowCreateAccessRequest = `
+		INSERT INTO access_request(user_id, agent_id, action_id, group_id, justification, duration)
+		VALUES ($1, $2, $3, $4, $5, make_interval(secs => $6))
+		ON CONFLICT DO NOTHING
+		RETURNING id
+	`
+	sqlRowSelectAccessRequests = `
+		SELECT ar.id, ar.user_id, u.email, ar.agent_id, a.name, ar.action_id, COALESCE(aa.name, ''),
+			ar.group_id, COALESCE(g.name, ''), ar.justification, EXTRACT(EPOCH FROM ar.duration)::bigint,
+			ar.status, COALESCE(rv.email, ''), ar.valid_until, ar.created_at, ar.updated_at
+		FROM access_request ar
+		JOIN "user" u ON u.id = ar.user_id
+		JOIN agent a ON a.id = ar.agent_id
+		LEFT JOIN agent_action aa ON aa.id = ar.action_id
+		LEFT JOIN "group" g ON g.id = ar.group_id
+		LEFT JOIN "user" rv ON rv.id = ar.reviewer_id
+	`
+	sqlRowGetAccessRequest = sqlRowSelectAccessRequests + `WHERE ar.id = $1`
+	// если viewer задан, то ему видны только его заявки и заявки в группы, за которые он отвечает
+	sqlRowListAccessRequests = sqlRowSelectAccessRequests + `
+		WHERE ($1 = '' OR ar.status::text = $1)
+			AND ($2 = '' OR a.name = $2)
+			AND ($3 = '' OR g.name = $3)
+			AND ($4 = '' OR u.email = $4)
+			AND ($5::uuid IS NULL OR ar.user_id = $5
+				OR EXISTS (SELECT 1 FROM group_owner o WHERE o.group_id = ar.group_id AND o.user_id = $5))
+		ORDER BY ar.created_at DESC, ar.id DESC
+	`
+	sqlRowSetAccessRequestStatus = `
+		UPDATE access_request
+		SET status = $2, reviewer_id = $3, valid_until = $4, updated_at = now()
+		WHERE id = $1 AND status = 'in_progress'
+	`
+)
+
+func scanAccessRequest(row pgx.Row) (*ent.AccessRequest, error) {
+	var ar ent.AccessRequest
+	var seconds int64
+	err := row.Scan(&ar.ID, &ar.UserID, &ar.Email, &ar.AgentID, &ar.Agent, &ar.ActionID, &ar.Action,
+		&ar.GroupID, &ar.Group, &ar.Justification, &seconds,
+		&ar.Status, &ar.Reviewer, &ar.ValidUntil, &ar.CreatedAt, &ar.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	ar.Duration = (time.Duration(seconds) * time.Second).String()
+	return &ar, nil
+}
+
+// Create создает заявку на доступ и возвращает ее идентификатор. Возвращает sql.ErrNoRows, если у пользователя
+// уже есть нерассмотренная заявка на тот же доступ.
+func (r *RepoLayer) Create(ctx context.Context, userID string, agentID int, actionID, groupID *int, justification string, duration time.Duration) (int, error) {
+	row := r.dbConn.QueryRow(ctx, sqlRowCreateAccessRequest, userID, agentID, actionID, groupID, justification, duration.Seconds())
+	var id int
+	err := row.Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *RepoLayer) Get(ctx context.Context, id int) (*ent.AccessRequest, error) {
+	return scanAccessRequest(r.dbConn.QueryRow(ctx, sqlRowGetAccessRequest, id))
+}
+
+// List возвращает заявки, подходящие под фильтр, начиная с новых. viewerID = nil означает просмотр всех заявок.
+func (r *RepoLayer) List(ctx context.Context, filter *dto.AccessRequestFilter, viewerID *string) ([]*ent.AccessRequest, error) {
+	rows, err := r.dbConn.Query(ctx, sqlRowListAccessRequests, filter.Status, filter.Agent, filter.Group, filter.Email, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	requests := make([]*ent.AccessRequest, 0)
+	for rows.Next() {
+		ar, err := scanAccessRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, ar)
+	}
+	return requests, rows.Err()
+}
+
+// SetStatus переводит нерассмотренную заявку в статус status. Возвращает ErrNoRowsAffected, если заявка уже
+// была рассмотрена или отменена.
+func (r *RepoLayer) SetStatus(ctx context.Context, id int, status string, reviewerID *string, validUntil *time.Time) error {
+	tag, err := r.dbConn.Exec(ctx, sqlRowSetAccessRequestStatus, id, status, reviewerID, validUntil)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return me.ErrNoRowsAffected
+	}
+	return nil
+}
@@ -5,14 +5,15 @@ import (
 
 	ent "github.com/cantylv/authorization-service/internal/entity"
 	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
-	"github.com/jackc/pgx/v5"
+	"github.com/cantylv/authorization-service/services/postgres"
 	"github.com/spf13/viper"
 )
 
 type Repo interface {
 	Read(ctx context.Context, name string) (*ent.Agent, error)
 	GetAll(ctx context.Context) ([]*ent.Agent, error)
-	Create(ctx context.Context, name string) (*ent.Agent, error)
+	Create(ctx context.Context, name string, actions []string) (*ent.Agent, error)
+	GetAction(ctx context.Context, agentID int, name string) (*ent.AgentAction, error)
 	Delete(ctx context.Context, id int) error
 	IsGroupAgent(ctx context.Context, groupID, agentID int) (bool, error)
 	IsUserAgent(ctx context.Context, userID string, agentID int) (bool, error)
@@ -21,19 +22,31 @@ type Repo interface {
 var _ Repo = (*RepoLayer)(nil)
 
 type RepoLayer struct {
-	dbConn *pgx.Conn
+	dbConn postgres.DB
 }
 
-func NewRepoLayer(dbConn *pgx.Conn) *RepoLayer {
+func NewRepoLayer(dbConn postgres.DB) *RepoLayer {
 	return &RepoLayer{
 		dbConn: dbConn,
 	}
 }
 
+var (
+	// агент вместе с названиями его действий
+	sqlRowSelectAgents = `
+		SELECT a.id, a.name,
+			COALESCE(array_agg(aa.name ORDER BY aa.name) FILTER (WHERE aa.name IS NOT NULL), '{}')
+		FROM agent a
+		LEFT JOIN agent_action aa ON aa.agent_id = a.id
+	`
+	sqlRowReadAgent    = sqlRowSelectAgents + ` WHERE a.name=$1 GROUP BY a.id`
+	sqlRowGetAllAgents = sqlRowSelectAgents + ` GROUP BY a.id ORDER BY a.name`
+)
+
 func (r *RepoLayer) Read(ctx context.Context, name string) (*ent.Agent, error) {
-	row := r.dbConn.QueryRow(ctx, `SELECT id, name FROM agent WHERE name=$1`, name)
+	row := r.dbConn.QueryRow(ctx, sqlRowReadAgent, name)
 	var a ent.Agent
-	err := row.Scan(&a.ID, &a.Name)
+	err := row.Scan(&a.ID, &a.Name, &a.Actions)
 	if err != nil {
 		return nil, err
 	}
@@ -41,14 +54,15 @@ func (r *RepoLayer) Read(ctx context.Context, name string) (*ent.Agent, error) {
 }
 
 func (r *RepoLayer) GetAll(ctx context.Context) ([]*ent.Agent, error) {
-	rows, err := r.dbConn.Query(ctx, `SELECT id, name FROM agent`)
+	rows, err := r.dbConn.Query(ctx, sqlRowGetAllAgents)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 	var as []*ent.Agent
 	for rows.Next() {
 		var a ent.Agent
-		err := rows.Scan(&a.ID, &a.Name)
+		err := rows.Scan(&a.ID, &a.Name, &a.Actions)
 		if err != nil {
 			return nil, err
 		}
@@ -57,7 +71,8 @@ func (r *RepoLayer) GetAll(ctx context.Context) ([]*ent.Agent, error) {
 	return as, nil
 }
 
-func (r *RepoLayer) Create(ctx context.Context, name string) (*ent.Agent, error) {
+// Create создает агента вместе с его действиями и выдает root пользователю доступ к нему.
+func (r *RepoLayer) Create(ctx context.Context, name string, actions []string) (*ent.Agent, error) {
 	tx, err := r.dbConn.Begin(ctx)
 	if err != nil {
 		return nil, err
@@ -87,6 +102,13 @@ func (r *RepoLayer) Create(ctx context.Context, name string) (*ent.Agent, error)
 	if tag.RowsAffected() == 0 {
 		return nil, me.ErrNoRowsAffected
 	}
+	// регистрируем действия агента
+	_, err = tx.Exec(ctx,
+		`INSERT INTO agent_action(agent_id, name) SELECT $1, unnest($2::text[]) ON CONFLICT DO NOTHING`, a.ID, actions)
+	if err != nil {
+		return nil, err
+	}
+	a.Actions = actions
 	// если все прошло успешно, коммитим транзакцию
 	if err = tx.Commit(ctx); err != nil {
 		return nil, err
@@ -105,8 +127,21 @@ func (r *RepoLayer) Delete(ctx context.Context, id int) error {
 	return nil
 }
 
+func (r *RepoLayer) GetAction(ctx context.Context, agentID int, name string) (*ent.AgentAction, error) {
+	row := r.dbConn.QueryRow(ctx, `SELECT id, agent_id, name FROM agent_action WHERE agent_id=$1 AND name=$2`, agentID, name)
+	var a ent.AgentAction
+	err := row.Scan(&a.ID, &a.AgentID, &a.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// IsGroupAgent проверяет, есть ли у группы неистекшая привелегия на агента.
 func (r *RepoLayer) IsGroupAgent(ctx context.Context, groupID, agentID int) (bool, error) {
-	row := r.dbConn.QueryRow(ctx, `SELECT 1 FROM privelege_group WHERE group_id=$1 AND agent_id=$2`, groupID, agentID)
+	row := r.dbConn.QueryRow(ctx,
+		`SELECT 1 FROM privelege_group pg WHERE pg.group_id=$1 AND pg.agent_id=$2 AND `+postgres.NotExpired("pg"),
+		groupID, agentID)
 	var isGroupAgent int
 	err := row.Scan(&isGroupAgent)
 	if err != nil {
@@ -115,8 +150,11 @@ func (r *RepoLayer) IsGroupAgent(ctx context.Context, groupID, agentID int) (boo
 	return true, nil
 }
 
+// IsUserAgent проверяет, есть ли у пользователя неистекшая привелегия на агента.
 func (r *RepoLayer) IsUserAgent(ctx context.Context, userID string, agentID int) (bool, error) {
-	row := r.dbConn.QueryRow(ctx, `SELECT 1 FROM privelege_user WHERE user_id=$1 AND agent_id=$2`, userID, agentID)
+	row := r.dbConn.QueryRow(ctx,
+		`SELECT 1 FROM privelege_user pu WHERE pu.user_id=$1 AND pu.agent_id=$2 AND `+postgres.NotExpired("pu"),
+		userID, agentID)
 	var isUserAgent int
 	err := row.Scan(&isUserAgent)
 	if err != nil {
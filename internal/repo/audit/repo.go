@@ -0,0 +1,114 @@
+package audit
+
+import (
+	"context"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/services/postgres"
+)
+
+type Repo interface {
+	Append(ctx context.Context, entry *ent.AuditEntry) error
+	List(ctx context.Context, filter *dto.AuditFilter) ([]*ent.AuditEntry, error)
+	Verify(ctx context.Context) (*ent.AuditVerification, error)
+}
+
+var _ Repo = (*RepoLayer)(nil)
+
+type RepoLayer struct {
+	dbConn postgres.DB
+}
+
+// NewRepoLayer возвращает структуру уровня repository. Позволяет дописывать и читать журнал аудита.
+// Идентификатор, время и хэши записи выставляет триггер базы данных, изменить или удалить запись нельзя.
+func NewRepoLayer(dbConn postgres.DB) *RepoLayer {
+	return &RepoLayer{
+		dbConn: dbConn,
+	}
+}
+
+var (
+	sqlRowAppendAuditEntry = `
+		INSERT INTO audit_log(actor_id, actor_email, action, target_type, target, before_state, after_state, request_id)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6::jsonb, $7::jsonb, NULLIF($8, ''))
+	`
+	sqlRowListAuditEntries = `
+		SELECT id, actor_id, COALESCE(actor_email, ''), action, target_type, target, before_state, after_state,
+			COALESCE(request_id, ''), created_at, prev_hash, hash
+		FROM audit_log
+		WHERE ($1 = '' OR actor_email = $1)
+			AND ($2 = '' OR action = $2)
+			AND ($3 = '' OR target_type = $3)
+			AND ($4 = '' OR target = $4)
+			AND ($5 = '' OR request_id = $5)
+			AND ($6::timestamptz IS NULL OR created_at >= $6)
+			AND ($7::timestamptz IS NULL OR created_at < $7)
+		ORDER BY id DESC
+		LIMIT $8 OFFSET $9
+	`
+	// запись считается поврежденной, если ее хэш не совпадает с пересчитанным, если она ссылается не на хэш
+	// предыдущей записи или если перед ней пропущена запись. Удаление записей из конца цепочки обнаруживается
+	// сравнением с последней записью в audit_log_head
+	sqlRowVerifyAuditLog = `
+		WITH chain AS (
+			SELECT a.id, a.hash, a.prev_hash, audit_log_hash(a) AS expected_hash,
+				lag(a.id) OVER w AS prev_id, lag(a.hash) OVER w AS prev_row_hash
+			FROM audit_log a
+			WINDOW w AS (ORDER BY a.id)
+		), broken AS (
+			SELECT min(id) AS id FROM chain
+			WHERE hash <> expected_hash
+				OR prev_hash <> COALESCE(prev_row_hash, repeat('0', 64))
+				OR id <> COALESCE(prev_id, 0) + 1
+		), last AS (
+			SELECT id, hash FROM chain ORDER BY id DESC LIMIT 1
+		)
+		SELECT (SELECT count(*) FROM chain),
+			COALESCE((SELECT id FROM broken),
+				CASE WHEN h.last_id <> COALESCE((SELECT id FROM last), 0) OR h.last_hash <> COALESCE((SELECT hash FROM last), repeat('0', 64))
+					THEN COALESCE((SELECT id FROM last), 0) + 1 END),
+			h.last_hash
+		FROM audit_log_head h
+	`
+)
+
+// Append дописывает запись в журнал аудита. Запись добавляется в транзакции изменения, которое она описывает,
+// поэтому при откате изменения откатывается и запись.
+func (r *RepoLayer) Append(ctx context.Context, entry *ent.AuditEntry) error {
+	_, err := r.dbConn.Exec(ctx, sqlRowAppendAuditEntry, entry.ActorID, entry.Actor, entry.Action, entry.TargetType,
+		entry.Target, []byte(entry.Before), []byte(entry.After), entry.RequestID)
+	return err
+}
+
+// List возвращает страницу журнала аудита, начиная с новых записей.
+func (r *RepoLayer) List(ctx context.Context, filter *dto.AuditFilter) ([]*ent.AuditEntry, error) {
+	rows, err := r.dbConn.Query(ctx, sqlRowListAuditEntries, filter.Actor, filter.Action, filter.TargetType,
+		filter.Target, filter.RequestID, filter.From, filter.To, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	entries := make([]*ent.AuditEntry, 0)
+	for rows.Next() {
+		var e ent.AuditEntry
+		err := rows.Scan(&e.ID, &e.ActorID, &e.Actor, &e.Action, &e.TargetType, &e.Target, &e.Before, &e.After,
+			&e.RequestID, &e.CreatedAt, &e.PrevHash, &e.Hash)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
+
+// Verify пересчитывает цепочку хэшей всего журнала и возвращает первую запись, на которой цепочка нарушена.
+func (r *RepoLayer) Verify(ctx context.Context) (*ent.AuditVerification, error) {
+	var v ent.AuditVerification
+	err := r.dbConn.QueryRow(ctx, sqlRowVerifyAuditLog).Scan(&v.Checked, &v.FirstInvalidID, &v.LastHash)
+	if err != nil {
+		return nil, err
+	}
+	v.Valid = v.FirstInvalidID == nil
+	return &v, nil
+}
@@ -0,0 +1,129 @@
+package decision
+
+import (
+	"context"
+	"time"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/services/postgres"
+)
+
+type Repo interface {
+	InsertBatch(ctx context.Context, decisions []*ent.AccessDecision) error
+	List(ctx context.Context, filter *dto.DecisionFilter) ([]*ent.AccessDecision, error)
+	Export(ctx context.Context, filter *dto.DecisionFilter, fn func(d *ent.AccessDecision) error) error
+}
+
+var _ Repo = (*RepoLayer)(nil)
+
+type RepoLayer struct {
+	dbConn postgres.DB
+}
+
+// NewRepoLayer возвращает структуру уровня repository. Позволяет записывать пачками и читать журнал решений о доступе.
+func NewRepoLayer(dbConn postgres.DB) *RepoLayer {
+	return &RepoLayer{
+		dbConn: dbConn,
+	}
+}
+
+// условие фильтра общее для списка и выгрузки
+const sqlDecisionFilter = `
+		WHERE ($1 = '' OR caller_email = $1)
+			AND ($2 = '' OR user_email = $2)
+			AND ($3 = '' OR agent = $3)
+			AND ($4 = '' OR action = $4)
+			AND ($5 = '' OR real_ip = $5)
+			AND ($6::boolean IS NULL OR allowed = $6)
+			AND ($7::timestamptz IS NULL OR checked_at >= $7)
+			AND ($8::timestamptz IS NULL OR checked_at < $8)
+`
+
+const sqlDecisionColumns = `
+		SELECT id, checked_at, COALESCE(caller_id::text, ''), COALESCE(caller_email, ''), user_email, agent,
+			COALESCE(action, ''), COALESCE(real_ip, ''), allowed, decision, COALESCE(failed_check, ''), COALESCE(request_id, '')
+		FROM access_decision
+`
+
+var (
+	// пачка записывается одним запросом, каждый столбец передается массивом
+	sqlRowInsertDecisions = `
+		INSERT INTO access_decision(checked_at, caller_id, caller_email, user_email, agent, action, real_ip, allowed,
+			decision, failed_check, request_id)
+		SELECT d.checked_at, NULLIF(d.caller_id, '')::uuid, NULLIF(d.caller_email, ''), d.user_email, d.agent,
+			NULLIF(d.action, ''), NULLIF(d.real_ip, ''), d.allowed, d.decision, NULLIF(d.failed_check, ''), NULLIF(d.request_id, '')
+		FROM unnest($1::timestamptz[], $2::text[], $3::text[], $4::text[], $5::text[], $6::text[], $7::text[],
+			$8::boolean[], $9::text[], $10::text[], $11::text[])
+			AS d(checked_at, caller_id, caller_email, user_email, agent, action, real_ip, allowed, decision, failed_check, request_id)
+	`
+	sqlRowListDecisions = sqlDecisionColumns + sqlDecisionFilter + `
+		ORDER BY id DESC
+		LIMIT $9 OFFSET $10
+	`
+	sqlRowExportDecisions = sqlDecisionColumns + sqlDecisionFilter + `
+		ORDER BY id
+	`
+)
+
+// InsertBatch записывает пачку решений о доступе одним запросом.
+func (r *RepoLayer) InsertBatch(ctx context.Context, decisions []*ent.AccessDecision) error {
+	n := len(decisions)
+	if n == 0 {
+		return nil
+	}
+	checkedAt, allowed := make([]time.Time, n), make([]bool, n)
+	callerID, caller, user, agent := make([]string, n), make([]string, n), make([]string, n), make([]string, n)
+	action, realIP, decision := make([]string, n), make([]string, n), make([]string, n)
+	failedCheck, requestID := make([]string, n), make([]string, n)
+	for i, d := range decisions {
+		checkedAt[i], allowed[i] = d.CheckedAt, d.Allowed
+		callerID[i], caller[i], user[i], agent[i] = d.CallerID, d.Caller, d.User, d.Agent
+		action[i], realIP[i], decision[i] = d.Action, d.RealIP, d.Decision
+		failedCheck[i], requestID[i] = d.FailedCheck, d.RequestID
+	}
+	_, err := r.dbConn.Exec(ctx, sqlRowInsertDecisions, checkedAt, callerID, caller, user, agent, action, realIP,
+		allowed, decision, failedCheck, requestID)
+	return err
+}
+
+// List возвращает страницу журнала решений о доступе, начиная с новых записей.
+func (r *RepoLayer) List(ctx context.Context, filter *dto.DecisionFilter) ([]*ent.AccessDecision, error) {
+	decisions := make([]*ent.AccessDecision, 0)
+	err := r.query(ctx, sqlRowListDecisions, filter, func(d *ent.AccessDecision) error {
+		decisions = append(decisions, d)
+		return nil
+	}, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return decisions, nil
+}
+
+// Export передает в fn все записи журнала, подходящие под фильтр, от старых к новым. Страница фильтра не
+// учитывается, записи читаются из курсора по одной и не собираются в память.
+func (r *RepoLayer) Export(ctx context.Context, filter *dto.DecisionFilter, fn func(d *ent.AccessDecision) error) error {
+	return r.query(ctx, sqlRowExportDecisions, filter, fn)
+}
+
+func (r *RepoLayer) query(ctx context.Context, sql string, filter *dto.DecisionFilter, fn func(d *ent.AccessDecision) error, args ...any) error {
+	args = append([]any{filter.Caller, filter.User, filter.Agent, filter.Action, filter.RealIP, filter.Allowed,
+		filter.From, filter.To}, args...)
+	rows, err := r.dbConn.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var d ent.AccessDecision
+		err := rows.Scan(&d.ID, &d.CheckedAt, &d.CallerID, &d.Caller, &d.User, &d.Agent, &d.Action, &d.RealIP,
+			&d.Allowed, &d.Decision, &d.FailedCheck, &d.RequestID)
+		if err != nil {
+			return err
+		}
+		if err := fn(&d); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
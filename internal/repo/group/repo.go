@@ -2,40 +2,53 @@ package group
 
 import (
 	"context"
-	"database/sql"
-	"errors"
 	"fmt"
 
 	ent "github.com/cantylv/authorization-service/internal/entity"
 	"github.com/cantylv/authorization-service/internal/entity/dto"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
 	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/cantylv/authorization-service/services/postgres"
 	"github.com/jackc/pgx/v5"
 )
 
 type Repo interface {
 	GetGroup(ctx context.Context, groupName string) (*ent.Group, error)
 	GetBid(ctx context.Context, userID, groupName string) (*dto.Bid, error)
-	AddUserToGroup(ctx context.Context, userID string, groupID int) error
+	GetBidByID(ctx context.Context, bidID int) (*dto.Bid, error)
+	ListBids(ctx context.Context, filter *dto.BidFilter, viewerID *string) ([]*dto.Bid, error)
+	GetBidHistory(ctx context.Context, bidID int) ([]*dto.BidTransition, error)
+	SetBidStatus(ctx context.Context, bidID int, status, actorID, comment string) error
+	AddUserToGroup(ctx context.Context, userID string, groupID int, period *dto.GrantPeriodData) error
 	ApproveGroupCreation(ctx context.Context, ownerID, rootUserID, groupName string) (*ent.Group, error)
-	RejectGroupCreation(ctx context.Context, bidID int) (*dto.Bid, error)
 	MakeBidGroupCreation(ctx context.Context, ownerID, groupName string) (*dto.Bid, error)
 	IsParticipantOfGroup(ctx context.Context, userID string, groupID int) (bool, error)
 	IsOwnerOfGroup(ctx context.Context, userID, groupName string) (bool, error)
+	IsGroupOwner(ctx context.Context, userID string, groupID int) (bool, error)
+	CountOwners(ctx context.Context, groupID int) (int, error)
+	AddOwner(ctx context.Context, groupID int, userID string) (bool, error)
+	RemoveOwner(ctx context.Context, groupID int, userID string) error
+	GetMembers(ctx context.Context, groupID int, page *dto.Pagination) ([]*ent.GroupMember, error)
+	RenameGroup(ctx context.Context, groupID int, groupName string) (*ent.Group, error)
+	DeleteGroup(ctx context.Context, groupID int) error
 	GetCommonGroups(ctx context.Context, userID1, userID2 string) ([]*ent.Group, error)
 	GetUserGroups(ctx context.Context, userID string) ([]*ent.Group, error)
 	KickUserFromGroup(ctx context.Context, userID string, groupID int) error
 	CreateGroup(ctx context.Context, userID, groupName string) (*ent.Group, error)
 	OwnerGroups(ctx context.Context, userID string) ([]*ent.Group, error)
 	UpdateOwner(ctx context.Context, groupID int, newOwnerID string) (*ent.Group, error)
+	SetParent(ctx context.Context, groupID int, parentID *int) error
+	IsAncestor(ctx context.Context, groupID, ancestorID int) (bool, error)
+	GetSubgroups(ctx context.Context, groupID int) ([]*ent.Group, error)
 }
 
 var _ Repo = (*RepoLayer)(nil)
 
 type RepoLayer struct {
-	dbConn *pgx.Conn
+	dbConn postgres.DB
 }
 
-func NewRepoLayer(dbConn *pgx.Conn) *RepoLayer {
+func NewRepoLayer(dbConn postgres.DB) *RepoLayer {
 	return &RepoLayer{
 		dbConn: dbConn,
 	}
@@ -46,43 +59,118 @@ var (
 )
 
 var (
-	sqlRowGetParticipants = `
-		SELECT u.id, u.email, u.first_name, u.last_name
-		FROM "user" u
-		JOIN participant p ON u.id = p.user_id
-		WHERE p.group_id = $1
+	// в список попадает и участие, которое начнет действовать в будущем
+	sqlRowGetMembers = `
+		SELECT u.id, u.email, u.first_name, u.last_name,
+			EXISTS (SELECT 1 FROM group_owner o WHERE o.group_id = p.group_id AND o.user_id = u.id),
+			p.valid_from, p.valid_until
+		FROM participation p
+		JOIN "user" u ON u.id = p.user_id
+		WHERE p.group_id = $1 AND ` + postgres.NotExpired("p") + `
+		ORDER BY u.email
+		LIMIT $2 OFFSET $3
 	`
 	sqlRowCreateGroup = fmt.Sprintf(`INSERT INTO "group"(name, owner_id) VALUES ($1, $2) RETURNING %s`, group_fiels)
-	sqlRowMakeBid     = `
-		INSERT INTO bid (group_name, user_id, status) 
-		VALUES ($1, $2, $3) 
-		RETURNING id, group_name, user_id, status
+	// создание заявки сразу попадает в ее историю
+	sqlRowMakeBid = `
+		WITH b AS (
+			INSERT INTO bid (group_name, user_id, status)
+			VALUES ($1, $2, $3)
+			RETURNING id, group_name, user_id, status, created_at, updated_at
+		), h AS (
+			INSERT INTO bid_history(bid_id, status_to, actor_id)
+			SELECT id, status, user_id FROM b
+		)
+		SELECT id, group_name, user_id, status, created_at, updated_at FROM b
 	`
-	sqlRowRejectBid = `
-		UPDATE bid 
-		SET status = 'rejected' 
-		WHERE id = $1
-		RETURNING id, group_name, user_id, status
+	sqlRowSelectBids = `
+		SELECT b.id, b.group_name, b.user_id, u.email, b.status, COALESCE(rv.email, ''),
+			COALESCE(b.review_comment, ''), b.reviewed_at, b.created_at, b.updated_at
+		FROM bid b
+		JOIN "user" u ON u.id = b.user_id
+		LEFT JOIN "user" rv ON rv.id = b.reviewer_id
+	`
+	sqlRowGetBidByID = sqlRowSelectBids + `WHERE b.id = $1`
+	// если viewer задан, то ему видны только его заявки
+	sqlRowListBids = sqlRowSelectBids + `
+		WHERE ($1 = '' OR b.status::text = $1)
+			AND ($2 = '' OR u.email = $2)
+			AND ($3 = '' OR b.group_name = $3)
+			AND ($4::uuid IS NULL OR b.user_id = $4)
+		ORDER BY b.created_at DESC, b.id DESC
+		LIMIT $5 OFFSET $6
+	`
+	// переход возможен только из нерассмотренного статуса, вместе с ним записывается история
+	sqlRowSetBidStatus = `
+		WITH b AS (
+			UPDATE bid
+			SET status = $2, reviewer_id = $3, review_comment = NULLIF($4, ''), reviewed_at = now(), updated_at = now()
+			WHERE id = $1 AND status = 'in_progress'
+			RETURNING id, status
+		)
+		INSERT INTO bid_history(bid_id, status_from, status_to, actor_id, comment)
+		SELECT id, 'in_progress', status, $3, NULLIF($4, '') FROM b
+	`
+	// UNION отбрасывает повторы, поэтому обход завершается даже при цикле в иерархии
+	sqlRowIsAncestor = `
+		WITH RECURSIVE ancestors(id) AS (
+			SELECT parent_id FROM "group" WHERE id = $1 AND parent_id IS NOT NULL
+			UNION
+			SELECT g.parent_id
+			FROM ancestors a
+			JOIN "group" g ON g.id = a.id
+			WHERE g.parent_id IS NOT NULL
+		)
+		SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $2)
+	`
+	sqlRowGetBidHistory = `
+		SELECT COALESCE(h.status_from::text, ''), h.status_to, COALESCE(u.email, ''), COALESCE(h.comment, ''), h.created_at
+		FROM bid_history h
+		LEFT JOIN "user" u ON u.id = h.actor_id
+		WHERE h.bid_id = $1
+		ORDER BY h.created_at, h.id
 	`
 	sqlRowGetOwnerGroups = `
 		SELECT g.id, g.name, g.owner_id
 		FROM "group" g
-		JOIN "user" u ON g.owner_id = u.id
-		WHERE u.id = $1
+		JOIN group_owner o ON o.group_id = g.id
+		WHERE o.user_id = $1
+	`
+	// ответственный состоит в группе бессрочно, поэтому срок его участия снимается
+	sqlRowAddOwnerParticipation = `
+		INSERT INTO participation(user_id, group_id) VALUES($1, $2)
+		ON CONFLICT (user_id, group_id) DO UPDATE
+		SET valid_from = LEAST(participation.valid_from, now()), valid_until = NULL
+	`
+	// если удаляется основной ответственный, то им становится самый ранний из оставшихся совладельцев
+	sqlRowReassignPrimaryOwner = `
+		UPDATE "group"
+		SET owner_id = (
+			SELECT user_id FROM group_owner WHERE group_id = $1 ORDER BY created_at, user_id LIMIT 1
+		)
+		WHERE id = $1 AND owner_id = $2
 	`
 	sqlRowGetBid = `
 		SELECT id, group_name, user_id, status 
 		FROM bid 
-		WHERE user_id=$1 AND group_name=$2 AND status != 'rejected'
+		WHERE user_id=$1 AND group_name=$2 AND status = 'in_progress'
 	`
 	sqlRowAddUserToGroup = `INSERT INTO participation(user_id, group_id) VALUES ($1, $2)`
+	// истекшее, но еще не удаленное очисткой участие заменяется новым
+	sqlRowAddUserToGroupWithPeriod = `
+		INSERT INTO participation(user_id, group_id, valid_from, valid_until)
+		VALUES ($1, $2, COALESCE($3, now()), $4)
+		ON CONFLICT (user_id, group_id) DO UPDATE
+		SET valid_from = EXCLUDED.valid_from, valid_until = EXCLUDED.valid_until
+		WHERE participation.valid_until <= now()
+	`
 )
 
 // GetGroup возвращает данные о группе
 func (r *RepoLayer) GetGroup(ctx context.Context, groupName string) (*ent.Group, error) {
-	row := r.dbConn.QueryRow(ctx, `SELECT id, name, owner_id FROM "group" WHERE name=$1`, groupName)
+	row := r.dbConn.QueryRow(ctx, `SELECT id, name, owner_id, parent_id FROM "group" WHERE name=$1`, groupName)
 	var g ent.Group
-	err := row.Scan(&g.ID, &g.Name, &g.OwnerID)
+	err := row.Scan(&g.ID, &g.Name, &g.OwnerID, &g.ParentID)
 	if err != nil {
 		return nil, err
 	}
@@ -100,9 +188,75 @@ func (r *RepoLayer) GetBid(ctx context.Context, userID, groupName string) (*dto.
 	return &b, nil
 }
 
-// GetCommonGroups добавляет пользователя в группу
-func (r *RepoLayer) AddUserToGroup(ctx context.Context, ownerID string, groupID int) error {
-	tag, err := r.dbConn.Exec(ctx, sqlRowAddUserToGroup, ownerID, groupID)
+func scanBid(row pgx.Row) (*dto.Bid, error) {
+	var b dto.Bid
+	err := row.Scan(&b.ID, &b.GroupName, &b.UserId, &b.Email, &b.Status, &b.Reviewer,
+		&b.ReviewComment, &b.ReviewedAt, &b.CreatedAt, &b.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// GetBidByID возвращает заявку в любом статусе вместе с данными о рассмотрении
+func (r *RepoLayer) GetBidByID(ctx context.Context, bidID int) (*dto.Bid, error) {
+	return scanBid(r.dbConn.QueryRow(ctx, sqlRowGetBidByID, bidID))
+}
+
+// ListBids возвращает страницу заявок, подходящих под фильтр, начиная с новых. viewerID = nil означает
+// просмотр заявок всех пользователей.
+func (r *RepoLayer) ListBids(ctx context.Context, filter *dto.BidFilter, viewerID *string) ([]*dto.Bid, error) {
+	rows, err := r.dbConn.Query(ctx, sqlRowListBids, filter.Status, filter.Email, filter.Group, viewerID, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	bids := make([]*dto.Bid, 0)
+	for rows.Next() {
+		b, err := scanBid(rows)
+		if err != nil {
+			return nil, err
+		}
+		bids = append(bids, b)
+	}
+	return bids, rows.Err()
+}
+
+// GetBidHistory возвращает переходы заявки между статусами в хронологическом порядке
+func (r *RepoLayer) GetBidHistory(ctx context.Context, bidID int) ([]*dto.BidTransition, error) {
+	rows, err := r.dbConn.Query(ctx, sqlRowGetBidHistory, bidID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	history := make([]*dto.BidTransition, 0)
+	for rows.Next() {
+		var t dto.BidTransition
+		err := rows.Scan(&t.StatusFrom, &t.StatusTo, &t.Actor, &t.Comment, &t.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, &t)
+	}
+	return history, rows.Err()
+}
+
+// SetBidStatus переводит нерассмотренную заявку в статус status от имени пользователя actorID и записывает
+// переход в историю. Возвращает ErrNoRowsAffected, если заявка уже была рассмотрена или отменена.
+func (r *RepoLayer) SetBidStatus(ctx context.Context, bidID int, status, actorID, comment string) error {
+	tag, err := r.dbConn.Exec(ctx, sqlRowSetBidStatus, bidID, status, actorID, comment)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return me.ErrNoRowsAffected
+	}
+	return nil
+}
+
+// AddUserToGroup добавляет пользователя в группу на срок period
+func (r *RepoLayer) AddUserToGroup(ctx context.Context, ownerID string, groupID int, period *dto.GrantPeriodData) error {
+	tag, err := r.dbConn.Exec(ctx, sqlRowAddUserToGroupWithPeriod, ownerID, groupID, period.ValidFrom, period.ValidUntil)
 	if err != nil {
 		return err
 	}
@@ -112,9 +266,12 @@ func (r *RepoLayer) AddUserToGroup(ctx context.Context, ownerID string, groupID
 	return nil
 }
 
-// IsParticipantOfGroup определяет, является ли пользователь членом группы
+// IsParticipantOfGroup определяет, является ли пользователь членом группы. Учитывается и участие, которое
+// начнет действовать в будущем, истекшее участие не учитывается
 func (r *RepoLayer) IsParticipantOfGroup(ctx context.Context, userID string, groupID int) (bool, error) {
-	row := r.dbConn.QueryRow(ctx, `SELECT 1 FROM participation WHERE user_id=$1 AND group_id=$2`, userID, groupID)
+	row := r.dbConn.QueryRow(ctx,
+		`SELECT 1 FROM participation p WHERE p.user_id=$1 AND p.group_id=$2 AND `+postgres.NotExpired("p"),
+		userID, groupID)
 	var isParticipant int
 	err := row.Scan(&isParticipant)
 	if err != nil {
@@ -123,8 +280,12 @@ func (r *RepoLayer) IsParticipantOfGroup(ctx context.Context, userID string, gro
 	return true, nil
 }
 
+// IsOwnerOfGroup определяет, является ли пользователь одним из ответственных за группу. Если не является,
+// возвращает sql.ErrNoRows
 func (r *RepoLayer) IsOwnerOfGroup(ctx context.Context, userID, groupName string) (bool, error) {
-	row := r.dbConn.QueryRow(ctx, `SELECT 1 FROM "group" WHERE owner_id=$1 AND name=$2`, userID, groupName)
+	row := r.dbConn.QueryRow(ctx, `
+		SELECT 1 FROM group_owner o JOIN "group" g ON g.id = o.group_id WHERE o.user_id=$1 AND g.name=$2
+	`, userID, groupName)
 	var isOwner int
 	err := row.Scan(&isOwner)
 	if err != nil {
@@ -140,7 +301,8 @@ func (r *RepoLayer) GetCommonGroups(ctx context.Context, userID1, userID2 string
 		FROM "group" g
 		JOIN participation p1 ON g.id = p1.group_id
 		JOIN participation p2 ON g.id = p2.group_id
-		WHERE p1.user_id = $1 AND p2.user_id = $2;
+		WHERE p1.user_id = $1 AND p2.user_id = $2
+			AND `+postgres.ValidNow("p1")+` AND `+postgres.ValidNow("p2")+`;
 	`, userID1, userID2)
 	if err != nil {
 		return nil, err
@@ -165,7 +327,7 @@ func (r *RepoLayer) GetUserGroups(ctx context.Context, userID string) ([]*ent.Gr
 		SELECT g.id, g.name, g.owner_id
 		FROM "group" g
 		JOIN participation p ON p.group_id = g.id
-		WHERE p.user_id = $1;
+		WHERE p.user_id = $1 AND `+postgres.ValidNow("p")+`;
 	`, userID)
 	if err != nil {
 		return nil, err
@@ -229,8 +391,8 @@ func (r *RepoLayer) CreateGroup(ctx context.Context, userID, groupName string) (
 	return &g, nil
 }
 
-// ApproveGroupCreation метод, который аппрувит статус создания группы каким-либо пользователем.
-// Сперва удаляет запись из таблицы заявок, после добавляет группу в таблицу
+// ApproveGroupCreation метод, который создает группу по одобренной заявке. Сама заявка остается в таблице заявок
+// со статусом approved, его выставляет SetBidStatus
 func (r *RepoLayer) ApproveGroupCreation(ctx context.Context, ownerID, rootUserID, groupName string) (*ent.Group, error) {
 	tx, err := r.dbConn.Begin(ctx)
 	if err != nil {
@@ -242,15 +404,6 @@ func (r *RepoLayer) ApproveGroupCreation(ctx context.Context, ownerID, rootUserI
 		}
 	}()
 
-	// удаляем запись из таблицы заявок
-	tag, err := tx.Exec(ctx, `DELETE FROM bid WHERE group_name=$1 AND user_id=$2`, groupName, ownerID)
-	if err != nil {
-		return nil, err
-	}
-	if tag.RowsAffected() == 0 {
-		err = me.ErrNoRowsAffected
-		return nil, err
-	}
 	// создаем новую группу
 	row := tx.QueryRow(ctx, `INSERT INTO "group"(name, owner_id) VALUES($1, $2) RETURNING id, name, owner_id`, groupName, ownerID)
 	var g ent.Group
@@ -259,7 +412,7 @@ func (r *RepoLayer) ApproveGroupCreation(ctx context.Context, ownerID, rootUserI
 		return nil, err
 	}
 	// добавляем создателя в его группу
-	tag, err = tx.Exec(ctx, sqlRowAddUserToGroup, ownerID, g.ID)
+	tag, err := tx.Exec(ctx, sqlRowAddUserToGroup, ownerID, g.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -283,9 +436,9 @@ func (r *RepoLayer) ApproveGroupCreation(ctx context.Context, ownerID, rootUserI
 
 // MakeBidGroupCreation создает заявку на создание группы
 func (r *RepoLayer) MakeBidGroupCreation(ctx context.Context, ownerID, groupName string) (*dto.Bid, error) {
-	row := r.dbConn.QueryRow(ctx, sqlRowMakeBid, groupName, ownerID, "in_progress")
+	row := r.dbConn.QueryRow(ctx, sqlRowMakeBid, groupName, ownerID, mc.BidInProgress)
 	var g dto.Bid
-	err := row.Scan(&g.ID, &g.GroupName, &g.UserId, &g.Status)
+	err := row.Scan(&g.ID, &g.GroupName, &g.UserId, &g.Status, &g.CreatedAt, &g.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -302,25 +455,21 @@ func (r *RepoLayer) UpdateOwner(ctx context.Context, groupID int, newOwnerID str
 			tx.Rollback(ctx)
 		}
 	}()
-	// проверяем, есть ли пользователь в группе уже
-	row := tx.QueryRow(ctx, `SELECT 1 FROM participation WHERE user_id=$1 AND group_id=$2`, newOwnerID, groupID)
-	var isParticipant int
-	err = row.Scan(&isParticipant)
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+	// добавляем пользователя в группу, если его там нет
+	_, err = tx.Exec(ctx, sqlRowAddOwnerParticipation, newOwnerID, groupID)
+	if err != nil {
 		return nil, err
 	}
-	// если пользователь не в группе, то надо его добавить
-	if isParticipant != 1 {
-		tag, err := tx.Exec(ctx, `INSERT INTO participation(user_id, group_id) VALUES($1, $2)`, newOwnerID, groupID)
-		if err != nil {
-			return nil, err
-		}
-		if tag.RowsAffected() == 0 {
-			return nil, me.ErrNoRowsAffected
-		}
+	// прежний основной ответственный перестает быть ответственным, совладельцы остаются
+	_, err = tx.Exec(ctx, `
+		DELETE FROM group_owner o USING "group" g
+		WHERE g.id = $1 AND o.group_id = g.id AND o.user_id = g.owner_id AND g.owner_id <> $2
+	`, groupID, newOwnerID)
+	if err != nil {
+		return nil, err
 	}
-	// делаем пользователя ответственным
-	row = tx.QueryRow(ctx, `UPDATE "group" SET owner_id=$1 WHERE id=$2 RETURNING id, name, owner_id`, newOwnerID, groupID)
+	// делаем пользователя ответственным, триггер добавляет его в совладельцы
+	row := tx.QueryRow(ctx, `UPDATE "group" SET owner_id=$1 WHERE id=$2 RETURNING id, name, owner_id`, newOwnerID, groupID)
 	var g ent.Group
 	err = row.Scan(&g.ID, &g.Name, &g.OwnerID)
 	if err != nil {
@@ -333,38 +482,32 @@ func (r *RepoLayer) UpdateOwner(ctx context.Context, groupID int, newOwnerID str
 	return &g, nil
 }
 
-func (r *RepoLayer) GetParticipants(ctx context.Context, groupID int) ([]*ent.User, error) {
-	rows, err := r.dbConn.Query(ctx, sqlRowGetParticipants, groupID)
+// GetMembers возвращает страницу участников группы, упорядоченных по email
+func (r *RepoLayer) GetMembers(ctx context.Context, groupID int, page *dto.Pagination) ([]*ent.GroupMember, error) {
+	rows, err := r.dbConn.Query(ctx, sqlRowGetMembers, groupID, page.Limit, page.Offset)
 	if err != nil {
 		return nil, err
 	}
-	var us []*ent.User
+	defer rows.Close()
+	members := make([]*ent.GroupMember, 0)
 	for rows.Next() {
-		var u ent.User
-		err := rows.Scan(&u.ID, &u.Email, &u.FirstName, &u.LastName)
+		var m ent.GroupMember
+		err := rows.Scan(&m.ID, &m.Email, &m.FirstName, &m.LastName, &m.IsOwner, &m.ValidFrom, &m.ValidUntil)
 		if err != nil {
 			return nil, err
 		}
-		us = append(us, &u)
+		members = append(members, &m)
 	}
-	return us, nil
-}
-
-func (r *RepoLayer) RejectGroupCreation(ctx context.Context, bidID int) (*dto.Bid, error) {
-	row := r.dbConn.QueryRow(ctx, sqlRowRejectBid, bidID)
-	var g dto.Bid
-	err := row.Scan(&g.ID, &g.GroupName, &g.UserId, &g.Status)
-	if err != nil {
-		return nil, err
-	}
-	return &g, nil
+	return members, rows.Err()
 }
 
+// OwnerGroups возвращает группы, за которые пользователь отвечает как основной ответственный или совладелец
 func (r *RepoLayer) OwnerGroups(ctx context.Context, userID string) ([]*ent.Group, error) {
 	rows, err := r.dbConn.Query(ctx, sqlRowGetOwnerGroups, userID)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	var groups []*ent.Group
 	for rows.Next() {
@@ -377,3 +520,124 @@ func (r *RepoLayer) OwnerGroups(ctx context.Context, userID string) ([]*ent.Grou
 	}
 	return groups, nil
 }
+
+// SetParent привязывает группу к родительской группе, parentID = nil отвязывает группу от родительской.
+// Проверка на циклы выполняется в usecase.
+func (r *RepoLayer) SetParent(ctx context.Context, groupID int, parentID *int) error {
+	tag, err := r.dbConn.Exec(ctx, `UPDATE "group" SET parent_id=$2 WHERE id=$1`, groupID, parentID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return me.ErrNoRowsAffected
+	}
+	return nil
+}
+
+// IsAncestor проверяет, является ли группа ancestorID родительской для groupID на любом уровне иерархии
+func (r *RepoLayer) IsAncestor(ctx context.Context, groupID, ancestorID int) (bool, error) {
+	row := r.dbConn.QueryRow(ctx, sqlRowIsAncestor, groupID, ancestorID)
+	var isAncestor bool
+	err := row.Scan(&isAncestor)
+	if err != nil {
+		return false, err
+	}
+	return isAncestor, nil
+}
+
+// GetSubgroups возвращает подгруппы первого уровня
+func (r *RepoLayer) GetSubgroups(ctx context.Context, groupID int) ([]*ent.Group, error) {
+	rows, err := r.dbConn.Query(ctx, `SELECT id, name, owner_id FROM "group" WHERE parent_id=$1 ORDER BY name`, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	groups := make([]*ent.Group, 0)
+	for rows.Next() {
+		var g ent.Group
+		err := rows.Scan(&g.ID, &g.Name, &g.OwnerID)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, &g)
+	}
+	return groups, rows.Err()
+}
+
+// IsGroupOwner определяет, является ли пользователь одним из ответственных за группу
+func (r *RepoLayer) IsGroupOwner(ctx context.Context, userID string, groupID int) (bool, error) {
+	row := r.dbConn.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM group_owner WHERE group_id=$1 AND user_id=$2)`, groupID, userID)
+	var isOwner bool
+	err := row.Scan(&isOwner)
+	if err != nil {
+		return false, err
+	}
+	return isOwner, nil
+}
+
+// CountOwners возвращает количество ответственных за группу
+func (r *RepoLayer) CountOwners(ctx context.Context, groupID int) (int, error) {
+	row := r.dbConn.QueryRow(ctx, `SELECT count(*) FROM group_owner WHERE group_id=$1`, groupID)
+	var count int
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// AddOwner делает пользователя совладельцем группы и добавляет его в группу бессрочно. Возвращает false,
+// если пользователь уже был ответственным за группу. Вызывается внутри транзакции usecase.
+func (r *RepoLayer) AddOwner(ctx context.Context, groupID int, userID string) (bool, error) {
+	_, err := r.dbConn.Exec(ctx, sqlRowAddOwnerParticipation, userID, groupID)
+	if err != nil {
+		return false, err
+	}
+	tag, err := r.dbConn.Exec(ctx,
+		`INSERT INTO group_owner(group_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, groupID, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() != 0, nil
+}
+
+// RemoveOwner снимает с пользователя ответственность за группу, участником группы он остается. Если пользователь
+// был основным ответственным, то им становится другой совладелец. Возвращает ErrNoRowsAffected, если
+// пользователь не был ответственным. Вызывается внутри транзакции usecase.
+func (r *RepoLayer) RemoveOwner(ctx context.Context, groupID int, userID string) error {
+	tag, err := r.dbConn.Exec(ctx, `DELETE FROM group_owner WHERE group_id=$1 AND user_id=$2`, groupID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return me.ErrNoRowsAffected
+	}
+	_, err = r.dbConn.Exec(ctx, sqlRowReassignPrimaryOwner, groupID, userID)
+	return err
+}
+
+// RenameGroup меняет название группы
+func (r *RepoLayer) RenameGroup(ctx context.Context, groupID int, groupName string) (*ent.Group, error) {
+	row := r.dbConn.QueryRow(ctx,
+		`UPDATE "group" SET name=$2 WHERE id=$1 RETURNING id, name, owner_id`, groupID, groupName)
+	var g ent.Group
+	err := row.Scan(&g.ID, &g.Name, &g.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// DeleteGroup удаляет группу. Участие, привелегии, запреты, роли и ответственные группы удаляются каскадно,
+// подгруппы становятся группами верхнего уровня.
+func (r *RepoLayer) DeleteGroup(ctx context.Context, groupID int) error {
+	tag, err := r.dbConn.Exec(ctx, `DELETE FROM "group" WHERE id=$1`, groupID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return me.ErrNoRowsAffected
+	}
+	return nil
+}
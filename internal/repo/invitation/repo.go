@@ -0,0 +1,134 @@
+package invitation
+
+import (
+	"context"
+	"time"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/cantylv/authorization-service/services/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type Repo interface {
+	Create(ctx context.Context, groupID int, userID, inviterID string, period *dto.GrantPeriodData, expiresAt time.Time) (int, error)
+	Get(ctx context.Context, id int) (*ent.Invitation, error)
+	List(ctx context.Context, filter *dto.InvitationFilter, viewerID *string) ([]*ent.Invitation, error)
+	SetStatus(ctx context.Context, id int, status string) error
+}
+
+var _ Repo = (*RepoLayer)(nil)
+
+type RepoLayer struct {
+	dbConn postgres.DB
+}
+
+// NewRepoLayer возвращает структуру уровня repository. Позволяет работать с приглашениями в группы.
+func NewRepoLayer(dbConn postgres.DB) *RepoLayer {
+	return &RepoLayer{
+		dbConn: dbConn,
+	}
+}
+
+var (
+	// ожидающее ответа приглашение уже есть, если вставка не произошла. Истекшее, но еще не отмеченное очисткой
+	// приглашение сначала помечается истекшим, чтобы не мешать новому
+	sqlRowExpirePendingInvitation = `
+		UPDATE group_invitation SET status = 'expired', updated_at = now()
+		WHERE group_id = $1 AND user_id = $2 AND status = 'pending' AND expires_at <= now()
+	`
+	sqlRowCreateInvitation = `
+		INSERT INTO group_invitation(group_id, user_id, inviter_id, valid_from, valid_until, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT DO NOTHING
+		RETURNING id
+	`
+	// ожидающее ответа приглашение, срок которого истек, считается истекшим и до очистки
+	sqlRowSelectInvitations = `
+		SELECT i.id, i.group_id, g.name, i.user_id, u.email, i.inviter_id, COALESCE(inv.email, ''),
+			CASE WHEN i.status = 'pending' AND i.expires_at <= now() THEN 'expired' ELSE i.status::text END,
+			i.valid_from, i.valid_until, i.expires_at, i.responded_at, i.created_at, i.updated_at
+		FROM group_invitation i
+		JOIN "group" g ON g.id = i.group_id
+		JOIN "user" u ON u.id = i.user_id
+		LEFT JOIN "user" inv ON inv.id = i.inviter_id
+	`
+	sqlRowGetInvitation = sqlRowSelectInvitations + `WHERE i.id = $1`
+	// если viewer задан, то ему видны только приглашения его самого и приглашения в группы, за которые он отвечает
+	sqlRowListInvitations = sqlRowSelectInvitations + `
+		WHERE ($1 = '' OR CASE WHEN i.status = 'pending' AND i.expires_at <= now() THEN 'expired' ELSE i.status::text END = $1)
+			AND ($2 = '' OR g.name = $2)
+			AND ($3::uuid IS NULL OR i.user_id = $3
+				OR EXISTS (SELECT 1 FROM group_owner o WHERE o.group_id = i.group_id AND o.user_id = $3))
+		ORDER BY i.created_at DESC, i.id DESC
+		LIMIT $4 OFFSET $5
+	`
+	sqlRowSetInvitationStatus = `
+		UPDATE group_invitation
+		SET status = $2, responded_at = now(), updated_at = now()
+		WHERE id = $1 AND status = 'pending' AND expires_at > now()
+	`
+)
+
+func scanInvitation(row pgx.Row) (*ent.Invitation, error) {
+	var i ent.Invitation
+	err := row.Scan(&i.ID, &i.GroupID, &i.Group, &i.UserID, &i.Email, &i.InviterID, &i.Inviter, &i.Status,
+		&i.ValidFrom, &i.ValidUntil, &i.ExpiresAt, &i.RespondedAt, &i.CreatedAt, &i.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+// Create создает приглашение и возвращает его идентификатор. Возвращает sql.ErrNoRows, если у пользователя уже
+// есть ожидающее ответа приглашение в эту группу.
+func (r *RepoLayer) Create(ctx context.Context, groupID int, userID, inviterID string, period *dto.GrantPeriodData, expiresAt time.Time) (int, error) {
+	_, err := r.dbConn.Exec(ctx, sqlRowExpirePendingInvitation, groupID, userID)
+	if err != nil {
+		return 0, err
+	}
+	row := r.dbConn.QueryRow(ctx, sqlRowCreateInvitation, groupID, userID, inviterID, period.ValidFrom, period.ValidUntil, expiresAt)
+	var id int
+	err = row.Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *RepoLayer) Get(ctx context.Context, id int) (*ent.Invitation, error) {
+	return scanInvitation(r.dbConn.QueryRow(ctx, sqlRowGetInvitation, id))
+}
+
+// List возвращает страницу приглашений, подходящих под фильтр, начиная с новых. viewerID = nil означает
+// просмотр всех приглашений.
+func (r *RepoLayer) List(ctx context.Context, filter *dto.InvitationFilter, viewerID *string) ([]*ent.Invitation, error) {
+	rows, err := r.dbConn.Query(ctx, sqlRowListInvitations, filter.Status, filter.Group, viewerID, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	invitations := make([]*ent.Invitation, 0)
+	for rows.Next() {
+		i, err := scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, i)
+	}
+	return invitations, rows.Err()
+}
+
+// SetStatus переводит ожидающее ответа приглашение в статус status. Возвращает ErrNoRowsAffected, если на приглашение
+// уже ответили, его отозвали или оно истекло.
+func (r *RepoLayer) SetStatus(ctx context.Context, id int, status string) error {
+	tag, err := r.dbConn.Exec(ctx, sqlRowSetInvitationStatus, id, status)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return me.ErrNoRowsAffected
+	}
+	return nil
+}
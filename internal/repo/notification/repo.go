@@ -0,0 +1,103 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/cantylv/authorization-service/services/postgres"
+)
+
+type Repo interface {
+	Notify(ctx context.Context, userIDs []string, event string, payload any) error
+	NotifyGroupOwners(ctx context.Context, groupID int, event string, payload any) error
+	List(ctx context.Context, userID string, filter *dto.NotificationFilter) ([]*ent.Notification, error)
+	MarkRead(ctx context.Context, userID string, id int64) error
+}
+
+var _ Repo = (*RepoLayer)(nil)
+
+type RepoLayer struct {
+	dbConn postgres.DB
+}
+
+// NewRepoLayer возвращает структуру уровня repository. Позволяет создавать и читать уведомления пользователей.
+// Уведомления создаются в транзакции изменения, о котором они сообщают.
+func NewRepoLayer(dbConn postgres.DB) *RepoLayer {
+	return &RepoLayer{
+		dbConn: dbConn,
+	}
+}
+
+var (
+	sqlRowNotify = `
+		INSERT INTO notification(user_id, event, payload)
+		SELECT DISTINCT unnest($1::uuid[]), $2, $3::jsonb
+	`
+	sqlRowNotifyGroupOwners = `
+		INSERT INTO notification(user_id, event, payload)
+		SELECT user_id, $2, $3::jsonb FROM group_owner WHERE group_id = $1
+	`
+	sqlRowListNotifications = `
+		SELECT id, event, payload, created_at, read_at
+		FROM notification
+		WHERE user_id = $1 AND (NOT $2 OR read_at IS NULL)
+		ORDER BY id DESC
+		LIMIT $3 OFFSET $4
+	`
+)
+
+// Notify создает уведомление о событии event для каждого из пользователей userIDs.
+func (r *RepoLayer) Notify(ctx context.Context, userIDs []string, event string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = r.dbConn.Exec(ctx, sqlRowNotify, userIDs, event, data)
+	return err
+}
+
+// NotifyGroupOwners создает уведомление о событии event для всех ответственных за группу.
+func (r *RepoLayer) NotifyGroupOwners(ctx context.Context, groupID int, event string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = r.dbConn.Exec(ctx, sqlRowNotifyGroupOwners, groupID, event, data)
+	return err
+}
+
+// List возвращает страницу уведомлений пользователя, начиная с новых.
+func (r *RepoLayer) List(ctx context.Context, userID string, filter *dto.NotificationFilter) ([]*ent.Notification, error) {
+	rows, err := r.dbConn.Query(ctx, sqlRowListNotifications, userID, filter.Unread, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	notifications := make([]*ent.Notification, 0)
+	for rows.Next() {
+		var n ent.Notification
+		err := rows.Scan(&n.ID, &n.Event, &n.Payload, &n.CreatedAt, &n.ReadAt)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, &n)
+	}
+	return notifications, rows.Err()
+}
+
+// MarkRead отмечает уведомление пользователя прочитанным. Возвращает ErrNoRowsAffected, если у пользователя
+// нет такого уведомления. Повторная отметка не меняет время прочтения.
+func (r *RepoLayer) MarkRead(ctx context.Context, userID string, id int64) error {
+	tag, err := r.dbConn.Exec(ctx,
+		`UPDATE notification SET read_at = COALESCE(read_at, now()) WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return me.ErrNoRowsAffected
+	}
+	return nil
+}
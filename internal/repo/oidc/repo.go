@@ -0,0 +1,74 @@
+package oidc
+
+import (
+	"context"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/services/postgres"
+)
+
+//go:generate mockgen -source ./repo.go -destination=./mocks/repo.go -package=mock_repo
+type Repo interface {
+	SaveState(ctx context.Context, state *ent.OpenIDState) error
+	PopState(ctx context.Context, state string) (*ent.OpenIDState, error)
+	GetUserID(ctx context.Context, issuer, subject string) (string, error)
+	LinkIdentity(ctx context.Context, userID, issuer, subject string) error
+}
+
+var _ Repo = (*RepoLayer)(nil)
+
+type RepoLayer struct {
+	dbConn postgres.DB
+}
+
+// NewRepoLayer возвращает структуру уровня repository. Позволяет работать с попытками входа через
+// OpenID провайдера и учетными записями провайдеров, привязанными к пользователям.
+func NewRepoLayer(dbConn postgres.DB) *RepoLayer {
+	return &RepoLayer{
+		dbConn: dbConn,
+	}
+}
+
+// SaveState сохраняет попытку входа. Заодно удаляет просроченные попытки, чтобы таблица не росла.
+func (r *RepoLayer) SaveState(ctx context.Context, state *ent.OpenIDState) error {
+	_, err := r.dbConn.Exec(ctx, `DELETE FROM oidc_state WHERE expires_at < now()`)
+	if err != nil {
+		return err
+	}
+	_, err = r.dbConn.Exec(ctx, `
+		INSERT INTO oidc_state (state, nonce, code_verifier, expires_at) VALUES ($1, $2, $3, $4)`,
+		state.State, state.Nonce, state.CodeVerifier, state.ExpiresAt)
+	return err
+}
+
+// PopState возвращает попытку входа и сразу удаляет ее, поэтому state нельзя использовать повторно.
+func (r *RepoLayer) PopState(ctx context.Context, state string) (*ent.OpenIDState, error) {
+	row := r.dbConn.QueryRow(ctx, `
+		DELETE FROM oidc_state WHERE state = $1
+		RETURNING state, nonce, code_verifier, expires_at`, state)
+	var s ent.OpenIDState
+	err := row.Scan(&s.State, &s.Nonce, &s.CodeVerifier, &s.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// GetUserID возвращает идентификатор пользователя, к которому привязана учетная запись провайдера.
+func (r *RepoLayer) GetUserID(ctx context.Context, issuer, subject string) (string, error) {
+	row := r.dbConn.QueryRow(ctx, `SELECT user_id FROM user_identity WHERE issuer = $1 AND subject = $2`, issuer, subject)
+	var userID string
+	err := row.Scan(&userID)
+	if err != nil {
+		return "", err
+	}
+	return userID, nil
+}
+
+// LinkIdentity привязывает учетную запись провайдера к пользователю.
+func (r *RepoLayer) LinkIdentity(ctx context.Context, userID, issuer, subject string) error {
+	_, err := r.dbConn.Exec(ctx, `
+		INSERT INTO user_identity (user_id, issuer, subject) VALUES ($1, $2, $3)
+		ON CONFLICT (issuer, subject) DO NOTHING`, userID, issuer, subject)
+	return err
+}
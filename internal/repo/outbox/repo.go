@@ -0,0 +1,116 @@
+package outbox
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/services/postgres"
+)
+
+type Repo interface {
+	Append(ctx context.Context, event *ent.ChangeEvent) error
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*ent.ChangeEvent, error)
+	MarkDelivered(ctx context.Context, id int64) error
+	Retry(ctx context.Context, id int64, reason string, nextAttemptAt time.Time) error
+	MarkFailed(ctx context.Context, id int64, reason string) error
+}
+
+var _ Repo = (*RepoLayer)(nil)
+
+type RepoLayer struct {
+	dbConn postgres.DB
+}
+
+// NewRepoLayer возвращает структуру уровня repository. Позволяет добавлять события изменений в outbox
+// и отмечать результат их доставки.
+func NewRepoLayer(dbConn postgres.DB) *RepoLayer {
+	return &RepoLayer{
+		dbConn: dbConn,
+	}
+}
+
+var (
+	sqlRowAppendChangeEvent = `
+		INSERT INTO outbox_event(event, target_type, target, actor_email, before_state, after_state, request_id)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5::jsonb, $6::jsonb, NULLIF($7, ''))
+	`
+	// события захватываются на время lease: next_attempt_at переносится вперед, поэтому другой диспетчер
+	// не возьмет их, пока не истечет lease. SKIP LOCKED позволяет нескольким экземплярам сервиса
+	// разбирать outbox параллельно
+	sqlRowClaimChangeEvents = `
+		UPDATE outbox_event o
+		SET attempts = o.attempts + 1, next_attempt_at = now() + $2::interval
+		WHERE o.id IN (
+			SELECT id FROM outbox_event
+			WHERE delivered_at IS NULL AND failed_at IS NULL AND next_attempt_at <= now()
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING o.id, o.event, o.target_type, o.target, COALESCE(o.actor_email, ''), o.before_state, o.after_state,
+			COALESCE(o.request_id, ''), o.created_at, o.attempts
+	`
+	sqlRowMarkChangeEventDelivered = `
+		UPDATE outbox_event SET delivered_at = now(), last_error = NULL WHERE id = $1
+	`
+	sqlRowRetryChangeEvent = `
+		UPDATE outbox_event SET next_attempt_at = $3, last_error = $2 WHERE id = $1
+	`
+	sqlRowMarkChangeEventFailed = `
+		UPDATE outbox_event SET failed_at = now(), last_error = $2 WHERE id = $1
+	`
+)
+
+// Append добавляет событие в outbox. Событие добавляется в транзакции изменения, которое оно описывает.
+func (r *RepoLayer) Append(ctx context.Context, event *ent.ChangeEvent) error {
+	_, err := r.dbConn.Exec(ctx, sqlRowAppendChangeEvent, event.Event, event.TargetType, event.Target, event.Actor,
+		[]byte(event.Before), []byte(event.After), event.RequestID)
+	return err
+}
+
+// Claim захватывает до limit событий, готовых к доставке, на время lease и увеличивает счетчик попыток.
+// События возвращаются в порядке добавления.
+func (r *RepoLayer) Claim(ctx context.Context, limit int, lease time.Duration) ([]*ent.ChangeEvent, error) {
+	rows, err := r.dbConn.Query(ctx, sqlRowClaimChangeEvents, limit, lease)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	events := make([]*ent.ChangeEvent, 0)
+	for rows.Next() {
+		var e ent.ChangeEvent
+		err := rows.Scan(&e.ID, &e.Event, &e.TargetType, &e.Target, &e.Actor, &e.Before, &e.After, &e.RequestID,
+			&e.CreatedAt, &e.Attempts)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// UPDATE ... RETURNING не сохраняет порядок подзапроса
+	slices.SortFunc(events, func(a, b *ent.ChangeEvent) int { return cmp.Compare(a.ID, b.ID) })
+	return events, nil
+}
+
+// MarkDelivered отмечает событие доставленным всем подписчикам.
+func (r *RepoLayer) MarkDelivered(ctx context.Context, id int64) error {
+	_, err := r.dbConn.Exec(ctx, sqlRowMarkChangeEventDelivered, id)
+	return err
+}
+
+// Retry откладывает следующую попытку доставки события до nextAttemptAt и сохраняет причину ошибки.
+func (r *RepoLayer) Retry(ctx context.Context, id int64, reason string, nextAttemptAt time.Time) error {
+	_, err := r.dbConn.Exec(ctx, sqlRowRetryChangeEvent, id, reason, nextAttemptAt)
+	return err
+}
+
+// MarkFailed прекращает попытки доставки события и сохраняет причину последней ошибки.
+func (r *RepoLayer) MarkFailed(ctx context.Context, id int64, reason string) error {
+	_, err := r.dbConn.Exec(ctx, sqlRowMarkChangeEventFailed, id, reason)
+	return err
+}
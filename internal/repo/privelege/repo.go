@@ -6,68 +6,424 @@ import (
 	"errors"
 
 	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
 	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
-	"github.com/jackc/pgx/v5"
+	"github.com/cantylv/authorization-service/services/postgres"
 )
 
 type Repo interface {
-	CreateGroupAgent(ctx context.Context, groupID, agentID int) (*ent.GroupPrivelege, error)
-	CreateUserAgent(ctx context.Context, userID string, agentID int) (*ent.UserPrivelege, error)
+	CreateGroupAgent(ctx context.Context, groupID, agentID int, period *dto.GrantPeriodData) (*ent.GroupPrivelege, error)
+	CreateUserAgent(ctx context.Context, userID string, agentID int, period *dto.GrantPeriodData) (*ent.UserPrivelege, error)
 	DeleteGroupAgent(ctx context.Context, groupID, agentID int) error
 	DeleteUserAgent(ctx context.Context, userID string, agentID int) error
 	GetGroupAgents(ctx context.Context, groupID int) ([]*ent.Agent, error)
 	GetUserAgents(ctx context.Context, userID string) ([]*ent.Agent, error)
+	GetEffectiveUserAgents(ctx context.Context, userID string) ([]*ent.Agent, error)
+	GetAgentAccessors(ctx context.Context, agentID int, page *dto.Pagination) ([]*ent.AgentAccessor, error)
+	ExportAgentAccessors(ctx context.Context, agentID int, fn func(a *ent.AgentAccessor) error) error
+	CreateGroupAgentAction(ctx context.Context, groupID, actionID int, period *dto.GrantPeriodData) (bool, error)
+	CreateUserAgentAction(ctx context.Context, userID string, actionID int, period *dto.GrantPeriodData) (bool, error)
+	DeleteGroupAgentAction(ctx context.Context, groupID, actionID int) (bool, error)
+	DeleteUserAgentAction(ctx context.Context, userID string, actionID int) (bool, error)
+	CreateGroupDeny(ctx context.Context, groupID, agentID int, actionID *int) (bool, error)
+	CreateUserDeny(ctx context.Context, userID string, agentID int, actionID *int) (bool, error)
+	DeleteGroupDeny(ctx context.Context, groupID, agentID int, actionID *int) (bool, error)
+	DeleteUserDeny(ctx context.Context, userID string, agentID int, actionID *int) (bool, error)
+	GroupGrantsAccess(ctx context.Context, groupID, agentID int, actionID *int) (bool, error)
+	CheckAccess(ctx context.Context, userEmail, agentName, action string) (*ent.AccessCheck, error)
+	CheckAccessBatch(ctx context.Context, checks []dto.AccessCheckItem) ([]*ent.AccessCheck, error)
+	GetAccessRules(ctx context.Context, userEmail, agentName, action string) ([]*ent.AccessRule, error)
 }
 
 var _ Repo = (*RepoLayer)(nil)
 
 type RepoLayer struct {
-	dbConn *pgx.Conn
+	dbConn postgres.DB
 }
 
-func NewRepoLayer(dbConn *pgx.Conn) *RepoLayer {
+func NewRepoLayer(dbConn postgres.DB) *RepoLayer {
 	return &RepoLayer{
 		dbConn: dbConn,
 	}
 }
 
 var (
+	// истекшая, но еще не удаленная очисткой привелегия заменяется новой
 	sqlRowCreateGroupPrivelege = `
-		INSERT INTO privelege_group(group_id, agent_id) 
-		VALUES ($1, $2) RETURNING id, group_id, agent_id
+		INSERT INTO privelege_group(group_id, agent_id, valid_from, valid_until)
+		VALUES ($1, $2, COALESCE($3, now()), $4)
+		ON CONFLICT (group_id, agent_id) DO UPDATE
+		SET valid_from = EXCLUDED.valid_from, valid_until = EXCLUDED.valid_until
+		WHERE privelege_group.valid_until <= now()
+		RETURNING id, group_id, agent_id, valid_from, valid_until
 	`
 	sqlRowCreateUserPrivelege = `
-		INSERT INTO privelege_user(user_id, agent_id) 
-		VALUES ($1, $2) RETURNING id, user_id, agent_id
+		INSERT INTO privelege_user(user_id, agent_id, valid_from, valid_until)
+		VALUES ($1, $2, COALESCE($3, now()), $4)
+		ON CONFLICT (user_id, agent_id) DO UPDATE
+		SET valid_from = EXCLUDED.valid_from, valid_until = EXCLUDED.valid_until
+		WHERE privelege_user.valid_until <= now()
+		RETURNING id, user_id, agent_id, valid_from, valid_until
 	`
-	sqlRowGetGroupAgents = `
-		SELECT a.id, a.name 
-		FROM agent a
-		JOIN privelege_group p ON a.id = p.agent_id
-		WHERE p.group_id = $1
+	sqlRowCreateGroupActionPrivelege = `
+		INSERT INTO privelege_group_action(group_id, action_id, valid_from, valid_until)
+		VALUES ($1, $2, COALESCE($3, now()), $4)
+		ON CONFLICT (group_id, action_id) DO UPDATE
+		SET valid_from = EXCLUDED.valid_from, valid_until = EXCLUDED.valid_until
+		WHERE privelege_group_action.valid_until <= now()
+	`
+	sqlRowCreateUserActionPrivelege = `
+		INSERT INTO privelege_user_action(user_id, action_id, valid_from, valid_until)
+		VALUES ($1, $2, COALESCE($3, now()), $4)
+		ON CONFLICT (user_id, action_id) DO UPDATE
+		SET valid_from = EXCLUDED.valid_from, valid_until = EXCLUDED.valid_until
+		WHERE privelege_user_action.valid_until <= now()
 	`
+	// по набору выданных прав grants(agent_id, action_id) строит список агентов с доступными действиями,
+	// action_id = NULL означает доступ ко всем действиям агента
+	sqlRowAgentsByGrants = `
+		SELECT a.id, a.name,
+			COALESCE(array_agg(DISTINCT aa.name ORDER BY aa.name) FILTER (WHERE aa.name IS NOT NULL), '{}')
+		FROM grants g
+		JOIN agent a ON a.id = g.agent_id
+		LEFT JOIN agent_action aa ON aa.agent_id = a.id AND (g.action_id IS NULL OR aa.id = g.action_id)
+		GROUP BY a.id
+		ORDER BY a.name
+	`
+	sqlRowGetGroupAgents = `
+		WITH grants AS (
+			SELECT pg.agent_id, NULL::int AS action_id
+			FROM privelege_group pg
+			WHERE pg.group_id = $1 AND ` + postgres.ValidNow("pg") + `
+			UNION
+			SELECT aa.agent_id, aa.id
+			FROM privelege_group_action pga
+			JOIN agent_action aa ON aa.id = pga.action_id
+			WHERE pga.group_id = $1 AND ` + postgres.ValidNow("pga") + `
+		)
+	` + sqlRowAgentsByGrants
 	sqlRowGetUserAgents = `
-		SELECT a.id, a.name 
-		FROM agent a
-		JOIN privelege_user p ON a.id = p.agent_id
-		WHERE p.user_id = $1
+		WITH grants AS (
+			SELECT pu.agent_id, NULL::int AS action_id
+			FROM privelege_user pu
+			WHERE pu.user_id = $1 AND ` + postgres.ValidNow("pu") + `
+			UNION
+			SELECT aa.agent_id, aa.id
+			FROM privelege_user_action pua
+			JOIN agent_action aa ON aa.id = pua.action_id
+			WHERE pua.user_id = $1 AND ` + postgres.ValidNow("pua") + `
+		)
+	` + sqlRowAgentsByGrants
+	// условия правил доступа для строки x(user_id, agent_id, action_id). Запрет или разрешение на агента целиком
+	// распространяется на все его действия, x.action_id = NULL означает проверку агента целиком. Учитываются только
+	// действующие в момент запроса привелегии и участия в группах. Правила групп берутся из user_groups
+	// пользователя x.user_id
+	sqlExistsUserDeny = `
+		EXISTS (
+			SELECT 1 FROM privelege_user_deny d
+			WHERE d.user_id = x.user_id AND d.agent_id = x.agent_id
+				AND (d.action_id IS NULL OR d.action_id = x.action_id)
+		)
+	`
+	sqlExistsUserAllow = `
+		EXISTS (
+			SELECT 1 FROM privelege_user pu
+			WHERE pu.user_id = x.user_id AND pu.agent_id = x.agent_id AND ` + postgres.ValidNow("pu") + `
+			UNION ALL
+			SELECT 1 FROM privelege_user_action pua
+			WHERE pua.user_id = x.user_id AND pua.action_id = x.action_id AND ` + postgres.ValidNow("pua") + `
+		)
+	`
+	sqlExistsGroupDeny = `
+		EXISTS (
+			SELECT 1
+			FROM user_groups ug
+			JOIN privelege_group_deny d ON d.group_id = ug.group_id
+			WHERE d.agent_id = x.agent_id AND (d.action_id IS NULL OR d.action_id = x.action_id)
+		)
+	`
+	sqlExistsGroupAllow = `
+		EXISTS (
+			SELECT 1
+			FROM user_groups ug
+			JOIN privelege_group pg ON pg.group_id = ug.group_id
+			WHERE pg.agent_id = x.agent_id AND ` + postgres.ValidNow("pg") + `
+			UNION ALL
+			SELECT 1
+			FROM user_groups ug
+			JOIN privelege_group_action pga ON pga.group_id = ug.group_id
+			WHERE pga.action_id = x.action_id AND ` + postgres.ValidNow("pga") + `
+		)
+	`
+	// доступ группы $1 к агенту $2 или его действию $3 по правилам ее самой и всех ее родительских групп, то есть
+	// доступ, который получит через группу ее новый участник без собственных правил. user_groups здесь цепочка
+	// групп от $1 до корня иерархии
+	sqlRowGroupGrantsAccess = `
+		WITH RECURSIVE user_groups(group_id) AS (
+			SELECT $1::int
+			UNION
+			SELECT g.parent_id
+			FROM user_groups ug
+			JOIN "group" g ON g.id = ug.group_id
+			WHERE g.parent_id IS NOT NULL
+		),
+		x AS (SELECT $2::int AS agent_id, $3::int AS action_id)
+		SELECT NOT ` + sqlExistsGroupDeny + ` AND ` + sqlExistsGroupAllow + `
+		FROM x
+	`
+	// ближайший момент в будущем, когда начнет или перестанет действовать привелегия пользователя или его групп
+	// на агента x.agent_id либо участие пользователя в группе, то есть когда решение о доступе может измениться
+	// без изменения строк. NULL, если таких моментов нет
+	sqlNextAccessChange = `
+		LEAST(
+			(SELECT min(v.t) FROM privelege_user pu, LATERAL (VALUES (pu.valid_from), (pu.valid_until)) v(t)
+				WHERE pu.user_id = x.user_id AND pu.agent_id = x.agent_id AND v.t > now()),
+			(SELECT min(v.t) FROM privelege_user_action pua, LATERAL (VALUES (pua.valid_from), (pua.valid_until)) v(t)
+				WHERE pua.user_id = x.user_id AND pua.action_id = x.action_id AND v.t > now()),
+			(SELECT min(v.t) FROM participation p, LATERAL (VALUES (p.valid_from), (p.valid_until)) v(t)
+				WHERE p.user_id = x.user_id AND v.t > now()),
+			(SELECT min(v.t) FROM user_groups ug JOIN privelege_group pg ON pg.group_id = ug.group_id,
+				LATERAL (VALUES (pg.valid_from), (pg.valid_until)) v(t)
+				WHERE pg.agent_id = x.agent_id AND v.t > now()),
+			(SELECT min(v.t) FROM user_groups ug JOIN privelege_group_action pga ON pga.group_id = ug.group_id,
+				LATERAL (VALUES (pga.valid_from), (pga.valid_until)) v(t)
+				WHERE pga.action_id = x.action_id AND v.t > now())
+		)
+	`
+	// индивидуальные привелегии пользователя и привелегии всех его групп, включая родительские, с учетом запретов.
+	// Для каждого агента и каждого его действия правила применяются в порядке: запрет пользователя >
+	// разрешение пользователя > запрет группы > разрешение группы
+	sqlRowGetEffectiveUserAgents = `
+		WITH RECURSIVE u AS (SELECT $1::uuid AS id),
+		` + postgres.UserGroups + `,
+		x AS (
+			SELECT $1::uuid AS user_id, a.id AS agent_id, NULL::int AS action_id, NULL::text AS action_name
+			FROM agent a
+			UNION ALL
+			SELECT $1::uuid, aa.agent_id, aa.id, aa.name
+			FROM agent_action aa
+		),
+		decision AS (
+			SELECT x.agent_id, x.action_name,
+				CASE
+					WHEN ` + sqlExistsUserDeny + ` THEN false
+					WHEN ` + sqlExistsUserAllow + ` THEN true
+					WHEN ` + sqlExistsGroupDeny + ` THEN false
+					ELSE ` + sqlExistsGroupAllow + `
+				END AS allowed
+			FROM x
+		)
+		SELECT a.id, a.name,
+			COALESCE(array_agg(d.action_name ORDER BY d.action_name) FILTER (WHERE d.action_name IS NOT NULL), '{}')
+		FROM decision d
+		JOIN agent a ON a.id = d.agent_id
+		WHERE d.allowed
+		GROUP BY a.id
+		ORDER BY a.name
+	`
+	// пользователи с действующим доступом к агенту $1 целиком и к каждому его действию. Кандидаты - пользователи
+	// с индивидуальной привелегией на агент и участники групп с привелегией на агент и их дочерних групп, решение
+	// для каждого кандидата принимается по тем же правилам, что и при проверке доступа. Для доступа через группы
+	// granting_groups - группы пользователя, включая родительские, которые дают разрешение
+	sqlAgentAccessors = `
+		WITH RECURSIVE scopes AS (
+			SELECT NULL::int AS action_id, NULL::text AS action_name
+			UNION ALL
+			SELECT aa.id, aa.name FROM agent_action aa WHERE aa.agent_id = $1
+		), granting(group_id) AS (
+			SELECT pg.group_id FROM privelege_group pg WHERE pg.agent_id = $1
+			UNION
+			SELECT pga.group_id
+			FROM privelege_group_action pga
+			JOIN agent_action aa ON aa.id = pga.action_id
+			WHERE aa.agent_id = $1
+			UNION
+			SELECT g.id FROM granting gr JOIN "group" g ON g.parent_id = gr.group_id
+		), candidates AS (
+			SELECT pu.user_id FROM privelege_user pu WHERE pu.agent_id = $1
+			UNION
+			SELECT pua.user_id
+			FROM privelege_user_action pua
+			JOIN agent_action aa ON aa.id = pua.action_id
+			WHERE aa.agent_id = $1
+			UNION
+			SELECT p.user_id FROM participation p JOIN granting gr ON gr.group_id = p.group_id
+		), t AS (
+			SELECT c.user_id, $1::int AS agent_id, s.action_id, s.action_name
+			FROM candidates c CROSS JOIN scopes s
+		)
+		SELECT usr.email, COALESCE(t.action_name, ''), d.source,
+			CASE WHEN d.source = 'group' THEN d.granting_groups ELSE '{}' END
+		FROM t
+		JOIN "user" usr ON usr.id = t.user_id
+		CROSS JOIN LATERAL (
+			WITH RECURSIVE u AS (SELECT t.user_id AS id),
+				 ` + postgres.UserGroups + `,
+				 x AS (SELECT t.user_id, t.agent_id, t.action_id)
+			SELECT
+				CASE
+					WHEN ` + sqlExistsUserDeny + ` THEN NULL
+					WHEN ` + sqlExistsUserAllow + ` THEN 'user'
+					WHEN ` + sqlExistsGroupDeny + ` THEN NULL
+					WHEN ` + sqlExistsGroupAllow + ` THEN 'group'
+				END AS source,
+				ARRAY(
+					SELECT g.name
+					FROM "group" g
+					WHERE g.id IN (
+						SELECT ug.group_id
+						FROM user_groups ug
+						JOIN privelege_group pg ON pg.group_id = ug.group_id
+						WHERE pg.agent_id = x.agent_id AND ` + postgres.ValidNow("pg") + `
+						UNION
+						SELECT ug.group_id
+						FROM user_groups ug
+						JOIN privelege_group_action pga ON pga.group_id = ug.group_id
+						WHERE pga.action_id = x.action_id AND ` + postgres.ValidNow("pga") + `
+					)
+					ORDER BY g.name
+				) AS granting_groups
+			FROM x
+		) d
+		WHERE d.source IS NOT NULL
+		ORDER BY usr.email, t.action_name NULLS FIRST
+	`
+	sqlRowGetAgentAccessors = sqlAgentAccessors + `
+		LIMIT $2 OFFSET $3
+	`
+	// пустое действие означает проверку доступа ко всему агенту
+	sqlRowCheckAccess = `
+		WITH RECURSIVE u AS (SELECT id FROM "user" WHERE email = $1),
+			 ` + postgres.UserGroups + `,
+			 a AS (SELECT id FROM agent WHERE name = $2),
+			 act AS (SELECT id FROM agent_action WHERE agent_id = (SELECT id FROM a) AND name = $3),
+			 x AS (SELECT (SELECT id FROM u) AS user_id, (SELECT id FROM a) AS agent_id, (SELECT id FROM act) AS action_id)
+		SELECT
+			EXISTS (SELECT 1 FROM u),
+			EXISTS (SELECT 1 FROM a),
+			EXISTS (SELECT 1 FROM act),
+			` + sqlExistsUserDeny + `,
+			` + sqlExistsUserAllow + `,
+			` + sqlExistsGroupDeny + `,
+			` + sqlExistsGroupAllow + `,
+			COALESCE(x.user_id::text, ''),
+			COALESCE(x.agent_id, 0),
+			` + sqlNextAccessChange + `
+		FROM x
+	`
+	// та же проверка для набора (email, агент, действие): $1, $2 и $3 - массивы одинаковой длины. Группы каждого
+	// пользователя обходятся в LATERAL подзапросе, поэтому условия правил совпадают с одиночной проверкой
+	sqlRowCheckAccessBatch = `
+		WITH q AS (
+			SELECT * FROM unnest($1::text[], $2::text[], $3::text[]) WITH ORDINALITY AS q(email, agent_name, action_name, idx)
+		), t AS (
+			SELECT q.idx, usr.id AS user_id, a.id AS agent_id, act.id AS action_id
+			FROM q
+			LEFT JOIN "user" usr ON usr.email = q.email
+			LEFT JOIN agent a ON a.name = q.agent_name
+			LEFT JOIN agent_action act ON act.agent_id = a.id AND act.name = q.action_name
+		)
+		SELECT
+			t.user_id IS NOT NULL,
+			t.agent_id IS NOT NULL,
+			t.action_id IS NOT NULL,
+			c.user_deny,
+			c.user_allow,
+			c.group_deny,
+			c.group_allow,
+			COALESCE(t.user_id::text, ''),
+			COALESCE(t.agent_id, 0),
+			c.next_change
+		FROM t
+		CROSS JOIN LATERAL (
+			WITH RECURSIVE u AS (SELECT t.user_id AS id),
+				 ` + postgres.UserGroups + `,
+				 x AS (SELECT t.user_id, t.agent_id, t.action_id)
+			SELECT
+				` + sqlExistsUserDeny + ` AS user_deny,
+				` + sqlExistsUserAllow + ` AS user_allow,
+				` + sqlExistsGroupDeny + ` AS group_deny,
+				` + sqlExistsGroupAllow + ` AS group_allow,
+				` + sqlNextAccessChange + ` AS next_change
+			FROM x
+		) c
+		ORDER BY t.idx
+	`
+	// все строки привелегий и запретов, подходящие под проверку доступа, в порядке приоритета правил. Для правил
+	// родительской группы via_group указывает группу, в которой пользователь состоит
+	sqlRowGetAccessRules = `
+		WITH RECURSIVE u AS (SELECT id FROM "user" WHERE email = $1),
+			 ` + postgres.UserGroups + `,
+			 a AS (SELECT id FROM agent WHERE name = $2),
+			 act AS (SELECT id FROM agent_action WHERE agent_id = (SELECT id FROM a) AND name = $3),
+			 x AS (SELECT (SELECT id FROM u) AS user_id, (SELECT id FROM a) AS agent_id, (SELECT id FROM act) AS action_id),
+			 rules AS (
+				SELECT 1 AS priority, 'deny' AS effect, 'user' AS source, NULL::text AS group_name, NULL::text AS via_group,
+					aa.name AS action_name, 'privelege_user_deny' AS table_name, d.id AS row_id, NULL::int AS participation_id
+				FROM x
+				JOIN privelege_user_deny d ON d.user_id = x.user_id AND d.agent_id = x.agent_id
+					AND (d.action_id IS NULL OR d.action_id = x.action_id)
+				LEFT JOIN agent_action aa ON aa.id = d.action_id
+				UNION ALL
+				SELECT 2, 'allow', 'user', NULL, NULL, NULL, 'privelege_user', pu.id, NULL
+				FROM x
+				JOIN privelege_user pu ON pu.user_id = x.user_id AND pu.agent_id = x.agent_id AND ` + postgres.ValidNow("pu") + `
+				UNION ALL
+				SELECT 2, 'allow', 'user', NULL, NULL, aa.name, 'privelege_user_action', pua.id, NULL
+				FROM x
+				JOIN privelege_user_action pua ON pua.user_id = x.user_id AND pua.action_id = x.action_id
+					AND ` + postgres.ValidNow("pua") + `
+				JOIN agent_action aa ON aa.id = pua.action_id
+				UNION ALL
+				SELECT 3, 'deny', 'group', g.name, NULLIF(vg.name, g.name), aa.name, 'privelege_group_deny', d.id, p.id
+				FROM x
+				CROSS JOIN user_groups ug
+				JOIN "group" g ON g.id = ug.group_id
+				JOIN participation p ON p.id = ug.participation_id
+				JOIN "group" vg ON vg.id = p.group_id
+				JOIN privelege_group_deny d ON d.group_id = ug.group_id AND d.agent_id = x.agent_id
+					AND (d.action_id IS NULL OR d.action_id = x.action_id)
+				LEFT JOIN agent_action aa ON aa.id = d.action_id
+				UNION ALL
+				SELECT 4, 'allow', 'group', g.name, NULLIF(vg.name, g.name), NULL, 'privelege_group', pg.id, p.id
+				FROM x
+				CROSS JOIN user_groups ug
+				JOIN "group" g ON g.id = ug.group_id
+				JOIN participation p ON p.id = ug.participation_id
+				JOIN "group" vg ON vg.id = p.group_id
+				JOIN privelege_group pg ON pg.group_id = ug.group_id AND pg.agent_id = x.agent_id AND ` + postgres.ValidNow("pg") + `
+				UNION ALL
+				SELECT 4, 'allow', 'group', g.name, NULLIF(vg.name, g.name), aa.name, 'privelege_group_action', pga.id, p.id
+				FROM x
+				CROSS JOIN user_groups ug
+				JOIN "group" g ON g.id = ug.group_id
+				JOIN participation p ON p.id = ug.participation_id
+				JOIN "group" vg ON vg.id = p.group_id
+				JOIN privelege_group_action pga ON pga.group_id = ug.group_id AND pga.action_id = x.action_id
+					AND ` + postgres.ValidNow("pga") + `
+				JOIN agent_action aa ON aa.id = pga.action_id
+			 )
+		SELECT effect, source, COALESCE(group_name, ''), COALESCE(via_group, ''), COALESCE(action_name, ''), table_name,
+			row_id, COALESCE(participation_id, 0)
+		FROM rules
+		ORDER BY priority, group_name NULLS FIRST, table_name, row_id
 	`
 )
 
-func (r *RepoLayer) CreateGroupAgent(ctx context.Context, groupID, agentID int) (*ent.GroupPrivelege, error) {
-	row := r.dbConn.QueryRow(ctx, sqlRowCreateGroupPrivelege, groupID, agentID)
+func (r *RepoLayer) CreateGroupAgent(ctx context.Context, groupID, agentID int, period *dto.GrantPeriodData) (*ent.GroupPrivelege, error) {
+	row := r.dbConn.QueryRow(ctx, sqlRowCreateGroupPrivelege, groupID, agentID, period.ValidFrom, period.ValidUntil)
 	var rl ent.GroupPrivelege
-	err := row.Scan(&rl.ID, &rl.GroupID, &rl.AgentID)
+	err := row.Scan(&rl.ID, &rl.GroupID, &rl.AgentID, &rl.ValidFrom, &rl.ValidUntil)
 	if err != nil {
 		return nil, err
 	}
 	return &rl, nil
 }
 
-func (r *RepoLayer) CreateUserAgent(ctx context.Context, userID string, agentID int) (*ent.UserPrivelege, error) {
-	row := r.dbConn.QueryRow(ctx, sqlRowCreateUserPrivelege, userID, agentID)
+func (r *RepoLayer) CreateUserAgent(ctx context.Context, userID string, agentID int, period *dto.GrantPeriodData) (*ent.UserPrivelege, error) {
+	row := r.dbConn.QueryRow(ctx, sqlRowCreateUserPrivelege, userID, agentID, period.ValidFrom, period.ValidUntil)
 	var rl ent.UserPrivelege
-	err := row.Scan(&rl.ID, &rl.UserID, &rl.AgentID)
+	err := row.Scan(&rl.ID, &rl.UserID, &rl.AgentID, &rl.ValidFrom, &rl.ValidUntil)
 	if err != nil {
 		return nil, err
 	}
@@ -104,10 +460,11 @@ func (r *RepoLayer) GetGroupAgents(ctx context.Context, groupID int) ([]*ent.Age
 		}
 		return nil, err
 	}
+	defer rows.Close()
 	var agents []*ent.Agent
 	for rows.Next() {
 		var a ent.Agent
-		err = rows.Scan(&a.ID, &a.Name)
+		err = rows.Scan(&a.ID, &a.Name, &a.Actions)
 		if err != nil {
 			return nil, err
 		}
@@ -124,10 +481,11 @@ func (r *RepoLayer) GetUserAgents(ctx context.Context, userID string) ([]*ent.Ag
 		}
 		return nil, err
 	}
+	defer rows.Close()
 	var agents []*ent.Agent
 	for rows.Next() {
 		var a ent.Agent
-		err = rows.Scan(&a.ID, &a.Name)
+		err = rows.Scan(&a.ID, &a.Name, &a.Actions)
 		if err != nil {
 			return nil, err
 		}
@@ -135,3 +493,220 @@ func (r *RepoLayer) GetUserAgents(ctx context.Context, userID string) ([]*ent.Ag
 	}
 	return agents, nil
 }
+
+// GetEffectiveUserAgents возвращает всех агентов, доступных пользователю напрямую или через группы, одним запросом.
+func (r *RepoLayer) GetEffectiveUserAgents(ctx context.Context, userID string) ([]*ent.Agent, error) {
+	rows, err := r.dbConn.Query(ctx, sqlRowGetEffectiveUserAgents, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var agents []*ent.Agent
+	for rows.Next() {
+		var a ent.Agent
+		err = rows.Scan(&a.ID, &a.Name, &a.Actions)
+		if err != nil {
+			return nil, err
+		}
+		agents = append(agents, &a)
+	}
+	return agents, rows.Err()
+}
+
+// CreateGroupAgentAction выдает группе доступ к действию агента на срок period. Возвращает false, если неистекший
+// доступ уже был выдан.
+func (r *RepoLayer) CreateGroupAgentAction(ctx context.Context, groupID, actionID int, period *dto.GrantPeriodData) (bool, error) {
+	tag, err := r.dbConn.Exec(ctx, sqlRowCreateGroupActionPrivelege, groupID, actionID, period.ValidFrom, period.ValidUntil)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() != 0, nil
+}
+
+// CreateUserAgentAction выдает пользователю доступ к действию агента на срок period. Возвращает false, если неистекший
+// доступ уже был выдан.
+func (r *RepoLayer) CreateUserAgentAction(ctx context.Context, userID string, actionID int, period *dto.GrantPeriodData) (bool, error) {
+	tag, err := r.dbConn.Exec(ctx, sqlRowCreateUserActionPrivelege, userID, actionID, period.ValidFrom, period.ValidUntil)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() != 0, nil
+}
+
+// DeleteGroupAgentAction отзывает у группы доступ к действию агента. Возвращает false, если доступа не было.
+func (r *RepoLayer) DeleteGroupAgentAction(ctx context.Context, groupID, actionID int) (bool, error) {
+	tag, err := r.dbConn.Exec(ctx, `DELETE FROM privelege_group_action WHERE group_id=$1 AND action_id=$2`, groupID, actionID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() != 0, nil
+}
+
+// DeleteUserAgentAction отзывает у пользователя доступ к действию агента. Возвращает false, если доступа не было.
+func (r *RepoLayer) DeleteUserAgentAction(ctx context.Context, userID string, actionID int) (bool, error) {
+	tag, err := r.dbConn.Exec(ctx, `DELETE FROM privelege_user_action WHERE user_id=$1 AND action_id=$2`, userID, actionID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() != 0, nil
+}
+
+// CreateGroupDeny запрещает группе доступ к агенту или к его действию, если actionID не nil.
+// Возвращает false, если запрет уже был.
+func (r *RepoLayer) CreateGroupDeny(ctx context.Context, groupID, agentID int, actionID *int) (bool, error) {
+	tag, err := r.dbConn.Exec(ctx,
+		`INSERT INTO privelege_group_deny(group_id, agent_id, action_id) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`,
+		groupID, agentID, actionID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() != 0, nil
+}
+
+// CreateUserDeny запрещает пользователю доступ к агенту или к его действию, если actionID не nil.
+// Возвращает false, если запрет уже был.
+func (r *RepoLayer) CreateUserDeny(ctx context.Context, userID string, agentID int, actionID *int) (bool, error) {
+	tag, err := r.dbConn.Exec(ctx,
+		`INSERT INTO privelege_user_deny(user_id, agent_id, action_id) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`,
+		userID, agentID, actionID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() != 0, nil
+}
+
+// DeleteGroupDeny снимает запрет с группы. Возвращает false, если запрета не было.
+func (r *RepoLayer) DeleteGroupDeny(ctx context.Context, groupID, agentID int, actionID *int) (bool, error) {
+	tag, err := r.dbConn.Exec(ctx,
+		`DELETE FROM privelege_group_deny WHERE group_id=$1 AND agent_id=$2 AND action_id IS NOT DISTINCT FROM $3`,
+		groupID, agentID, actionID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() != 0, nil
+}
+
+// DeleteUserDeny снимает запрет с пользователя. Возвращает false, если запрета не было.
+func (r *RepoLayer) DeleteUserDeny(ctx context.Context, userID string, agentID int, actionID *int) (bool, error) {
+	tag, err := r.dbConn.Exec(ctx,
+		`DELETE FROM privelege_user_deny WHERE user_id=$1 AND agent_id=$2 AND action_id IS NOT DISTINCT FROM $3`,
+		userID, agentID, actionID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() != 0, nil
+}
+
+// GroupGrantsAccess проверяет, что группа с учетом родительских групп разрешает и не запрещает доступ к агенту
+// или к его действию, если actionID не nil.
+func (r *RepoLayer) GroupGrantsAccess(ctx context.Context, groupID, agentID int, actionID *int) (bool, error) {
+	row := r.dbConn.QueryRow(ctx, sqlRowGroupGrantsAccess, groupID, agentID, actionID)
+	var granted bool
+	err := row.Scan(&granted)
+	if err != nil {
+		return false, err
+	}
+	return granted, nil
+}
+
+// CheckAccess одним запросом проверяет существование пользователя, агента и его действия, а также наличие
+// подходящих запретов и разрешений пользователя и его групп. Пустое действие означает весь агент. Также
+// возвращает момент, когда решение может измениться по сроку действия привелегий или участий.
+func (r *RepoLayer) CheckAccess(ctx context.Context, userEmail, agentName, action string) (*ent.AccessCheck, error) {
+	row := r.dbConn.QueryRow(ctx, sqlRowCheckAccess, userEmail, agentName, action)
+	var c ent.AccessCheck
+	err := row.Scan(&c.UserExists, &c.AgentExists, &c.ActionExists,
+		&c.UserDeny, &c.UserAllow, &c.GroupDeny, &c.GroupAllow, &c.UserID, &c.AgentID, &c.NextChange)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// CheckAccessBatch выполняет CheckAccess для каждой проверки одним запросом. Результаты возвращаются в порядке
+// проверок.
+func (r *RepoLayer) CheckAccessBatch(ctx context.Context, checks []dto.AccessCheckItem) ([]*ent.AccessCheck, error) {
+	emails := make([]string, 0, len(checks))
+	agents := make([]string, 0, len(checks))
+	actions := make([]string, 0, len(checks))
+	for _, check := range checks {
+		emails = append(emails, check.Email)
+		agents = append(agents, check.Agent)
+		actions = append(actions, check.Action)
+	}
+	rows, err := r.dbConn.Query(ctx, sqlRowCheckAccessBatch, emails, agents, actions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result := make([]*ent.AccessCheck, 0, len(checks))
+	for rows.Next() {
+		var c ent.AccessCheck
+		err := rows.Scan(&c.UserExists, &c.AgentExists, &c.ActionExists,
+			&c.UserDeny, &c.UserAllow, &c.GroupDeny, &c.GroupAllow, &c.UserID, &c.AgentID, &c.NextChange)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &c)
+	}
+	return result, rows.Err()
+}
+
+// GetAgentAccessors возвращает страницу пользователей с действующим доступом к агенту или к его действиям,
+// упорядоченную по email.
+func (r *RepoLayer) GetAgentAccessors(ctx context.Context, agentID int, page *dto.Pagination) ([]*ent.AgentAccessor, error) {
+	accessors := make([]*ent.AgentAccessor, 0)
+	err := r.queryAgentAccessors(ctx, sqlRowGetAgentAccessors, func(a *ent.AgentAccessor) error {
+		accessors = append(accessors, a)
+		return nil
+	}, agentID, page.Limit, page.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return accessors, nil
+}
+
+// ExportAgentAccessors передает в fn всех пользователей с действующим доступом к агенту или к его действиям.
+// Записи читаются из курсора по одной и не собираются в память.
+func (r *RepoLayer) ExportAgentAccessors(ctx context.Context, agentID int, fn func(a *ent.AgentAccessor) error) error {
+	return r.queryAgentAccessors(ctx, sqlAgentAccessors, fn, agentID)
+}
+
+func (r *RepoLayer) queryAgentAccessors(ctx context.Context, sql string, fn func(a *ent.AgentAccessor) error, args ...any) error {
+	rows, err := r.dbConn.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a ent.AgentAccessor
+		if err := rows.Scan(&a.Email, &a.Action, &a.Source, &a.Groups); err != nil {
+			return err
+		}
+		if err := fn(&a); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// GetAccessRules возвращает строки привелегий и запретов пользователя и его групп, подходящие под проверку
+// доступа к агенту или к его действию, в порядке приоритета правил.
+func (r *RepoLayer) GetAccessRules(ctx context.Context, userEmail, agentName, action string) ([]*ent.AccessRule, error) {
+	rows, err := r.dbConn.Query(ctx, sqlRowGetAccessRules, userEmail, agentName, action)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	rules := make([]*ent.AccessRule, 0)
+	for rows.Next() {
+		var rl ent.AccessRule
+		err = rows.Scan(&rl.Effect, &rl.Source, &rl.Group, &rl.ViaGroup, &rl.Action, &rl.Table, &rl.RowID, &rl.ParticipationID)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, &rl)
+	}
+	return rules, rows.Err()
+}
@@ -0,0 +1,243 @@
+package role
+
+import (
+	"context"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/cantylv/authorization-service/services/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type Repo interface {
+	GetPermissions(ctx context.Context) ([]*ent.Permission, error)
+	CountPermissions(ctx context.Context, names []string) (int, error)
+	GetRoles(ctx context.Context) ([]*ent.Role, error)
+	GetRole(ctx context.Context, name string) (*ent.Role, error)
+	CreateRole(ctx context.Context, name, description string) (*ent.Role, error)
+	DeleteRole(ctx context.Context, id int) error
+	SetRolePermissions(ctx context.Context, roleID int, permissions []string) error
+	AssignToUser(ctx context.Context, roleID int, userID string) (bool, error)
+	RevokeFromUser(ctx context.Context, roleID int, userID string) (bool, error)
+	AssignToGroup(ctx context.Context, roleID, groupID int) (bool, error)
+	RevokeFromGroup(ctx context.Context, roleID, groupID int) (bool, error)
+	GetUserPermissions(ctx context.Context, userID string) ([]string, error)
+	HasPermission(ctx context.Context, userID, permission string) (bool, error)
+}
+
+var _ Repo = (*RepoLayer)(nil)
+
+type RepoLayer struct {
+	dbConn postgres.DB
+}
+
+// NewRepoLayer возвращает структуру уровня repository. Позволяет работать с ролями и правами пользователей.
+func NewRepoLayer(dbConn postgres.DB) *RepoLayer {
+	return &RepoLayer{
+		dbConn: dbConn,
+	}
+}
+
+var (
+	sqlRowGetRoles = `
+		SELECT r.id, r.name, r.description, r.is_system,
+			COALESCE(array_agg(rp.permission ORDER BY rp.permission) FILTER (WHERE rp.permission IS NOT NULL), '{}')
+		FROM role r
+		LEFT JOIN role_permission rp ON rp.role_id = r.id
+		GROUP BY r.id
+		ORDER BY r.name
+	`
+	sqlRowGetRole = `
+		SELECT r.id, r.name, r.description, r.is_system,
+			COALESCE(array_agg(rp.permission ORDER BY rp.permission) FILTER (WHERE rp.permission IS NOT NULL), '{}')
+		FROM role r
+		LEFT JOIN role_permission rp ON rp.role_id = r.id
+		WHERE r.name = $1
+		GROUP BY r.id
+	`
+	// права пользователя складываются из ролей, назначенных ему напрямую, и ролей всех групп, участие
+	// в которых действует в момент запроса, вместе с их родительскими группами
+	sqlRowUserRoles = `
+		WITH RECURSIVE u AS (SELECT $1::uuid AS id),
+			 ` + postgres.UserGroups + `
+		SELECT ur.role_id FROM user_role ur WHERE ur.user_id = $1
+		UNION
+		SELECT gr.role_id
+		FROM user_groups ug
+		JOIN group_role gr ON gr.group_id = ug.group_id
+	`
+	sqlRowGetUserPermissions = `
+		SELECT DISTINCT rp.permission
+		FROM role_permission rp
+		WHERE rp.role_id IN (` + sqlRowUserRoles + `)
+		ORDER BY rp.permission
+	`
+	sqlRowHasPermission = `
+		SELECT EXISTS (
+			SELECT 1 FROM role_permission rp
+			WHERE rp.permission = $2 AND rp.role_id IN (` + sqlRowUserRoles + `)
+		)
+	`
+)
+
+func scanRole(row pgx.Row) (*ent.Role, error) {
+	var r ent.Role
+	err := row.Scan(&r.ID, &r.Name, &r.Description, &r.IsSystem, &r.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (r *RepoLayer) GetPermissions(ctx context.Context) ([]*ent.Permission, error) {
+	rows, err := r.dbConn.Query(ctx, `SELECT name, description FROM permission ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ps []*ent.Permission
+	for rows.Next() {
+		var p ent.Permission
+		err := rows.Scan(&p.Name, &p.Description)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, &p)
+	}
+	return ps, rows.Err()
+}
+
+// CountPermissions возвращает, сколько из переданных прав существует.
+func (r *RepoLayer) CountPermissions(ctx context.Context, names []string) (int, error) {
+	row := r.dbConn.QueryRow(ctx, `SELECT count(*) FROM permission WHERE name = ANY($1)`, names)
+	var count int
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *RepoLayer) GetRoles(ctx context.Context) ([]*ent.Role, error) {
+	rows, err := r.dbConn.Query(ctx, sqlRowGetRoles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var rs []*ent.Role
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, role)
+	}
+	return rs, rows.Err()
+}
+
+func (r *RepoLayer) GetRole(ctx context.Context, name string) (*ent.Role, error) {
+	return scanRole(r.dbConn.QueryRow(ctx, sqlRowGetRole, name))
+}
+
+func (r *RepoLayer) CreateRole(ctx context.Context, name, description string) (*ent.Role, error) {
+	row := r.dbConn.QueryRow(ctx,
+		`INSERT INTO role(name, description) VALUES ($1, $2) RETURNING id, name, description, is_system`,
+		name, description)
+	var role ent.Role
+	err := row.Scan(&role.ID, &role.Name, &role.Description, &role.IsSystem)
+	if err != nil {
+		return nil, err
+	}
+	role.Permissions = make([]string, 0)
+	return &role, nil
+}
+
+func (r *RepoLayer) DeleteRole(ctx context.Context, id int) error {
+	tag, err := r.dbConn.Exec(ctx, `DELETE FROM role WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return me.ErrNoRowsAffected
+	}
+	return nil
+}
+
+// SetRolePermissions заменяет набор прав роли. Вызывается внутри транзакции usecase.
+func (r *RepoLayer) SetRolePermissions(ctx context.Context, roleID int, permissions []string) error {
+	_, err := r.dbConn.Exec(ctx, `DELETE FROM role_permission WHERE role_id=$1`, roleID)
+	if err != nil {
+		return err
+	}
+	_, err = r.dbConn.Exec(ctx,
+		`INSERT INTO role_permission(role_id, permission) SELECT $1, unnest($2::text[]) ON CONFLICT DO NOTHING`,
+		roleID, permissions)
+	return err
+}
+
+// AssignToUser назначает роль пользователю. Возвращает false, если роль уже была назначена.
+func (r *RepoLayer) AssignToUser(ctx context.Context, roleID int, userID string) (bool, error) {
+	tag, err := r.dbConn.Exec(ctx,
+		`INSERT INTO user_role(user_id, role_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, userID, roleID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() != 0, nil
+}
+
+// RevokeFromUser отзывает роль у пользователя. Возвращает false, если роль не была назначена.
+func (r *RepoLayer) RevokeFromUser(ctx context.Context, roleID int, userID string) (bool, error) {
+	tag, err := r.dbConn.Exec(ctx, `DELETE FROM user_role WHERE user_id=$1 AND role_id=$2`, userID, roleID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() != 0, nil
+}
+
+// AssignToGroup назначает роль группе. Возвращает false, если роль уже была назначена.
+func (r *RepoLayer) AssignToGroup(ctx context.Context, roleID, groupID int) (bool, error) {
+	tag, err := r.dbConn.Exec(ctx,
+		`INSERT INTO group_role(group_id, role_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, groupID, roleID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() != 0, nil
+}
+
+// RevokeFromGroup отзывает роль у группы. Возвращает false, если роль не была назначена.
+func (r *RepoLayer) RevokeFromGroup(ctx context.Context, roleID, groupID int) (bool, error) {
+	tag, err := r.dbConn.Exec(ctx, `DELETE FROM group_role WHERE group_id=$1 AND role_id=$2`, groupID, roleID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() != 0, nil
+}
+
+// GetUserPermissions возвращает права пользователя с учетом ролей его групп.
+func (r *RepoLayer) GetUserPermissions(ctx context.Context, userID string) ([]string, error) {
+	rows, err := r.dbConn.Query(ctx, sqlRowGetUserPermissions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	permissions := make([]string, 0)
+	for rows.Next() {
+		var p string
+		err := rows.Scan(&p)
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, p)
+	}
+	return permissions, rows.Err()
+}
+
+func (r *RepoLayer) HasPermission(ctx context.Context, userID, permission string) (bool, error) {
+	row := r.dbConn.QueryRow(ctx, sqlRowHasPermission, userID, permission)
+	var allowed bool
+	err := row.Scan(&allowed)
+	if err != nil {
+		return false, err
+	}
+	return allowed, nil
+}
@@ -0,0 +1,163 @@
+package sweeper
+
+import (
+	"context"
+	"time"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	"github.com/cantylv/authorization-service/services/postgres"
+)
+
+type Repo interface {
+	DeleteExpired(ctx context.Context) ([]*ent.ExpiredGrant, error)
+	ExpireInvitations(ctx context.Context) (int, error)
+	DeleteOldDecisions(ctx context.Context, before time.Time) (int, error)
+	DeleteOldChangeEvents(ctx context.Context, before time.Time) (int, error)
+}
+
+var _ Repo = (*RepoLayer)(nil)
+
+type RepoLayer struct {
+	dbConn postgres.DB
+}
+
+// NewRepoLayer возвращает структуру уровня repository. Позволяет удалять истекшие привелегии и участия в группах,
+// помечать истекшие приглашения в группы и удалять старые записи журнала решений о доступе и outbox.
+func NewRepoLayer(dbConn postgres.DB) *RepoLayer {
+	return &RepoLayer{
+		dbConn: dbConn,
+	}
+}
+
+var (
+	// удаление из всех таблиц, запись об удаленных строках и события grant.expired в outbox выполняются
+	// одним запросом, поэтому параллельные запуски очистки не удалят одну запись дважды
+	sqlRowDeleteExpired = `
+		WITH user_agent AS (
+			DELETE FROM privelege_user t WHERE t.valid_until <= now()
+			RETURNING 'privelege_user' AS table_name, t.id, t.user_id, NULL::int AS group_id, t.agent_id,
+				NULL::int AS action_id, t.valid_from, t.valid_until
+		), group_agent AS (
+			DELETE FROM privelege_group t WHERE t.valid_until <= now()
+			RETURNING 'privelege_group', t.id, NULL::uuid, t.group_id, t.agent_id, NULL::int, t.valid_from, t.valid_until
+		), user_action AS (
+			DELETE FROM privelege_user_action t USING agent_action aa
+			WHERE aa.id = t.action_id AND t.valid_until <= now()
+			RETURNING 'privelege_user_action', t.id, t.user_id, NULL::int, aa.agent_id, t.action_id,
+				t.valid_from, t.valid_until
+		), group_action AS (
+			DELETE FROM privelege_group_action t USING agent_action aa
+			WHERE aa.id = t.action_id AND t.valid_until <= now()
+			RETURNING 'privelege_group_action', t.id, NULL::uuid, t.group_id, aa.agent_id, t.action_id,
+				t.valid_from, t.valid_until
+		), membership AS (
+			DELETE FROM participation t WHERE t.valid_until <= now()
+			RETURNING 'participation', t.id, t.user_id, t.group_id, NULL::int, NULL::int, t.valid_from, t.valid_until
+		), removed AS (
+			INSERT INTO expired_grant(table_name, row_id, user_id, group_id, agent_id, action_id, valid_from, valid_until)
+			SELECT * FROM user_agent
+			UNION ALL SELECT * FROM group_agent
+			UNION ALL SELECT * FROM user_action
+			UNION ALL SELECT * FROM group_action
+			UNION ALL SELECT * FROM membership
+			RETURNING id, table_name, row_id, user_id, group_id, agent_id, action_id, valid_from, valid_until, removed_at
+		), events AS (
+			INSERT INTO outbox_event(event, target_type, target, before_state)
+			SELECT $1,
+				CASE WHEN r.table_name IN ('privelege_user', 'privelege_user_action') THEN 'user' ELSE 'group' END,
+				COALESCE(CASE WHEN r.table_name IN ('privelege_user', 'privelege_user_action') THEN u.email ELSE g.name END, ''),
+				jsonb_strip_nulls(jsonb_build_object(
+					'user', CASE WHEN r.table_name = 'participation' THEN u.email END,
+					'agent', a.name,
+					'action', aa.name,
+					'valid_from', r.valid_from,
+					'valid_until', r.valid_until
+				))
+			FROM removed r
+			LEFT JOIN "user" u ON u.id = r.user_id
+			LEFT JOIN "group" g ON g.id = r.group_id
+			LEFT JOIN agent a ON a.id = r.agent_id
+			LEFT JOIN agent_action aa ON aa.id = r.action_id
+		)
+		SELECT id, table_name, row_id, COALESCE(user_id::text, ''), COALESCE(group_id, 0), COALESCE(agent_id, 0),
+			COALESCE(action_id, 0), valid_from, valid_until, removed_at
+		FROM removed
+	`
+	// приглашенный и пригласивший получают уведомление об истечении приглашения
+	sqlRowExpireInvitations = `
+		WITH e AS (
+			UPDATE group_invitation i
+			SET status = 'expired', updated_at = now()
+			FROM "group" g, "user" u
+			WHERE g.id = i.group_id AND u.id = i.user_id AND i.status = 'pending' AND i.expires_at <= now()
+			RETURNING i.id, i.user_id, i.inviter_id, g.name, u.email
+		), n AS (
+			INSERT INTO notification(user_id, event, payload)
+			SELECT r.user_id, $1, jsonb_build_object('group', e.name, 'user', e.email, 'invitation_id', e.id)
+			FROM e CROSS JOIN LATERAL (VALUES (e.user_id), (e.inviter_id)) r(user_id)
+			WHERE r.user_id IS NOT NULL
+		)
+		SELECT count(*) FROM e
+	`
+	sqlRowDeleteOldDecisions = `
+		DELETE FROM access_decision WHERE checked_at < $1
+	`
+	sqlRowDeleteOldChangeEvents = `
+		DELETE FROM outbox_event WHERE created_at < $1
+	`
+)
+
+// DeleteExpired удаляет привелегии и участия в группах, срок действия которых истек, сохраняет
+// удаленные записи в таблицу expired_grant и добавляет о каждой событие grant.expired в outbox.
+// Возвращает удаленные записи.
+func (r *RepoLayer) DeleteExpired(ctx context.Context) ([]*ent.ExpiredGrant, error) {
+	rows, err := r.dbConn.Query(ctx, sqlRowDeleteExpired, mc.EventGrantExpired)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var grants []*ent.ExpiredGrant
+	for rows.Next() {
+		var g ent.ExpiredGrant
+		err := rows.Scan(&g.ID, &g.Table, &g.RowID, &g.UserID, &g.GroupID, &g.AgentID, &g.ActionID,
+			&g.ValidFrom, &g.ValidUntil, &g.RemovedAt)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, &g)
+	}
+	return grants, rows.Err()
+}
+
+// ExpireInvitations помечает истекшими приглашения в группы, на которые не ответили в срок, и уведомляет об этом
+// приглашенных и пригласивших. Возвращает количество истекших приглашений.
+func (r *RepoLayer) ExpireInvitations(ctx context.Context) (int, error) {
+	row := r.dbConn.QueryRow(ctx, sqlRowExpireInvitations, mc.EventInvitationExpired)
+	var count int
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteOldDecisions удаляет записи журнала решений о доступе, сделанные раньше before. Возвращает количество
+// удаленных записей.
+func (r *RepoLayer) DeleteOldDecisions(ctx context.Context, before time.Time) (int, error) {
+	tag, err := r.dbConn.Exec(ctx, sqlRowDeleteOldDecisions, before)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// DeleteOldChangeEvents удаляет события outbox, добавленные раньше before, в том числе недоставленные.
+// Возвращает количество удаленных событий.
+func (r *RepoLayer) DeleteOldChangeEvents(ctx context.Context, before time.Time) (int, error) {
+	tag, err := r.dbConn.Exec(ctx, sqlRowDeleteOldChangeEvents, before)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
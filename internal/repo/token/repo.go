@@ -0,0 +1,123 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/cantylv/authorization-service/services/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+//go:generate mockgen -source ./repo.go -destination=./mocks/repo.go -package=mock_repo
+type Repo interface {
+	Create(ctx context.Context, userID, tokenHash string, expiresAt time.Time) (*ent.RefreshToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (*ent.RefreshToken, error)
+	Rotate(ctx context.Context, oldID, newTokenHash string, expiresAt time.Time) (*ent.RefreshToken, error)
+	Revoke(ctx context.Context, id string) error
+	RevokeAllByUser(ctx context.Context, userID string) error
+}
+
+var _ Repo = (*RepoLayer)(nil)
+
+type RepoLayer struct {
+	dbConn postgres.DB
+}
+
+// NewRepoLayer возвращает структуру уровня repository. Позволяет работать с refresh токенами пользователей.
+func NewRepoLayer(dbConn postgres.DB) *RepoLayer {
+	return &RepoLayer{
+		dbConn: dbConn,
+	}
+}
+
+var (
+	token_fields = "id, user_id, token_hash, expires_at, revoked_at, replaced_by"
+)
+
+var (
+	sqlRowCreateToken = fmt.Sprintf(`
+		INSERT INTO refresh_token (
+			user_id,
+			token_hash,
+			expires_at
+		) VALUES ($1, $2, $3) RETURNING %s`, token_fields)
+	sqlRowGetByHash = fmt.Sprintf(
+		`SELECT %s FROM refresh_token WHERE token_hash=$1`,
+		token_fields,
+	)
+)
+
+func scanToken(row pgx.Row) (*ent.RefreshToken, error) {
+	var t ent.RefreshToken
+	err := row.Scan(&t.ID, &t.UserID, &t.TokenHash, &t.ExpiresAt, &t.RevokedAt, &t.ReplacedBy)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Create сохраняет хэш нового refresh токена пользователя.
+func (r *RepoLayer) Create(ctx context.Context, userID, tokenHash string, expiresAt time.Time) (*ent.RefreshToken, error) {
+	return scanToken(r.dbConn.QueryRow(ctx, sqlRowCreateToken, userID, tokenHash, expiresAt))
+}
+
+// GetByHash возвращает refresh токен по его хэшу, в том числе отозванный или просроченный.
+func (r *RepoLayer) GetByHash(ctx context.Context, tokenHash string) (*ent.RefreshToken, error) {
+	return scanToken(r.dbConn.QueryRow(ctx, sqlRowGetByHash, tokenHash))
+}
+
+// Rotate выпускает новый refresh токен взамен старого. Старый токен отзывается и ссылается на новый.
+// Если старый токен уже был отозван параллельным запросом, транзакция откатывается.
+func (r *RepoLayer) Rotate(ctx context.Context, oldID, newTokenHash string, expiresAt time.Time) (*ent.RefreshToken, error) {
+	tx, err := r.dbConn.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	// выпускаем новый токен тому же пользователю
+	rowToken := tx.QueryRow(ctx, fmt.Sprintf(`
+		INSERT INTO refresh_token (user_id, token_hash, expires_at)
+		SELECT user_id, $2, $3 FROM refresh_token WHERE id = $1
+		RETURNING %s`, token_fields), oldID, newTokenHash, expiresAt)
+	t, err := scanToken(rowToken)
+	if err != nil {
+		return nil, err
+	}
+	// отзываем старый токен
+	tag, err := tx.Exec(ctx, `
+		UPDATE refresh_token SET revoked_at = now(), replaced_by = $2
+		WHERE id = $1 AND revoked_at IS NULL`, oldID, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		err = me.ErrInvalidRefreshToken
+		return nil, err
+	}
+
+	// если все прошло успешно, коммитим транзакцию
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Revoke отзывает refresh токен. Повторный отзыв не является ошибкой.
+func (r *RepoLayer) Revoke(ctx context.Context, id string) error {
+	_, err := r.dbConn.Exec(ctx, `UPDATE refresh_token SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL`, id)
+	return err
+}
+
+// RevokeAllByUser отзывает все действующие refresh токены пользователя.
+func (r *RepoLayer) RevokeAllByUser(ctx context.Context, userID string) error {
+	_, err := r.dbConn.Exec(ctx, `UPDATE refresh_token SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL`, userID)
+	return err
+}
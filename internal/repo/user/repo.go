@@ -6,12 +6,13 @@ import (
 
 	ent "github.com/cantylv/authorization-service/internal/entity"
 	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
-	"github.com/jackc/pgx/v5"
+	"github.com/cantylv/authorization-service/services/postgres"
 )
 
 //go:generate mockgen -source ./repo.go -destination=./mocks/repo.go -package=mock_repo
 type Repo interface {
 	GetByEmail(ctx context.Context, email string) (*ent.User, error)
+	GetByID(ctx context.Context, id string) (*ent.User, error)
 	DeleteByEmail(ctx context.Context, email string) error
 	Create(ctx context.Context, initData *ent.User) (*ent.User, error)
 }
@@ -19,11 +20,11 @@ type Repo interface {
 var _ Repo = (*RepoLayer)(nil)
 
 type RepoLayer struct {
-	dbConn *pgx.Conn
+	dbConn postgres.DB
 }
 
 // NewRepoLayer возвращает структуру уровня repository. Позволяет работать с пользователем (crd).
-func NewRepoLayer(dbConn *pgx.Conn) *RepoLayer {
+func NewRepoLayer(dbConn postgres.DB) *RepoLayer {
 	return &RepoLayer{
 		dbConn: dbConn,
 	}
@@ -38,6 +39,10 @@ var (
 		`SELECT %s FROM "user" WHERE email=$1`,
 		user_fields,
 	)
+	sqlRowGetByID = fmt.Sprintf(
+		`SELECT %s FROM "user" WHERE id=$1`,
+		user_fields,
+	)
 	sqlRowCreateUser = fmt.Sprintf(`
 		INSERT INTO "user" (
 			email,  
@@ -58,6 +63,17 @@ func (r *RepoLayer) GetByEmail(ctx context.Context, email string) (*ent.User, er
 	return &u, nil
 }
 
+// GetByID позволяет получить пользователя по его идентификатору
+func (r *RepoLayer) GetByID(ctx context.Context, id string) (*ent.User, error) {
+	row := r.dbConn.QueryRow(ctx, sqlRowGetByID, id)
+	var u ent.User
+	err := row.Scan(&u.ID, &u.Email, &u.Password, &u.FirstName, &u.LastName)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
 // DeleteByEmail позволяет удалить пользователя из системы. Пользователя можно удалить только
 // в том случае, если он не является ответственным за какую-либо группу. Если он таковым является,
 // необходимо сперва поменять ответственного. Это сделать может только root.
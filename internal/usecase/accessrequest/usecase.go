@@ -0,0 +1,353 @@
+package accessrequest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/internal/repo/accessrequest"
+	"github.com/cantylv/authorization-service/internal/repo/agent"
+	"github.com/cantylv/authorization-service/internal/repo/group"
+	"github.com/cantylv/authorization-service/internal/repo/privelege"
+	"github.com/cantylv/authorization-service/internal/repo/user"
+	"github.com/cantylv/authorization-service/internal/usecase/audit"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/cantylv/authorization-service/services/postgres"
+	"github.com/spf13/viper"
+)
+
+type Usecase interface {
+	Create(ctx context.Context, data *dto.CreateAccessRequestData) (*ent.AccessRequest, error)
+	List(ctx context.Context, filter *dto.AccessRequestFilter) ([]*ent.AccessRequest, error)
+	Approve(ctx context.Context, id int) (*ent.AccessRequest, error)
+	Reject(ctx context.Context, id int) (*ent.AccessRequest, error)
+	Cancel(ctx context.Context, id int) (*ent.AccessRequest, error)
+}
+
+var _ Usecase = (*UsecaseLayer)(nil)
+
+type UsecaseLayer struct {
+	uow               postgres.UnitOfWork
+	policy            policy.Policy
+	audit             audit.Recorder
+	repoAccessRequest accessrequest.Repo
+	repoAgent         agent.Repo
+	repoGroup         group.Repo
+	repoPrivelege     privelege.Repo
+	repoUser          user.Repo
+}
+
+// NewUsecaseLayer возвращает структуру уровня usecase, управляющую заявками на временный доступ к агентам.
+// Прямой доступ одобряет пользователь с правом privilege.grant, участие в группе - ответственный за группу
+// или пользователь с правом group.manage. Выданный по заявке доступ записывается в журнал аудита.
+func NewUsecaseLayer(uow postgres.UnitOfWork, policy policy.Policy, audit audit.Recorder, repoAccessRequest accessrequest.Repo,
+	repoAgent agent.Repo, repoGroup group.Repo, repoPrivelege privelege.Repo, repoUser user.Repo) *UsecaseLayer {
+	return &UsecaseLayer{
+		uow:               uow,
+		policy:            policy,
+		audit:             audit,
+		repoAccessRequest: repoAccessRequest,
+		repoAgent:         repoAgent,
+		repoGroup:         repoGroup,
+		repoPrivelege:     repoPrivelege,
+		repoUser:          repoUser,
+	}
+}
+
+// Create создает заявку аутентифицированного пользователя на доступ к агенту. Заявку нельзя создать на доступ,
+// который у пользователя уже есть, а заявку на участие в группе - если группа не дает запрошенного доступа.
+func (u *UsecaseLayer) Create(ctx context.Context, data *dto.CreateAccessRequestData) (*ent.AccessRequest, error) {
+	var res *ent.AccessRequest
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = u.create(ctx, data)
+		return err
+	})
+	return res, err
+}
+
+func (u *UsecaseLayer) create(ctx context.Context, data *dto.CreateAccessRequestData) (*ent.AccessRequest, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if data.RequestedDuration() > viper.GetDuration("access_request.max_duration") {
+		return nil, me.ErrInvalidRequestDuration
+	}
+	a, err := u.repoAgent.Read(ctx, data.Agent)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrAgentNotExist
+		}
+		return nil, err
+	}
+	var actionID *int
+	if data.Action != "" {
+		act, err := u.repoAgent.GetAction(ctx, a.ID, data.Action)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, me.ErrAgentActionNotExist
+			}
+			return nil, err
+		}
+		actionID = &act.ID
+	}
+	var groupID *int
+	if data.Group != "" {
+		g, err := u.repoGroup.GetGroup(ctx, data.Group)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, me.ErrGroupNotExist
+			}
+			return nil, err
+		}
+		// участие в группе, в которой пользователь уже состоит, просить бессмысленно
+		isParticipant, err := u.repoGroup.IsParticipantOfGroup(ctx, principal.ID, g.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if isParticipant {
+			return nil, me.ErrUserAlreadyInGroup
+		}
+		// участие в группе дает только доступ, который разрешают правила группы и ее родительских групп
+		granted, err := u.repoPrivelege.GroupGrantsAccess(ctx, g.ID, a.ID, actionID)
+		if err != nil {
+			return nil, err
+		}
+		if !granted {
+			return nil, me.ErrGroupHasNoAccess
+		}
+		groupID = &g.ID
+	} else if actionID == nil {
+		isUserAgent, err := u.repoAgent.IsUserAgent(ctx, principal.ID, a.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if isUserAgent {
+			return nil, me.ErrUserAgentAlreadyExist
+		}
+	}
+	id, err := u.repoAccessRequest.Create(ctx, principal.ID, a.ID, actionID, groupID, data.Justification, data.RequestedDuration())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrAccessRequestAlreadyExist
+		}
+		return nil, err
+	}
+	return u.repoAccessRequest.Get(ctx, id)
+}
+
+// List возвращает заявки, подходящие под фильтр. Пользователь с правом privilege.read или privilege.grant видит
+// все заявки, остальные только свои и заявки в группы, за которые отвечают.
+func (u *UsecaseLayer) List(ctx context.Context, filter *dto.AccessRequestFilter) ([]*ent.AccessRequest, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	viewerID := &principal.ID
+	for _, permission := range []string{mc.PermPrivilegeRead, mc.PermPrivilegeGrant} {
+		allowed, err := u.policy.HasPermission(ctx, permission)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			viewerID = nil
+			break
+		}
+	}
+	return u.repoAccessRequest.List(ctx, filter, viewerID)
+}
+
+// Approve одобряет заявку и выдает доступ на запрошенный срок, отсчитываемый с момента одобрения.
+func (u *UsecaseLayer) Approve(ctx context.Context, id int) (*ent.AccessRequest, error) {
+	var res *ent.AccessRequest
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = u.approve(ctx, id)
+		return err
+	})
+	return res, err
+}
+
+func (u *UsecaseLayer) approve(ctx context.Context, id int) (*ent.AccessRequest, error) {
+	principal, ar, err := u.readForReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	duration, err := time.ParseDuration(ar.Duration)
+	if err != nil {
+		return nil, err
+	}
+	validUntil := time.Now().Add(duration)
+	period := &dto.GrantPeriodData{ValidUntil: &validUntil}
+	switch {
+	case ar.GroupID != nil:
+		isParticipant, err := u.repoGroup.IsParticipantOfGroup(ctx, ar.UserID, *ar.GroupID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if isParticipant {
+			return nil, me.ErrUserAlreadyInGroup
+		}
+		// правила группы могли измениться после создания заявки
+		granted, err := u.repoPrivelege.GroupGrantsAccess(ctx, *ar.GroupID, ar.AgentID, ar.ActionID)
+		if err != nil {
+			return nil, err
+		}
+		if !granted {
+			return nil, me.ErrGroupHasNoAccess
+		}
+		err = u.repoGroup.AddUserToGroup(ctx, ar.UserID, *ar.GroupID, period)
+		if err != nil {
+			return nil, err
+		}
+		member := &ent.AuditMember{User: ar.Email, ValidUntil: &validUntil}
+		if err := u.audit.Record(ctx, mc.AuditGroupMemberAdd, mc.AuditTargetGroup, ar.Group, nil, member); err != nil {
+			return nil, err
+		}
+	case ar.ActionID != nil:
+		created, err := u.repoPrivelege.CreateUserAgentAction(ctx, ar.UserID, *ar.ActionID, period)
+		if err != nil {
+			return nil, err
+		}
+		if !created {
+			return nil, me.ErrUserActionAlreadyExist
+		}
+		grant := &ent.AuditGrant{Agent: ar.Agent, Action: ar.Action, ValidUntil: &validUntil}
+		if err := u.audit.Record(ctx, mc.AuditPrivilegeGrant, mc.AuditTargetUser, ar.Email, nil, grant); err != nil {
+			return nil, err
+		}
+	default:
+		isUserAgent, err := u.repoAgent.IsUserAgent(ctx, ar.UserID, ar.AgentID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if isUserAgent {
+			return nil, me.ErrUserAgentAlreadyExist
+		}
+		_, err = u.repoPrivelege.CreateUserAgent(ctx, ar.UserID, ar.AgentID, period)
+		if err != nil {
+			return nil, err
+		}
+		grant := &ent.AuditGrant{Agent: ar.Agent, ValidUntil: &validUntil}
+		if err := u.audit.Record(ctx, mc.AuditPrivilegeGrant, mc.AuditTargetUser, ar.Email, nil, grant); err != nil {
+			return nil, err
+		}
+	}
+	err = u.setStatus(ctx, id, mc.AccessRequestApproved, &principal.ID, &validUntil)
+	if err != nil {
+		return nil, err
+	}
+	return u.repoAccessRequest.Get(ctx, id)
+}
+
+// Reject отклоняет заявку.
+func (u *UsecaseLayer) Reject(ctx context.Context, id int) (*ent.AccessRequest, error) {
+	var res *ent.AccessRequest
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		principal, _, err := u.readForReview(ctx, id)
+		if err != nil {
+			return err
+		}
+		err = u.setStatus(ctx, id, mc.AccessRequestRejected, &principal.ID, nil)
+		if err != nil {
+			return err
+		}
+		res, err = u.repoAccessRequest.Get(ctx, id)
+		return err
+	})
+	return res, err
+}
+
+// Cancel отменяет заявку. Отменить заявку может только ее автор, пока она не рассмотрена.
+func (u *UsecaseLayer) Cancel(ctx context.Context, id int) (*ent.AccessRequest, error) {
+	var res *ent.AccessRequest
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		principal, err := f.GetCtxPrincipal(ctx)
+		if err != nil {
+			return err
+		}
+		ar, err := u.read(ctx, id)
+		if err != nil {
+			return err
+		}
+		if ar.UserID != principal.ID {
+			return me.ErrOnlyRequesterCanCancel
+		}
+		err = u.setStatus(ctx, id, mc.AccessRequestCanceled, nil, nil)
+		if err != nil {
+			return err
+		}
+		res, err = u.repoAccessRequest.Get(ctx, id)
+		return err
+	})
+	return res, err
+}
+
+func (u *UsecaseLayer) read(ctx context.Context, id int) (*ent.AccessRequest, error) {
+	ar, err := u.repoAccessRequest.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrAccessRequestNotExist
+		}
+		return nil, err
+	}
+	return ar, nil
+}
+
+// readForReview возвращает нерассмотренную заявку, если аутентифицированный пользователь может ее рассмотреть.
+// Свою заявку рассмотреть нельзя даже при наличии прав.
+func (u *UsecaseLayer) readForReview(ctx context.Context, id int) (*ent.Principal, *ent.AccessRequest, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	ar, err := u.read(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ar.Status != mc.AccessRequestInProgress {
+		return nil, nil, me.ErrAccessRequestIsReviewed
+	}
+	if ar.UserID == principal.ID {
+		return nil, nil, me.ErrCantReviewOwnAccessRequest
+	}
+	if ar.GroupID == nil {
+		if err := u.policy.Authorize(ctx, mc.PermPrivilegeGrant); err != nil {
+			return nil, nil, err
+		}
+		return principal, ar, nil
+	}
+	// участие в группе одобряет ответственный за группу или пользователь с правом group.manage
+	g, err := u.repoGroup.GetGroup(ctx, ar.Group)
+	if err != nil {
+		return nil, nil, err
+	}
+	isOwner, err := u.repoGroup.IsGroupOwner(ctx, principal.ID, g.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isOwner {
+		if err := u.policy.Authorize(ctx, mc.PermGroupManage); err != nil {
+			return nil, nil, err
+		}
+	}
+	return principal, ar, nil
+}
+
+func (u *UsecaseLayer) setStatus(ctx context.Context, id int, status string, reviewerID *string, validUntil *time.Time) error {
+	err := u.repoAccessRequest.SetStatus(ctx, id, status, reviewerID, validUntil)
+	if err != nil {
+		if errors.Is(err, me.ErrNoRowsAffected) {
+			return me.ErrAccessRequestIsReviewed
+		}
+		return err
+	}
+	return nil
+}
@@ -4,36 +4,59 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"slices"
 
 	ent "github.com/cantylv/authorization-service/internal/entity"
 	"github.com/cantylv/authorization-service/internal/repo/agent"
+	"github.com/cantylv/authorization-service/internal/usecase/audit"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
 	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
-	"github.com/spf13/viper"
+	"github.com/cantylv/authorization-service/services/postgres"
 )
 
 type Usecase interface {
-	CreateAgent(ctx context.Context, emailCreator, agentName string) (*ent.Agent, error)
-	DeleteAgent(ctx context.Context, emailCreator, agentName string) error
-	GetAgents(ctx context.Context, emailCreator string) ([]*ent.Agent, error)
+	CreateAgent(ctx context.Context, agentName string, actions []string) (*ent.Agent, error)
+	DeleteAgent(ctx context.Context, agentName string) error
+	GetAgents(ctx context.Context) ([]*ent.Agent, error)
 }
 
 var _ Usecase = (*UsecaseLayer)(nil)
 
 type UsecaseLayer struct {
+	uow       postgres.UnitOfWork
+	policy    policy.Policy
+	audit     audit.Recorder
 	repoAgent agent.Repo
 }
 
-// NewUsecaseLayer возвращает структуру уровня usecase, управляющую агентами серверной архитектуры
-func NewUsecaseLayer(repoAgent agent.Repo) *UsecaseLayer {
+// NewUsecaseLayer возвращает структуру уровня usecase, управляющую агентами серверной архитектуры.
+// Изменяющие методы выполняются в одной транзакции через uow, права проверяются политикой доступа,
+// изменения записываются в журнал аудита в той же транзакции.
+func NewUsecaseLayer(uow postgres.UnitOfWork, policy policy.Policy, audit audit.Recorder, repoAgent agent.Repo) *UsecaseLayer {
 	return &UsecaseLayer{
+		uow:       uow,
+		policy:    policy,
+		audit:     audit,
 		repoAgent: repoAgent,
 	}
 }
 
-// CreateAgent создает агента, его создать может только root пользователь
-func (u *UsecaseLayer) CreateAgent(ctx context.Context, emailCreator, agentName string) (*ent.Agent, error) {
-	if emailCreator != viper.GetString("root_email") {
-		return nil, me.ErrOnlyRootCanAddAgent
+// CreateAgent создает агента вместе с его действиями, для этого нужно право agent.create.
+// Действия агента нельзя изменить после создания, права на них выдаются отдельно.
+func (u *UsecaseLayer) CreateAgent(ctx context.Context, agentName string, actions []string) (*ent.Agent, error) {
+	var res *ent.Agent
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = u.createAgent(ctx, agentName, actions)
+		return err
+	})
+	return res, err
+}
+
+func (u *UsecaseLayer) createAgent(ctx context.Context, agentName string, actions []string) (*ent.Agent, error) {
+	if err := u.policy.Authorize(ctx, mc.PermAgentCreate); err != nil {
+		return nil, err
 	}
 	// проверяем, есть ли уже агент с таким именем, если есть, то возвращаем ошибку
 	a, err := u.repoAgent.Read(ctx, agentName)
@@ -44,17 +67,31 @@ func (u *UsecaseLayer) CreateAgent(ctx context.Context, emailCreator, agentName
 		return nil, me.ErrAgentAlreadyExist
 	}
 	// создаем
-	a, err = u.repoAgent.Create(ctx, agentName)
+	// повторяющиеся действия регистрируются один раз
+	actions = slices.Compact(slices.Sorted(slices.Values(actions)))
+	if actions == nil {
+		actions = make([]string, 0)
+	}
+	a, err = u.repoAgent.Create(ctx, agentName, actions)
 	if err != nil {
 		return nil, err
 	}
+	if err := u.audit.Record(ctx, mc.AuditAgentCreate, mc.AuditTargetAgent, agentName, nil, a); err != nil {
+		return nil, err
+	}
 	return a, nil
 }
 
-// DeleteAgent удаляет агента, его удалить может только root пользователь
-func (u *UsecaseLayer) DeleteAgent(ctx context.Context, emailCreator, agentName string) error {
-	if emailCreator != viper.GetString("root_email") {
-		return me.ErrOnlyRootCanDeleteAgent
+// DeleteAgent удаляет агента, для этого нужно право agent.delete
+func (u *UsecaseLayer) DeleteAgent(ctx context.Context, agentName string) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.deleteAgent(ctx, agentName)
+	})
+}
+
+func (u *UsecaseLayer) deleteAgent(ctx context.Context, agentName string) error {
+	if err := u.policy.Authorize(ctx, mc.PermAgentDelete); err != nil {
+		return err
 	}
 	// проверяем, есть ли агент с таким именем
 	a, err := u.repoAgent.Read(ctx, agentName)
@@ -64,13 +101,16 @@ func (u *UsecaseLayer) DeleteAgent(ctx context.Context, emailCreator, agentName
 		}
 		return err
 	}
-	return u.repoAgent.Delete(ctx, a.ID)
+	if err := u.repoAgent.Delete(ctx, a.ID); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, mc.AuditAgentDelete, mc.AuditTargetAgent, agentName, a, nil)
 }
 
-// DeleteAgent удаляет агента, его удалить может только root пользователь
-func (u *UsecaseLayer) GetAgents(ctx context.Context, emailCreator string) ([]*ent.Agent, error) {
-	if emailCreator != viper.GetString("root_email") {
-		return nil, me.ErrOnlyRootCanGetAgents
+// GetAgents возвращает список всех агентов, для этого нужно право agent.read
+func (u *UsecaseLayer) GetAgents(ctx context.Context) ([]*ent.Agent, error) {
+	if err := u.policy.Authorize(ctx, mc.PermAgentRead); err != nil {
+		return nil, err
 	}
 	// проверяем, есть ли агент с таким именем
 	a, err := u.repoAgent.GetAll(ctx)
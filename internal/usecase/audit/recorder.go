@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/repo/audit"
+	"github.com/cantylv/authorization-service/internal/repo/outbox"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+)
+
+// Recorder единая точка записи в журнал аудита. Вызывается внутри транзакции изменения, поэтому запись
+// появляется в журнале тогда и только тогда, когда изменение зафиксировано. Изменения, влияющие на доступ
+// к агентам (mc.OutboxActions), в той же транзакции добавляются в outbox для доставки подписчикам.
+type Recorder interface {
+	// Record записывает действие аутентифицированного пользователя над объектом target типа targetType.
+	// before и after - состояние объекта до и после изменения, nil означает отсутствие объекта.
+	Record(ctx context.Context, action, targetType, target string, before, after any) error
+}
+
+var _ Recorder = (*RecorderLayer)(nil)
+
+type RecorderLayer struct {
+	repoAudit  audit.Repo
+	repoOutbox outbox.Repo
+}
+
+// NewRecorderLayer возвращает структуру, записывающую изменения в журнал аудита и события изменений в outbox.
+func NewRecorderLayer(repoAudit audit.Repo, repoOutbox outbox.Repo) *RecorderLayer {
+	return &RecorderLayer{
+		repoAudit:  repoAudit,
+		repoOutbox: repoOutbox,
+	}
+}
+
+func (r *RecorderLayer) Record(ctx context.Context, action, targetType, target string, before, after any) error {
+	entry := &ent.AuditEntry{
+		Action:     action,
+		TargetType: targetType,
+		Target:     target,
+		RequestID:  f.GetRequestIDFromCtx(ctx),
+	}
+	// изменения без аутентифицированного пользователя (например, регистрация через OpenID) пишутся без автора
+	if principal, err := f.GetCtxPrincipal(ctx); err == nil {
+		entry.ActorID = &principal.ID
+		entry.Actor = principal.Email
+	}
+	var err error
+	entry.Before, err = marshalState(before)
+	if err != nil {
+		return err
+	}
+	entry.After, err = marshalState(after)
+	if err != nil {
+		return err
+	}
+	if err := r.repoAudit.Append(ctx, entry); err != nil {
+		return err
+	}
+	if _, ok := mc.OutboxActions[action]; !ok {
+		return nil
+	}
+	return r.repoOutbox.Append(ctx, &ent.ChangeEvent{
+		Event:      action,
+		TargetType: targetType,
+		Target:     target,
+		Actor:      entry.Actor,
+		Before:     entry.Before,
+		After:      entry.After,
+		RequestID:  entry.RequestID,
+	})
+}
+
+// marshalState сериализует состояние объекта, nil и типизированный nil указатель дают пустое состояние.
+func marshalState(state any) (json.RawMessage, error) {
+	if state == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+	return data, nil
+}
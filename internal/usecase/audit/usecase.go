@@ -0,0 +1,61 @@
+package audit
+
+import (
+	"context"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/internal/repo/audit"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	"github.com/cantylv/authorization-service/services/postgres"
+)
+
+type Usecase interface {
+	List(ctx context.Context, filter *dto.AuditFilter) ([]*ent.AuditEntry, error)
+	Verify(ctx context.Context) (*ent.AuditVerification, error)
+}
+
+var _ Usecase = (*UsecaseLayer)(nil)
+
+type UsecaseLayer struct {
+	uow       postgres.UnitOfWork
+	policy    policy.Policy
+	repoAudit audit.Repo
+}
+
+// NewUsecaseLayer возвращает структуру уровня usecase, позволяющую пользователям с правом audit.read читать
+// и проверять журнал аудита.
+func NewUsecaseLayer(uow postgres.UnitOfWork, policy policy.Policy, repoAudit audit.Repo) *UsecaseLayer {
+	return &UsecaseLayer{
+		uow:       uow,
+		policy:    policy,
+		repoAudit: repoAudit,
+	}
+}
+
+// List возвращает страницу журнала аудита, подходящую под фильтр.
+func (u *UsecaseLayer) List(ctx context.Context, filter *dto.AuditFilter) ([]*ent.AuditEntry, error) {
+	if err := u.policy.Authorize(ctx, mc.PermAuditRead); err != nil {
+		return nil, err
+	}
+	return u.repoAudit.List(ctx, filter)
+}
+
+// Verify проверяет цепочку хэшей журнала аудита. Журнал и его последняя запись читаются из одного снимка базы данных,
+// иначе параллельная запись выглядела бы как нарушение цепочки.
+func (u *UsecaseLayer) Verify(ctx context.Context) (*ent.AuditVerification, error) {
+	if err := u.policy.Authorize(ctx, mc.PermAuditRead); err != nil {
+		return nil, err
+	}
+	var v *ent.AuditVerification
+	err := u.uow.Do(ctx, postgres.RepeatableRead, func(ctx context.Context) error {
+		var err error
+		v, err = u.repoAudit.Verify(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
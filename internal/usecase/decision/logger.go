@@ -0,0 +1,127 @@
+package decision
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/repo/decision"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// Logger записывает решения о доступе в журнал решений.
+type Logger interface {
+	Log(ctx context.Context, explanation *ent.AccessExplanation)
+}
+
+var _ Logger = (*LoggerLayer)(nil)
+
+type LoggerLayer struct {
+	repoDecision  decision.Repo
+	queue         chan *ent.AccessDecision
+	sampleRate    float64
+	batchSize     int
+	flushInterval time.Duration
+	dropped       atomic.Int64
+	logger        *zap.Logger
+}
+
+// NewLoggerLayer возвращает журнал решений о доступе. Решения складываются в ограниченный буфер и записываются
+// в базу данных пачками в Run, поэтому проверка доступа не ждет записи. Если буфер заполнен, решение отбрасывается,
+// количество отброшенных решений попадает в лог сервиса при следующей записи пачки.
+func NewLoggerLayer(repoDecision decision.Repo, logger *zap.Logger) *LoggerLayer {
+	return &LoggerLayer{
+		repoDecision:  repoDecision,
+		queue:         make(chan *ent.AccessDecision, max(viper.GetInt("decision_log.buffer_size"), 1)),
+		sampleRate:    viper.GetFloat64("decision_log.sample_rate"),
+		batchSize:     max(viper.GetInt("decision_log.batch_size"), 1),
+		flushInterval: viper.GetDuration("decision_log.flush_interval"),
+		logger:        logger,
+	}
+}
+
+// Log кладет решение в буфер журнала, не блокируя проверку доступа. Отказы записываются всегда, разрешения
+// записываются с вероятностью decision_log.sample_rate.
+func (l *LoggerLayer) Log(ctx context.Context, explanation *ent.AccessExplanation) {
+	if explanation.CanExecute && l.sampleRate < 1 && rand.Float64() >= l.sampleRate {
+		return
+	}
+	d := &ent.AccessDecision{
+		CheckedAt:   time.Now(),
+		User:        explanation.Email,
+		Agent:       explanation.Agent,
+		Action:      explanation.Action,
+		RealIP:      f.GetRealIPFromCtx(ctx),
+		Allowed:     explanation.CanExecute,
+		Decision:    explanation.Decision,
+		FailedCheck: explanation.FailedCheck,
+		RequestID:   f.GetRequestIDFromCtx(ctx),
+	}
+	if principal, err := f.GetCtxPrincipal(ctx); err == nil {
+		d.CallerID, d.Caller = principal.ID, principal.Email
+	}
+	select {
+	case l.queue <- d:
+	default:
+		l.dropped.Add(1)
+	}
+}
+
+// Run записывает решения из буфера пачками по decision_log.batch_size, но не реже раза в decision_log.flush_interval,
+// пока не будет отменен ctx. После отмены записывает оставшиеся в буфере решения и завершается.
+func (l *LoggerLayer) Run(ctx context.Context) {
+	interval := l.flushInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	batch := make([]*ent.AccessDecision, 0, l.batchSize)
+	for {
+		select {
+		case d := <-l.queue:
+			batch = append(batch, d)
+			if len(batch) >= l.batchSize {
+				batch = l.flush(ctx, batch)
+			}
+		case <-ticker.C:
+			batch = l.flush(ctx, batch)
+		case <-ctx.Done():
+			// контекст уже отменен, поэтому оставшиеся решения записываются с отдельным таймаутом
+			flushCtx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("server.shutdown_duration"))
+			defer cancel()
+			for {
+				select {
+				case d := <-l.queue:
+					batch = append(batch, d)
+					if len(batch) >= l.batchSize {
+						batch = l.flush(flushCtx, batch)
+					}
+				default:
+					l.flush(flushCtx, batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush записывает пачку и возвращает пустой срез для следующей. Журнал решений не должен влиять на проверку
+// доступа, поэтому ошибка записи только логируется, а пачка отбрасывается.
+func (l *LoggerLayer) flush(ctx context.Context, batch []*ent.AccessDecision) []*ent.AccessDecision {
+	if dropped := l.dropped.Swap(0); dropped != 0 {
+		l.logger.Warn("access decisions were dropped because the decision log buffer is full", zap.Int64("count", dropped))
+	}
+	if len(batch) == 0 {
+		return batch
+	}
+	if err := l.repoDecision.InsertBatch(ctx, batch); err != nil {
+		l.logger.Error(fmt.Sprintf("error while writing access decisions: %v", err), zap.Int("count", len(batch)))
+	}
+	return batch[:0]
+}
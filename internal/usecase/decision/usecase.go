@@ -0,0 +1,48 @@
+package decision
+
+import (
+	"context"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/internal/repo/decision"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+)
+
+type Usecase interface {
+	List(ctx context.Context, filter *dto.DecisionFilter) ([]*ent.AccessDecision, error)
+	Export(ctx context.Context, filter *dto.DecisionFilter, fn func(d *ent.AccessDecision) error) error
+}
+
+var _ Usecase = (*UsecaseLayer)(nil)
+
+type UsecaseLayer struct {
+	policy       policy.Policy
+	repoDecision decision.Repo
+}
+
+// NewUsecaseLayer возвращает структуру уровня usecase, позволяющую пользователям с правом decision_log.read
+// читать и выгружать журнал решений о доступе.
+func NewUsecaseLayer(policy policy.Policy, repoDecision decision.Repo) *UsecaseLayer {
+	return &UsecaseLayer{
+		policy:       policy,
+		repoDecision: repoDecision,
+	}
+}
+
+// List возвращает страницу журнала решений о доступе, подходящую под фильтр.
+func (u *UsecaseLayer) List(ctx context.Context, filter *dto.DecisionFilter) ([]*ent.AccessDecision, error) {
+	if err := u.policy.Authorize(ctx, mc.PermDecisionLogRead); err != nil {
+		return nil, err
+	}
+	return u.repoDecision.List(ctx, filter)
+}
+
+// Export передает в fn все записи журнала решений о доступе, подходящие под фильтр, от старых к новым.
+func (u *UsecaseLayer) Export(ctx context.Context, filter *dto.DecisionFilter, fn func(d *ent.AccessDecision) error) error {
+	if err := u.policy.Authorize(ctx, mc.PermDecisionLogRead); err != nil {
+		return err
+	}
+	return u.repoDecision.Export(ctx, filter, fn)
+}
@@ -4,43 +4,90 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strconv"
+	"unicode/utf8"
 
 	ent "github.com/cantylv/authorization-service/internal/entity"
 	"github.com/cantylv/authorization-service/internal/entity/dto"
 	"github.com/cantylv/authorization-service/internal/repo/group"
+	"github.com/cantylv/authorization-service/internal/repo/notification"
 	"github.com/cantylv/authorization-service/internal/repo/user"
+	"github.com/cantylv/authorization-service/internal/usecase/audit"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
 	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
 	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/cantylv/authorization-service/services/postgres"
 	"github.com/spf13/viper"
 )
 
 type Usecase interface {
-	AddUserToGroup(ctx context.Context, userEmail, inviteUserEmail, groupName string) (string, error)
-	GetUserGroups(ctx context.Context, userEmail, askUserEmail string) ([]*ent.Group, error)
-	KickUserFromGroup(ctx context.Context, userEmail, kickUserEmail, groupName string) (string, error)
-	MakeRequestToCreateGroup(ctx context.Context, userEmail, groupName string) (*dto.Bid, error)
-	UpdateRequestStatus(ctx context.Context, userEmail, groupName, userChangeStatus, status string) (*dto.Bid, error)
-	ChangeOwner(ctx context.Context, userEmail, groupName, userChangeOwnerEmail string) (*ent.Group, error)
+	AddUserToGroup(ctx context.Context, userEmail, groupName string, period *dto.GrantPeriodData) (string, error)
+	GetUserGroups(ctx context.Context, userEmail string) ([]*ent.Group, error)
+	KickUserFromGroup(ctx context.Context, userEmail, groupName string) (string, error)
+	LeaveGroup(ctx context.Context, groupName string) (string, error)
+	MakeRequestToCreateGroup(ctx context.Context, groupName string) (*dto.Bid, error)
+	UpdateRequestStatus(ctx context.Context, userEmail, groupName, status, comment string) (*dto.Bid, error)
+	GetBids(ctx context.Context, filter *dto.BidFilter) ([]*dto.Bid, error)
+	GetBid(ctx context.Context, bidID int) (*dto.Bid, error)
+	CancelBid(ctx context.Context, bidID int, comment string) (*dto.Bid, error)
+	AttachSubgroup(ctx context.Context, groupName, subgroupName string) error
+	DetachSubgroup(ctx context.Context, groupName, subgroupName string) error
+	GetSubgroups(ctx context.Context, groupName string) ([]*ent.Group, error)
+	ChangeOwner(ctx context.Context, userEmail, groupName string) (*ent.Group, error)
+	AddGroupOwner(ctx context.Context, userEmail, groupName string) error
+	RemoveGroupOwner(ctx context.Context, userEmail, groupName string) error
+	GetMembers(ctx context.Context, groupName string, page *dto.Pagination) ([]*ent.GroupMember, error)
+	RenameGroup(ctx context.Context, groupName, newGroupName string) (*ent.Group, error)
+	DeleteGroup(ctx context.Context, groupName string) error
 }
 
 var _ Usecase = (*UsecaseLayer)(nil)
 
 type UsecaseLayer struct {
-	repoUser  user.Repo
-	repoGroup group.Repo
+	uow              postgres.UnitOfWork
+	policy           policy.Policy
+	audit            audit.Recorder
+	repoUser         user.Repo
+	repoGroup        group.Repo
+	repoNotification notification.Repo
 }
 
-// NewUsecaseLayer возвращает структуру уровня usecase, управляющую группами пользователей
-func NewUsecaseLayer(repoUser user.Repo, repoGroup group.Repo) *UsecaseLayer {
+// NewUsecaseLayer возвращает структуру уровня usecase, управляющую группами пользователей.
+// Изменяющие методы выполняются в одной транзакции через uow, права проверяются политикой доступа,
+// изменения записываются в журнал аудита в той же транзакции.
+func NewUsecaseLayer(uow postgres.UnitOfWork, policy policy.Policy, audit audit.Recorder, repoUser user.Repo,
+	repoGroup group.Repo, repoNotification notification.Repo) *UsecaseLayer {
 	return &UsecaseLayer{
-		repoUser:  repoUser,
-		repoGroup: repoGroup,
+		uow:              uow,
+		policy:           policy,
+		audit:            audit,
+		repoUser:         repoUser,
+		repoGroup:        repoGroup,
+		repoNotification: repoNotification,
 	}
 }
 
-// AddUserToGroup позволяет добавить пользователя в группу. Добавить в группу может только основатель этой группы.
+// AddUserToGroup позволяет добавить пользователя в группу без его согласия. Это может сделать только пользователь
+// с правом group.manage, ответственные за группу приглашают пользователей через приглашения.
+// Участие действует в течение period, пустой period означает бессрочное участие.
 // Метод возвращает название группы, в которую пользоваетель был добавлен и ошибку в случае неудачи.
-func (u *UsecaseLayer) AddUserToGroup(ctx context.Context, userEmail, inviteUserEmail, groupName string) (string, error) {
+func (u *UsecaseLayer) AddUserToGroup(ctx context.Context, userEmail, groupName string, period *dto.GrantPeriodData) (string, error) {
+	var res string
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = u.addUserToGroup(ctx, userEmail, groupName, period)
+		return err
+	})
+	return res, err
+}
+
+func (u *UsecaseLayer) addUserToGroup(ctx context.Context, userEmail, groupName string, period *dto.GrantPeriodData) (string, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return "", err
+	}
+	inviteUserEmail := principal.Email
 	// проверяем, существует ли группа, в которую мы хотим добавить пользователя
 	groupDB, err := u.repoGroup.GetGroup(ctx, groupName)
 	if err != nil {
@@ -69,10 +116,21 @@ func (u *UsecaseLayer) AddUserToGroup(ctx context.Context, userEmail, inviteUser
 		}
 		return "", err
 	}
-	// проверяем, является ли пользователь, который добавляет в группу другого пользователя,
-	// ответственным за нее (создателем другими словами). Также root пользователь может добавить кого угодно в любую группу.
-	if inviteUserEmail != viper.GetString("root_email") && groupDB.OwnerID != uInviter.ID {
-		return "", me.ErrOnlyOwnerCanAddUserToGroup
+	// пользователь с правом group.manage может добавить кого угодно в любую группу, ответственному за группу
+	// нужно согласие пользователя, поэтому он отправляет приглашение
+	canManage, err := u.policy.HasPermission(ctx, mc.PermGroupManage)
+	if err != nil {
+		return "", err
+	}
+	if !canManage {
+		isOwner, err := u.repoGroup.IsGroupOwner(ctx, uInviter.ID, groupDB.ID)
+		if err != nil {
+			return "", err
+		}
+		if isOwner {
+			return "", me.ErrDirectAddRequiresManage
+		}
+		return "", policy.Denied(mc.PermGroupManage)
 	}
 	// проверка на то, есть ли уже пользователь в этой группе
 	isParticipant, err := u.repoGroup.IsParticipantOfGroup(ctx, uDB.ID, groupDB.ID)
@@ -84,15 +142,24 @@ func (u *UsecaseLayer) AddUserToGroup(ctx context.Context, userEmail, inviteUser
 	}
 	// добавляем пользователя | добавление происходит без подтверждения root пользователя
 	// в данном случае мы рассчитываем, что создателям групп можно доверять
-	err = u.repoGroup.AddUserToGroup(ctx, uDB.ID, groupDB.ID)
+	err = u.repoGroup.AddUserToGroup(ctx, uDB.ID, groupDB.ID, period)
 	if err != nil {
 		return "", err
 	}
+	member := &ent.AuditMember{User: uDB.Email, ValidFrom: period.ValidFrom, ValidUntil: period.ValidUntil}
+	if err := u.audit.Record(ctx, mc.AuditGroupMemberAdd, mc.AuditTargetGroup, groupDB.Name, nil, member); err != nil {
+		return "", err
+	}
 	return groupDB.Name, nil
 }
 
 // GetUserGroups возвращает список групп пользователя. Показывает только общие группы с другими пользователями.
-func (u *UsecaseLayer) GetUserGroups(ctx context.Context, userEmail, askUserEmail string) ([]*ent.Group, error) {
+func (u *UsecaseLayer) GetUserGroups(ctx context.Context, userEmail string) ([]*ent.Group, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	askUserEmail := principal.Email
 	// проверяем, существует ли пользователь, чьи группы мы хотим получить
 	uDB, err := u.repoUser.GetByEmail(ctx, userEmail)
 	if err != nil {
@@ -131,8 +198,24 @@ func (u *UsecaseLayer) GetUserGroups(ctx context.Context, userEmail, askUserEmai
 	return groups, nil
 }
 
-// KickUserFromGroup удаляет пользователя из группы
-func (u *UsecaseLayer) KickUserFromGroup(ctx context.Context, userEmail, kickUserEmail, groupName string) (string, error) {
+// KickUserFromGroup удаляет пользователя из группы. Удаленный пользователь получает уведомление, а если пользователь
+// сам покидает группу, то уведомление получают ответственные за группу.
+func (u *UsecaseLayer) KickUserFromGroup(ctx context.Context, userEmail, groupName string) (string, error) {
+	var res string
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = u.kickUserFromGroup(ctx, userEmail, groupName)
+		return err
+	})
+	return res, err
+}
+
+func (u *UsecaseLayer) kickUserFromGroup(ctx context.Context, userEmail, groupName string) (string, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return "", err
+	}
+	kickUserEmail := principal.Email
 	// проверяем, существует ли группа, из которую мы хотим удалить пользователя
 	groupDB, err := u.repoGroup.GetGroup(ctx, groupName)
 	if err != nil {
@@ -161,40 +244,96 @@ func (u *UsecaseLayer) KickUserFromGroup(ctx context.Context, userEmail, kickUse
 		}
 		return "", err
 	}
-	// ограничение: владелец группы не может выйти из беседы, для того чтобы покинуть, необходимо назначить нового владельца
-	if groupDB.OwnerID == uDB.ID {
-		return "", me.ErrOwnerCantExitFromGroup
+	// ограничение: единственный владелец группы не может выйти из беседы, для того чтобы покинуть,
+	// необходимо назначить еще одного владельца
+	isOwner, err := u.repoGroup.IsGroupOwner(ctx, uDB.ID, groupDB.ID)
+	if err != nil {
+		return "", err
+	}
+	if isOwner {
+		owners, err := u.repoGroup.CountOwners(ctx, groupDB.ID)
+		if err != nil {
+			return "", err
+		}
+		if owners <= 1 {
+			return "", me.ErrOwnerCantExitFromGroup
+		}
 	}
+	event := &ent.GroupEvent{Group: groupDB.Name, User: uDB.Email, Actor: kickUserEmail}
 	// проверяем, пользователь сам покидает группу или нет
-	if userEmail == kickUserEmail {
-		err = u.repoGroup.KickUserFromGroup(ctx, uDB.ID, groupDB.ID)
+	if userEmail != kickUserEmail {
+		// проверяем, есть ли пользователь, который собирается удалить пользователя из группы
+		uKicker, err := u.repoUser.GetByEmail(ctx, kickUserEmail)
 		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", me.ErrUserNotExist
+			}
+			return "", err
+		}
+		// пользователя из группы может удалить любой из владельцев группы
+		// проверим, что это так и есть | не забываем, что пользователь с правом group.manage может также удалить
+		if err := u.authorizeOwner(ctx, uKicker.ID, groupDB.ID); err != nil {
 			return "", err
 		}
-		return groupName, nil
 	}
-	// проверяем, есть ли пользователь, который собирается удалить пользователя из группы
-	uKicker, err := u.repoUser.GetByEmail(ctx, kickUserEmail)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return "", me.ErrUserNotExist
+	// покидающий группу владелец перестает быть ответственным за нее
+	if isOwner {
+		err = u.repoGroup.RemoveOwner(ctx, groupDB.ID, uDB.ID)
+		if err != nil {
+			return "", err
 		}
+		err = u.audit.Record(ctx, mc.AuditGroupOwnerRemove, mc.AuditTargetGroup, groupDB.Name, &ent.AuditMember{User: uDB.Email}, nil)
+		if err != nil {
+			return "", err
+		}
+	}
+	err = u.repoGroup.KickUserFromGroup(ctx, uDB.ID, groupDB.ID)
+	if err != nil {
+		return "", err
+	}
+	err = u.audit.Record(ctx, mc.AuditGroupMemberRemove, mc.AuditTargetGroup, groupDB.Name, &ent.AuditMember{User: uDB.Email}, nil)
+	if err != nil {
 		return "", err
 	}
-	// пользователя из группы может удалить только владелец группы
-	// проверим, что это так и есть | не забываем, что root пользователь может также удалить
-	if kickUserEmail != viper.GetString("root_email") && uKicker.ID != groupDB.OwnerID {
-		return "", me.ErrOnlyOwnerCanDeleteUserFromGroup
+	if userEmail == kickUserEmail {
+		err = u.repoNotification.NotifyGroupOwners(ctx, groupDB.ID, mc.EventMemberLeft, event)
+	} else {
+		err = u.repoNotification.Notify(ctx, []string{uDB.ID}, mc.EventMemberKicked, event)
 	}
-	err = u.repoGroup.KickUserFromGroup(ctx, uDB.ID, groupDB.ID)
 	if err != nil {
 		return "", err
 	}
 	return groupName, nil
 }
 
-// MakeRequestToCreateGroup создает заявку на создание группы, статус заявки "in_progress"
-func (u *UsecaseLayer) MakeRequestToCreateGroup(ctx context.Context, userEmail, groupName string) (*dto.Bid, error) {
+// LeaveGroup позволяет аутентифицированному пользователю самому покинуть группу. Единственный ответственный
+// за группу не может ее покинуть, пока не назначит другого ответственного.
+func (u *UsecaseLayer) LeaveGroup(ctx context.Context, groupName string) (string, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return "", err
+	}
+	return u.KickUserFromGroup(ctx, principal.Email, groupName)
+}
+
+// MakeRequestToCreateGroup создает заявку на создание группы от имени аутентифицированного пользователя,
+// статус заявки "in_progress"
+func (u *UsecaseLayer) MakeRequestToCreateGroup(ctx context.Context, groupName string) (*dto.Bid, error) {
+	var res *dto.Bid
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = u.makeRequestToCreateGroup(ctx, groupName)
+		return err
+	})
+	return res, err
+}
+
+func (u *UsecaseLayer) makeRequestToCreateGroup(ctx context.Context, groupName string) (*dto.Bid, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userEmail := principal.Email
 	// проверяем, существует ли пользователь, который подает заявку на создание группы
 	uDB, err := u.repoUser.GetByEmail(ctx, userEmail)
 	if err != nil {
@@ -219,12 +358,31 @@ func (u *UsecaseLayer) MakeRequestToCreateGroup(ctx context.Context, userEmail,
 	if bidDB != nil {
 		return nil, me.ErrBidAlreadyExist
 	}
-	// если запрос на создание делает root, то мы не добавляем заявку в таблицу, а сразу создаем группу
-	if uDB.Email == viper.GetString("root_email") {
+	// если запрос на создание делает пользователь с правом group.create, то мы не добавляем заявку в таблицу,
+	// а сразу создаем группу
+	canCreate, err := u.policy.HasPermission(ctx, mc.PermGroupCreate)
+	if err != nil {
+		return nil, err
+	}
+	if canCreate {
 		groupNew, err := u.repoGroup.CreateGroup(ctx, uDB.ID, groupName)
 		if err != nil {
 			return nil, err
 		}
+		// root пользователь присутствует во всех группах
+		if uDB.Email != viper.GetString("root_email") {
+			userRoot, err := u.repoUser.GetByEmail(ctx, viper.GetString("root_email"))
+			if err != nil {
+				return nil, err
+			}
+			err = u.repoGroup.AddUserToGroup(ctx, userRoot.ID, groupNew.ID, &dto.GrantPeriodData{})
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := u.audit.Record(ctx, mc.AuditGroupCreate, mc.AuditTargetGroup, groupNew.Name, nil, groupNew); err != nil {
+			return nil, err
+		}
 		return newBidFromExistingGroup(groupNew), nil
 	}
 	// создаем заявку
@@ -232,17 +390,35 @@ func (u *UsecaseLayer) MakeRequestToCreateGroup(ctx context.Context, userEmail,
 	if err != nil {
 		return nil, err
 	}
+	if err := u.audit.Record(ctx, mc.AuditBidCreate, mc.AuditTargetBid, strconv.Itoa(bid.ID), nil, bid); err != nil {
+		return nil, err
+	}
 	return bid, nil
 }
 
-func (u *UsecaseLayer) UpdateRequestStatus(ctx context.Context, userEmail, groupName, userChangeStatus, status string) (*dto.Bid, error) {
+// UpdateRequestStatus подтверждает или отклоняет заявку пользователя на создание группы. Доступно пользователям
+// с правом group.approve_bid. Рассмотревший заявку пользователь и комментарий сохраняются в заявке и ее истории.
+func (u *UsecaseLayer) UpdateRequestStatus(ctx context.Context, userEmail, groupName, status, comment string) (*dto.Bid, error) {
+	var res *dto.Bid
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = u.updateRequestStatus(ctx, userEmail, groupName, status, comment)
+		return err
+	})
+	return res, err
+}
+
+func (u *UsecaseLayer) updateRequestStatus(ctx context.Context, userEmail, groupName, status, comment string) (*dto.Bid, error) {
 	// проверим, что статус имеет допустимое значение
 	if _, ok := mc.AllowedStatus[status]; !ok {
 		return nil, me.ErrInvalidStatus
 	}
-	// проверяем, root это или нет
-	if viper.GetString("root_email") != userChangeStatus {
-		return nil, me.ErrOnlyRootCanChangeBidStatus
+	if err := u.policy.Authorize(ctx, mc.PermGroupApproveBid); err != nil {
+		return nil, err
+	}
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
 	}
 	// нужно получить id пользователя, который хочет создать новую группу (быть ее ответственным)
 	uDB, err := u.repoUser.GetByEmail(ctx, userEmail)
@@ -257,14 +433,14 @@ func (u *UsecaseLayer) UpdateRequestStatus(ctx context.Context, userEmail, group
 		}
 		return nil, err
 	}
-	// если root пользователь отказывает в создании группы, то нам нет смысла
+	// если в создании группы отказано, то нам нет смысла
 	// узнавать, есть ли такая группа уже
-	if status == "rejected" {
-		b, err := u.repoGroup.RejectGroupCreation(ctx, bidDB.ID)
+	if status == mc.BidRejected {
+		err = u.setBidStatus(ctx, bidDB.ID, status, principal.ID, comment)
 		if err != nil {
 			return nil, err
 		}
-		return b, nil
+		return u.recordBid(ctx, mc.AuditBidReview, bidDB)
 	}
 	// проверяем, что в существующих группах нет такого же имени
 	groupDB, err := u.repoGroup.GetGroup(ctx, groupName)
@@ -274,7 +450,7 @@ func (u *UsecaseLayer) UpdateRequestStatus(ctx context.Context, userEmail, group
 	if groupDB != nil {
 		return nil, me.ErrGroupAlreadyExist
 	}
-	// нужно получить id root пользователя
+	// нужно получить id root пользователя, он присутствует во всех группах
 	userRoot, err := u.repoUser.GetByEmail(ctx, viper.GetString("root_email"))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -282,14 +458,141 @@ func (u *UsecaseLayer) UpdateRequestStatus(ctx context.Context, userEmail, group
 		}
 		return nil, err
 	}
-	g, err := u.repoGroup.ApproveGroupCreation(ctx, bidDB.UserId, userRoot.ID, groupName)
+	err = u.setBidStatus(ctx, bidDB.ID, status, principal.ID, comment)
 	if err != nil {
 		return nil, err
 	}
-	return newBidFromExistingGroup(g), nil
+	groupNew, err := u.repoGroup.ApproveGroupCreation(ctx, bidDB.UserId, userRoot.ID, groupName)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.audit.Record(ctx, mc.AuditGroupCreate, mc.AuditTargetGroup, groupNew.Name, nil, groupNew); err != nil {
+		return nil, err
+	}
+	return u.recordBid(ctx, mc.AuditBidReview, bidDB)
 }
 
-func (u *UsecaseLayer) ChangeOwner(ctx context.Context, userEmail, groupName, userChangeOwnerEmail string) (*ent.Group, error) {
+// GetBids возвращает страницу заявок на создание группы. Пользователю с правом group.approve_bid видны заявки
+// всех пользователей, остальным только свои.
+func (u *UsecaseLayer) GetBids(ctx context.Context, filter *dto.BidFilter) ([]*dto.Bid, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	canReview, err := u.policy.HasPermission(ctx, mc.PermGroupApproveBid)
+	if err != nil {
+		return nil, err
+	}
+	var viewerID *string
+	if !canReview {
+		viewerID = &principal.ID
+	}
+	return u.repoGroup.ListBids(ctx, filter, viewerID)
+}
+
+// GetBid возвращает заявку на создание группы вместе с историей ее статусов. Заявку видит ее автор
+// и пользователь с правом group.approve_bid.
+func (u *UsecaseLayer) GetBid(ctx context.Context, bidID int) (*dto.Bid, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bidDB, err := u.readBid(ctx, bidID)
+	if err != nil {
+		return nil, err
+	}
+	if bidDB.UserId != principal.ID {
+		if err := u.policy.Authorize(ctx, mc.PermGroupApproveBid); err != nil {
+			return nil, err
+		}
+	}
+	bidDB.History, err = u.repoGroup.GetBidHistory(ctx, bidID)
+	if err != nil {
+		return nil, err
+	}
+	return bidDB, nil
+}
+
+// CancelBid отзывает нерассмотренную заявку на создание группы. Отозвать заявку может только ее автор.
+func (u *UsecaseLayer) CancelBid(ctx context.Context, bidID int, comment string) (*dto.Bid, error) {
+	var res *dto.Bid
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = u.cancelBid(ctx, bidID, comment)
+		return err
+	})
+	return res, err
+}
+
+func (u *UsecaseLayer) cancelBid(ctx context.Context, bidID int, comment string) (*dto.Bid, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bidDB, err := u.readBid(ctx, bidID)
+	if err != nil {
+		return nil, err
+	}
+	if bidDB.UserId != principal.ID {
+		return nil, me.ErrOnlyBidAuthorCanCancel
+	}
+	err = u.setBidStatus(ctx, bidID, mc.BidCancelled, principal.ID, comment)
+	if err != nil {
+		return nil, err
+	}
+	return u.recordBid(ctx, mc.AuditBidCancel, bidDB)
+}
+
+func (u *UsecaseLayer) readBid(ctx context.Context, bidID int) (*dto.Bid, error) {
+	bidDB, err := u.repoGroup.GetBidByID(ctx, bidID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrBidNotExist
+		}
+		return nil, err
+	}
+	return bidDB, nil
+}
+
+// recordBid перечитывает заявку после смены статуса и записывает смену статуса в журнал аудита.
+func (u *UsecaseLayer) recordBid(ctx context.Context, action string, before *dto.Bid) (*dto.Bid, error) {
+	after, err := u.repoGroup.GetBidByID(ctx, before.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.audit.Record(ctx, action, mc.AuditTargetBid, strconv.Itoa(before.ID), before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+func (u *UsecaseLayer) setBidStatus(ctx context.Context, bidID int, status, actorID, comment string) error {
+	err := u.repoGroup.SetBidStatus(ctx, bidID, status, actorID, comment)
+	if errors.Is(err, me.ErrNoRowsAffected) {
+		return me.ErrBidIsReviewed
+	}
+	return err
+}
+
+// ChangeOwner назначает нового основного ответственного за группу, прежний основной ответственный перестает быть
+// ответственным, совладельцы группы остаются. Назначить может любой из ответственных или пользователь с правом
+// group.manage.
+func (u *UsecaseLayer) ChangeOwner(ctx context.Context, userEmail, groupName string) (*ent.Group, error) {
+	var res *ent.Group
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = u.changeOwner(ctx, userEmail, groupName)
+		return err
+	})
+	return res, err
+}
+
+func (u *UsecaseLayer) changeOwner(ctx context.Context, userEmail, groupName string) (*ent.Group, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userChangeOwnerEmail := principal.Email
 	// проверим существование группы
 	groupDB, err := u.repoGroup.GetGroup(ctx, groupName)
 	if err != nil {
@@ -312,9 +615,13 @@ func (u *UsecaseLayer) ChangeOwner(ctx context.Context, userEmail, groupName, us
 	if groupName == "users" {
 		return nil, me.ErrOnlyRootCanBeOwnerOfUsersGroup
 	}
-	// root пользователь может менять ответственного любой группы
-	if userChangeOwnerEmail == viper.GetString("root_email") {
-		return u.repoGroup.UpdateOwner(ctx, groupDB.ID, userNewOwner.ID)
+	// пользователь с правом group.manage может менять ответственного любой группы
+	canManage, err := u.policy.HasPermission(ctx, mc.PermGroupManage)
+	if err != nil {
+		return nil, err
+	}
+	if canManage {
+		return u.updateOwner(ctx, groupDB, userNewOwner.ID)
 	}
 	userOldOwner, err := u.repoUser.GetByEmail(ctx, userChangeOwnerEmail)
 	if err != nil {
@@ -327,9 +634,377 @@ func (u *UsecaseLayer) ChangeOwner(ctx context.Context, userEmail, groupName, us
 	_, err = u.repoGroup.IsOwnerOfGroup(ctx, userOldOwner.ID, groupName)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, me.ErrOnlyOwnerCanAppointNewOwner
+			return nil, policy.Denied(mc.PermGroupManage)
+		}
+		return nil, err
+	}
+	return u.updateOwner(ctx, groupDB, userNewOwner.ID)
+}
+
+// updateOwner назначает основного ответственного за группу и записывает смену в журнал аудита.
+func (u *UsecaseLayer) updateOwner(ctx context.Context, groupDB *ent.Group, newOwnerID string) (*ent.Group, error) {
+	groupNew, err := u.repoGroup.UpdateOwner(ctx, groupDB.ID, newOwnerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.audit.Record(ctx, mc.AuditGroupOwnerChange, mc.AuditTargetGroup, groupDB.Name, groupDB, groupNew); err != nil {
+		return nil, err
+	}
+	return groupNew, nil
+}
+
+// AttachSubgroup делает группу subgroupName подгруппой groupName, после чего участники подгруппы наследуют
+// привелегии и запреты groupName и всех ее родительских групп. Привязать может пользователь, ответственный
+// за обе группы, или пользователь с правом group.manage. У группы может быть только одна родительская группа,
+// циклы в иерархии запрещены.
+func (u *UsecaseLayer) AttachSubgroup(ctx context.Context, groupName, subgroupName string) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.attachSubgroup(ctx, groupName, subgroupName)
+	})
+}
+
+func (u *UsecaseLayer) attachSubgroup(ctx context.Context, groupName, subgroupName string) error {
+	parent, child, err := u.readGroupPair(ctx, groupName, subgroupName)
+	if err != nil {
+		return err
+	}
+	if parent.ID == child.ID {
+		return me.ErrGroupCycle
+	}
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return err
+	}
+	ownsParent, ownsChild, err := u.ownsGroupPair(ctx, principal.ID, parent, child)
+	if err != nil {
+		return err
+	}
+	// подгруппа получает права родительской группы, поэтому нужно согласие ответственных за обе группы
+	if !ownsParent || !ownsChild {
+		if err := u.policy.Authorize(ctx, mc.PermGroupManage); err != nil {
+			return err
+		}
+	}
+	if child.ParentID != nil {
+		if *child.ParentID == parent.ID {
+			return me.ErrSubgroupAlreadyAttached
+		}
+		return me.ErrGroupAlreadyHasParent
+	}
+	// родительская группа не может находиться внутри подгруппы
+	isCycle, err := u.repoGroup.IsAncestor(ctx, parent.ID, child.ID)
+	if err != nil {
+		return err
+	}
+	if isCycle {
+		return me.ErrGroupCycle
+	}
+	if err := u.repoGroup.SetParent(ctx, child.ID, &parent.ID); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, mc.AuditGroupSubgroupAttach, mc.AuditTargetGroup, child.Name, nil, &ent.AuditParent{Parent: parent.Name})
+}
+
+// DetachSubgroup отвязывает подгруппу subgroupName от группы groupName. Отвязать может ответственный за любую
+// из двух групп или пользователь с правом group.manage.
+func (u *UsecaseLayer) DetachSubgroup(ctx context.Context, groupName, subgroupName string) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.detachSubgroup(ctx, groupName, subgroupName)
+	})
+}
+
+func (u *UsecaseLayer) detachSubgroup(ctx context.Context, groupName, subgroupName string) error {
+	parent, child, err := u.readGroupPair(ctx, groupName, subgroupName)
+	if err != nil {
+		return err
+	}
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return err
+	}
+	ownsParent, ownsChild, err := u.ownsGroupPair(ctx, principal.ID, parent, child)
+	if err != nil {
+		return err
+	}
+	if !ownsParent && !ownsChild {
+		if err := u.policy.Authorize(ctx, mc.PermGroupManage); err != nil {
+			return err
+		}
+	}
+	if child.ParentID == nil || *child.ParentID != parent.ID {
+		return me.ErrSubgroupNotAttached
+	}
+	if err := u.repoGroup.SetParent(ctx, child.ID, nil); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, mc.AuditGroupSubgroupDetach, mc.AuditTargetGroup, child.Name, &ent.AuditParent{Parent: parent.Name}, nil)
+}
+
+// GetSubgroups возвращает подгруппы первого уровня. Список доступен участникам и ответственным за группу,
+// а также пользователю с правом group.manage.
+func (u *UsecaseLayer) GetSubgroups(ctx context.Context, groupName string) ([]*ent.Group, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groupDB, err := u.repoGroup.GetGroup(ctx, groupName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrGroupNotExist
 		}
 		return nil, err
 	}
-	return u.repoGroup.UpdateOwner(ctx, groupDB.ID, userNewOwner.ID)
+	if err := u.authorizeMember(ctx, principal.ID, groupDB.ID); err != nil {
+		return nil, err
+	}
+	return u.repoGroup.GetSubgroups(ctx, groupDB.ID)
+}
+
+func (u *UsecaseLayer) readGroupPair(ctx context.Context, groupName, subgroupName string) (*ent.Group, *ent.Group, error) {
+	parent, err := u.repoGroup.GetGroup(ctx, groupName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, me.ErrGroupNotExist
+		}
+		return nil, nil, err
+	}
+	child, err := u.repoGroup.GetGroup(ctx, subgroupName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, me.ErrGroupNotExist
+		}
+		return nil, nil, err
+	}
+	return parent, child, nil
+}
+
+func (u *UsecaseLayer) ownsGroupPair(ctx context.Context, userID string, parent, child *ent.Group) (bool, bool, error) {
+	ownsParent, err := u.repoGroup.IsGroupOwner(ctx, userID, parent.ID)
+	if err != nil {
+		return false, false, err
+	}
+	ownsChild, err := u.repoGroup.IsGroupOwner(ctx, userID, child.ID)
+	if err != nil {
+		return false, false, err
+	}
+	return ownsParent, ownsChild, nil
+}
+
+// authorizeOwner пропускает ответственных за группу и пользователей с правом group.manage
+func (u *UsecaseLayer) authorizeOwner(ctx context.Context, userID string, groupID int) error {
+	isOwner, err := u.repoGroup.IsGroupOwner(ctx, userID, groupID)
+	if err != nil {
+		return err
+	}
+	if !isOwner {
+		return u.policy.Authorize(ctx, mc.PermGroupManage)
+	}
+	return nil
+}
+
+// authorizeMember пропускает участников и ответственных за группу, а также пользователей с правом group.manage
+func (u *UsecaseLayer) authorizeMember(ctx context.Context, userID string, groupID int) error {
+	isParticipant, err := u.repoGroup.IsParticipantOfGroup(ctx, userID, groupID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if isParticipant {
+		return nil
+	}
+	return u.authorizeOwner(ctx, userID, groupID)
+}
+
+func (u *UsecaseLayer) readGroup(ctx context.Context, groupName string) (*ent.Group, error) {
+	groupDB, err := u.repoGroup.GetGroup(ctx, groupName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrGroupNotExist
+		}
+		return nil, err
+	}
+	return groupDB, nil
+}
+
+// AddGroupOwner делает пользователя совладельцем группы, пользователь добавляется в группу бессрочно. Назначить
+// совладельца может любой из ответственных за группу или пользователь с правом group.manage.
+func (u *UsecaseLayer) AddGroupOwner(ctx context.Context, userEmail, groupName string) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.addGroupOwner(ctx, userEmail, groupName)
+	})
+}
+
+func (u *UsecaseLayer) addGroupOwner(ctx context.Context, userEmail, groupName string) error {
+	// ответственным за группу users может быть только root пользователь
+	if groupName == "users" {
+		return me.ErrUsersGroupIsFixed
+	}
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return err
+	}
+	groupDB, err := u.readGroup(ctx, groupName)
+	if err != nil {
+		return err
+	}
+	if err := u.authorizeOwner(ctx, principal.ID, groupDB.ID); err != nil {
+		return err
+	}
+	uDB, err := u.repoUser.GetByEmail(ctx, userEmail)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return me.ErrUserNotExist
+		}
+		return err
+	}
+	added, err := u.repoGroup.AddOwner(ctx, groupDB.ID, uDB.ID)
+	if err != nil {
+		return err
+	}
+	if !added {
+		return me.ErrUserIsAlreadyOwner
+	}
+	return u.audit.Record(ctx, mc.AuditGroupOwnerAdd, mc.AuditTargetGroup, groupDB.Name, nil, &ent.AuditMember{User: uDB.Email})
+}
+
+// RemoveGroupOwner снимает с пользователя ответственность за группу, участником группы он остается. У группы
+// должен остаться хотя бы один ответственный. Снять может любой из ответственных за группу или пользователь
+// с правом group.manage.
+func (u *UsecaseLayer) RemoveGroupOwner(ctx context.Context, userEmail, groupName string) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.removeGroupOwner(ctx, userEmail, groupName)
+	})
+}
+
+func (u *UsecaseLayer) removeGroupOwner(ctx context.Context, userEmail, groupName string) error {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return err
+	}
+	groupDB, err := u.readGroup(ctx, groupName)
+	if err != nil {
+		return err
+	}
+	if err := u.authorizeOwner(ctx, principal.ID, groupDB.ID); err != nil {
+		return err
+	}
+	uDB, err := u.repoUser.GetByEmail(ctx, userEmail)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return me.ErrUserNotExist
+		}
+		return err
+	}
+	isOwner, err := u.repoGroup.IsGroupOwner(ctx, uDB.ID, groupDB.ID)
+	if err != nil {
+		return err
+	}
+	if !isOwner {
+		return me.ErrUserIsNotOwner
+	}
+	owners, err := u.repoGroup.CountOwners(ctx, groupDB.ID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return me.ErrLastGroupOwner
+	}
+	if err := u.repoGroup.RemoveOwner(ctx, groupDB.ID, uDB.ID); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, mc.AuditGroupOwnerRemove, mc.AuditTargetGroup, groupDB.Name, &ent.AuditMember{User: uDB.Email}, nil)
+}
+
+// GetMembers возвращает страницу участников группы. Список доступен участникам и ответственным за группу,
+// а также пользователю с правом group.manage.
+func (u *UsecaseLayer) GetMembers(ctx context.Context, groupName string, page *dto.Pagination) ([]*ent.GroupMember, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groupDB, err := u.readGroup(ctx, groupName)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.authorizeMember(ctx, principal.ID, groupDB.ID); err != nil {
+		return nil, err
+	}
+	return u.repoGroup.GetMembers(ctx, groupDB.ID, page)
+}
+
+// RenameGroup меняет название группы. Переименовать может любой из ответственных за группу или пользователь
+// с правом group.manage, системную группу users переименовать нельзя.
+func (u *UsecaseLayer) RenameGroup(ctx context.Context, groupName, newGroupName string) (*ent.Group, error) {
+	var res *ent.Group
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = u.renameGroup(ctx, groupName, newGroupName)
+		return err
+	})
+	return res, err
+}
+
+func (u *UsecaseLayer) renameGroup(ctx context.Context, groupName, newGroupName string) (*ent.Group, error) {
+	if l := utf8.RuneCountInString(newGroupName); l < 2 || l > 30 {
+		return nil, me.ErrInvalidGroupName
+	}
+	if groupName == "users" {
+		return nil, me.ErrUsersGroupIsFixed
+	}
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groupDB, err := u.readGroup(ctx, groupName)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.authorizeOwner(ctx, principal.ID, groupDB.ID); err != nil {
+		return nil, err
+	}
+	// проверяем, что в существующих группах нет такого же имени
+	groupExisting, err := u.repoGroup.GetGroup(ctx, newGroupName)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if groupExisting != nil {
+		return nil, me.ErrGroupAlreadyExist
+	}
+	groupNew, err := u.repoGroup.RenameGroup(ctx, groupDB.ID, newGroupName)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.audit.Record(ctx, mc.AuditGroupRename, mc.AuditTargetGroup, groupDB.Name, groupDB, groupNew); err != nil {
+		return nil, err
+	}
+	return groupNew, nil
+}
+
+// DeleteGroup удаляет группу вместе с участием в ней, ее привелегиями, запретами и ролями. Подгруппы удаляемой
+// группы становятся группами верхнего уровня. Удалить может любой из ответственных за группу или пользователь
+// с правом group.manage, системную группу users удалить нельзя.
+func (u *UsecaseLayer) DeleteGroup(ctx context.Context, groupName string) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.deleteGroup(ctx, groupName)
+	})
+}
+
+func (u *UsecaseLayer) deleteGroup(ctx context.Context, groupName string) error {
+	if groupName == "users" {
+		return me.ErrUsersGroupIsFixed
+	}
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return err
+	}
+	groupDB, err := u.readGroup(ctx, groupName)
+	if err != nil {
+		return err
+	}
+	if err := u.authorizeOwner(ctx, principal.ID, groupDB.ID); err != nil {
+		return err
+	}
+	if err := u.repoGroup.DeleteGroup(ctx, groupDB.ID); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, mc.AuditGroupDelete, mc.AuditTargetGroup, groupDB.Name, groupDB, nil)
 }
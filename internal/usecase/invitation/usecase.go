@@ -0,0 +1,315 @@
+package invitation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/internal/repo/group"
+	"github.com/cantylv/authorization-service/internal/repo/invitation"
+	"github.com/cantylv/authorization-service/internal/repo/notification"
+	"github.com/cantylv/authorization-service/internal/repo/user"
+	"github.com/cantylv/authorization-service/internal/usecase/audit"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/cantylv/authorization-service/services/postgres"
+	"github.com/spf13/viper"
+)
+
+type Usecase interface {
+	Create(ctx context.Context, groupName, userEmail string, period *dto.GrantPeriodData) (*ent.Invitation, error)
+	List(ctx context.Context, filter *dto.InvitationFilter) ([]*ent.Invitation, error)
+	Accept(ctx context.Context, id int) (*ent.Invitation, error)
+	Decline(ctx context.Context, id int) (*ent.Invitation, error)
+	Revoke(ctx context.Context, id int) (*ent.Invitation, error)
+}
+
+var _ Usecase = (*UsecaseLayer)(nil)
+
+type UsecaseLayer struct {
+	uow              postgres.UnitOfWork
+	policy           policy.Policy
+	audit            audit.Recorder
+	repoInvitation   invitation.Repo
+	repoGroup        group.Repo
+	repoUser         user.Repo
+	repoNotification notification.Repo
+}
+
+// NewUsecaseLayer возвращает структуру уровня usecase, управляющую приглашениями в группы. Пригласить может
+// ответственный за группу или пользователь с правом group.manage, а участником группы пользователь становится
+// только после принятия приглашения. О каждом шаге участники получают уведомления, а вступление в группу
+// записывается в журнал аудита.
+func NewUsecaseLayer(uow postgres.UnitOfWork, policy policy.Policy, audit audit.Recorder, repoInvitation invitation.Repo,
+	repoGroup group.Repo, repoUser user.Repo, repoNotification notification.Repo) *UsecaseLayer {
+	return &UsecaseLayer{
+		uow:              uow,
+		policy:           policy,
+		audit:            audit,
+		repoInvitation:   repoInvitation,
+		repoGroup:        repoGroup,
+		repoUser:         repoUser,
+		repoNotification: repoNotification,
+	}
+}
+
+// Create приглашает пользователя в группу. Участие начнет действовать после принятия приглашения и продлится
+// в течение period, пустой period означает бессрочное участие. Приглашение истекает через invitation.ttl,
+// но не позже окончания срока участия.
+func (u *UsecaseLayer) Create(ctx context.Context, groupName, userEmail string, period *dto.GrantPeriodData) (*ent.Invitation, error) {
+	var res *ent.Invitation
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = u.create(ctx, groupName, userEmail, period)
+		return err
+	})
+	return res, err
+}
+
+func (u *UsecaseLayer) create(ctx context.Context, groupName, userEmail string, period *dto.GrantPeriodData) (*ent.Invitation, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	g, err := u.repoGroup.GetGroup(ctx, groupName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrGroupNotExist
+		}
+		return nil, err
+	}
+	if err := u.authorizeOwner(ctx, principal.ID, g.ID); err != nil {
+		return nil, err
+	}
+	if userEmail == principal.Email {
+		return nil, me.ErrUserEmailMustBeDiff
+	}
+	uDB, err := u.repoUser.GetByEmail(ctx, userEmail)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrUserNotExist
+		}
+		return nil, err
+	}
+	isParticipant, err := u.repoGroup.IsParticipantOfGroup(ctx, uDB.ID, g.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if isParticipant {
+		return nil, me.ErrUserAlreadyInGroup
+	}
+	// принимать приглашение после окончания срока участия бессмысленно
+	expiresAt := time.Now().Add(viper.GetDuration("invitation.ttl"))
+	if period.ValidUntil != nil && period.ValidUntil.Before(expiresAt) {
+		expiresAt = *period.ValidUntil
+	}
+	id, err := u.repoInvitation.Create(ctx, g.ID, uDB.ID, principal.ID, period, expiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrInvitationAlreadyExist
+		}
+		return nil, err
+	}
+	err = u.repoNotification.Notify(ctx, []string{uDB.ID}, mc.EventInvitationCreated,
+		&ent.GroupEvent{Group: g.Name, User: uDB.Email, Actor: principal.Email, InvitationID: id})
+	if err != nil {
+		return nil, err
+	}
+	return u.repoInvitation.Get(ctx, id)
+}
+
+// List возвращает страницу приглашений, подходящих под фильтр. Пользователь с правом group.manage видит все
+// приглашения, остальные только свои и приглашения в группы, за которые отвечают.
+func (u *UsecaseLayer) List(ctx context.Context, filter *dto.InvitationFilter) ([]*ent.Invitation, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	canManage, err := u.policy.HasPermission(ctx, mc.PermGroupManage)
+	if err != nil {
+		return nil, err
+	}
+	var viewerID *string
+	if !canManage {
+		viewerID = &principal.ID
+	}
+	return u.repoInvitation.List(ctx, filter, viewerID)
+}
+
+// Accept принимает приглашение, после чего пользователь становится участником группы на указанный в приглашении срок.
+// Принять приглашение может только приглашенный пользователь.
+func (u *UsecaseLayer) Accept(ctx context.Context, id int) (*ent.Invitation, error) {
+	var res *ent.Invitation
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = u.accept(ctx, id)
+		return err
+	})
+	return res, err
+}
+
+func (u *UsecaseLayer) accept(ctx context.Context, id int) (*ent.Invitation, error) {
+	inv, err := u.readForResponse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	isParticipant, err := u.repoGroup.IsParticipantOfGroup(ctx, inv.UserID, inv.GroupID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if isParticipant {
+		return nil, me.ErrUserAlreadyInGroup
+	}
+	err = u.setStatus(ctx, id, mc.InvitationAccepted)
+	if err != nil {
+		return nil, err
+	}
+	err = u.repoGroup.AddUserToGroup(ctx, inv.UserID, inv.GroupID,
+		&dto.GrantPeriodData{ValidFrom: inv.ValidFrom, ValidUntil: inv.ValidUntil})
+	if err != nil {
+		return nil, err
+	}
+	member := &ent.AuditMember{User: inv.Email, ValidFrom: inv.ValidFrom, ValidUntil: inv.ValidUntil}
+	if err := u.audit.Record(ctx, mc.AuditGroupMemberAdd, mc.AuditTargetGroup, inv.Group, nil, member); err != nil {
+		return nil, err
+	}
+	err = u.notifyGroup(ctx, inv, mc.EventInvitationAccepted)
+	if err != nil {
+		return nil, err
+	}
+	return u.repoInvitation.Get(ctx, id)
+}
+
+// Decline отклоняет приглашение. Отклонить приглашение может только приглашенный пользователь.
+func (u *UsecaseLayer) Decline(ctx context.Context, id int) (*ent.Invitation, error) {
+	var res *ent.Invitation
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		inv, err := u.readForResponse(ctx, id)
+		if err != nil {
+			return err
+		}
+		err = u.setStatus(ctx, id, mc.InvitationDeclined)
+		if err != nil {
+			return err
+		}
+		err = u.notifyGroup(ctx, inv, mc.EventInvitationDeclined)
+		if err != nil {
+			return err
+		}
+		res, err = u.repoInvitation.Get(ctx, id)
+		return err
+	})
+	return res, err
+}
+
+// Revoke отзывает приглашение, на которое еще не ответили. Отозвать может пригласивший пользователь, ответственный
+// за группу или пользователь с правом group.manage.
+func (u *UsecaseLayer) Revoke(ctx context.Context, id int) (*ent.Invitation, error) {
+	var res *ent.Invitation
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		principal, err := f.GetCtxPrincipal(ctx)
+		if err != nil {
+			return err
+		}
+		inv, err := u.read(ctx, id)
+		if err != nil {
+			return err
+		}
+		if inv.InviterID == nil || *inv.InviterID != principal.ID {
+			if err := u.authorizeOwner(ctx, principal.ID, inv.GroupID); err != nil {
+				return err
+			}
+		}
+		err = u.setStatus(ctx, id, mc.InvitationRevoked)
+		if err != nil {
+			return err
+		}
+		err = u.repoNotification.Notify(ctx, []string{inv.UserID}, mc.EventInvitationRevoked,
+			&ent.GroupEvent{Group: inv.Group, User: inv.Email, Actor: principal.Email, InvitationID: inv.ID})
+		if err != nil {
+			return err
+		}
+		res, err = u.repoInvitation.Get(ctx, id)
+		return err
+	})
+	return res, err
+}
+
+func (u *UsecaseLayer) read(ctx context.Context, id int) (*ent.Invitation, error) {
+	inv, err := u.repoInvitation.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrInvitationNotExist
+		}
+		return nil, err
+	}
+	return inv, nil
+}
+
+// readForResponse возвращает ожидающее ответа приглашение, если аутентифицированный пользователь является приглашенным.
+func (u *UsecaseLayer) readForResponse(ctx context.Context, id int) (*ent.Invitation, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inv, err := u.read(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if inv.UserID != principal.ID {
+		return nil, me.ErrOnlyInviteeCanRespond
+	}
+	if inv.Status != mc.InvitationPending {
+		return nil, me.ErrInvitationIsResolved
+	}
+	return inv, nil
+}
+
+func (u *UsecaseLayer) setStatus(ctx context.Context, id int, status string) error {
+	err := u.repoInvitation.SetStatus(ctx, id, status)
+	if err != nil {
+		if errors.Is(err, me.ErrNoRowsAffected) {
+			return me.ErrInvitationIsResolved
+		}
+		return err
+	}
+	return nil
+}
+
+// notifyGroup уведомляет об ответе на приглашение ответственных за группу, а также пригласившего пользователя,
+// если он не является ответственным
+func (u *UsecaseLayer) notifyGroup(ctx context.Context, inv *ent.Invitation, event string) error {
+	payload := &ent.GroupEvent{Group: inv.Group, User: inv.Email, Actor: inv.Email, InvitationID: inv.ID}
+	err := u.repoNotification.NotifyGroupOwners(ctx, inv.GroupID, event, payload)
+	if err != nil {
+		return err
+	}
+	if inv.InviterID == nil {
+		return nil
+	}
+	isOwner, err := u.repoGroup.IsGroupOwner(ctx, *inv.InviterID, inv.GroupID)
+	if err != nil {
+		return err
+	}
+	if isOwner {
+		return nil
+	}
+	return u.repoNotification.Notify(ctx, []string{*inv.InviterID}, event, payload)
+}
+
+// authorizeOwner пропускает ответственных за группу и пользователей с правом group.manage
+func (u *UsecaseLayer) authorizeOwner(ctx context.Context, userID string, groupID int) error {
+	isOwner, err := u.repoGroup.IsGroupOwner(ctx, userID, groupID)
+	if err != nil {
+		return err
+	}
+	if !isOwner {
+		return u.policy.Authorize(ctx, mc.PermGroupManage)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package notification
+
+import (
+	"context"
+	"errors"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/internal/repo/notification"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+)
+
+type Usecase interface {
+	List(ctx context.Context, filter *dto.NotificationFilter) ([]*ent.Notification, error)
+	MarkRead(ctx context.Context, id int64) error
+}
+
+var _ Usecase = (*UsecaseLayer)(nil)
+
+type UsecaseLayer struct {
+	repoNotification notification.Repo
+}
+
+// NewUsecaseLayer возвращает структуру уровня usecase, позволяющую пользователю читать свои уведомления.
+func NewUsecaseLayer(repoNotification notification.Repo) *UsecaseLayer {
+	return &UsecaseLayer{
+		repoNotification: repoNotification,
+	}
+}
+
+// List возвращает страницу уведомлений аутентифицированного пользователя, начиная с новых.
+func (u *UsecaseLayer) List(ctx context.Context, filter *dto.NotificationFilter) ([]*ent.Notification, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.repoNotification.List(ctx, principal.ID, filter)
+}
+
+// MarkRead отмечает уведомление аутентифицированного пользователя прочитанным.
+func (u *UsecaseLayer) MarkRead(ctx context.Context, id int64) error {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return err
+	}
+	err = u.repoNotification.MarkRead(ctx, principal.ID, id)
+	if errors.Is(err, me.ErrNoRowsAffected) {
+		return me.ErrNotificationNotExist
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cantylv/authorization-service/internal/repo/role"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+)
+
+// Policy единая точка проверки прав. Права пользователя определяются ролями, назначенными ему напрямую
+// и через группы, поэтому проверка всегда идет по актуальному состоянию базы данных, а не по токену доступа.
+type Policy interface {
+	// Authorize возвращает ErrPermissionDenied, если у аутентифицированного пользователя нет права permission.
+	Authorize(ctx context.Context, permission string) error
+	// HasPermission проверяет право аутентифицированного пользователя без возврата ошибки доступа.
+	HasPermission(ctx context.Context, permission string) (bool, error)
+}
+
+var _ Policy = (*PolicyLayer)(nil)
+
+type PolicyLayer struct {
+	repoRole role.Repo
+}
+
+// NewPolicyLayer возвращает политику доступа, основанную на ролях.
+func NewPolicyLayer(repoRole role.Repo) *PolicyLayer {
+	return &PolicyLayer{
+		repoRole: repoRole,
+	}
+}
+
+func (p *PolicyLayer) Authorize(ctx context.Context, permission string) error {
+	allowed, err := p.HasPermission(ctx, permission)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return Denied(permission)
+	}
+	return nil
+}
+
+func (p *PolicyLayer) HasPermission(ctx context.Context, permission string) (bool, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return false, err
+	}
+	return p.repoRole.HasPermission(ctx, principal.ID, permission)
+}
+
+// Denied возвращает ошибку доступа с названием недостающего права.
+func Denied(permission string) error {
+	return fmt.Errorf("%w: '%s' is required", me.ErrPermissionDenied, permission)
+}
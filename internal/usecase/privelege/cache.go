@@ -0,0 +1,165 @@
+package privelege
+
+import (
+	"container/list"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/spf13/viper"
+)
+
+type accessKey struct {
+	email  string
+	agent  string
+	action string
+}
+
+type accessEntry struct {
+	key       accessKey
+	check     *ent.AccessCheck
+	expiresAt time.Time
+}
+
+// AccessCache ограниченный LRU кэш результатов проверки доступа для CanExecute. Запись живет не дольше
+// access_cache.ttl и не дольше момента, когда решение изменится по сроку действия привелегий. Записи сбрасываются
+// по уведомлениям канала access_cache, которые триггеры базы данных отправляют при изменении прав, участий
+// в группах, агентов и пользователей, поэтому кэш остается согласованным на всех репликах.
+type AccessCache struct {
+	mu         sync.Mutex
+	capacity   int
+	ttl        time.Duration
+	entries    map[accessKey]*list.Element
+	lru        *list.List
+	generation uint64
+
+	hits          atomic.Int64
+	misses        atomic.Int64
+	evictions     atomic.Int64
+	invalidations atomic.Int64
+}
+
+// NewAccessCache возвращает кэш решений о доступе на access_cache.size записей. Нулевой размер или срок жизни
+// отключают кэш.
+func NewAccessCache() *AccessCache {
+	return &AccessCache{
+		capacity: viper.GetInt("access_cache.size"),
+		ttl:      viper.GetDuration("access_cache.ttl"),
+		entries:  make(map[accessKey]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// Enabled сообщает, включен ли кэш.
+func (c *AccessCache) Enabled() bool {
+	return c.capacity > 0 && c.ttl > 0
+}
+
+// get возвращает результат проверки из кэша и поколение кэша. Поколение нужно передать в put, чтобы результат,
+// прочитанный до сброса, не попал в кэш после него.
+func (c *AccessCache) get(key accessKey) (*ent.AccessCheck, uint64, bool) {
+	if !c.Enabled() {
+		return nil, 0, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*accessEntry)
+		if time.Now().Before(entry.expiresAt) {
+			c.lru.MoveToFront(el)
+			c.hits.Add(1)
+			return entry.check, c.generation, true
+		}
+		c.lru.Remove(el)
+		delete(c.entries, key)
+	}
+	c.misses.Add(1)
+	return nil, c.generation, false
+}
+
+// put сохраняет результат проверки, если с момента get кэш не сбрасывался.
+func (c *AccessCache) put(key accessKey, generation uint64, check *ent.AccessCheck) {
+	if !c.Enabled() {
+		return
+	}
+	expiresAt := time.Now().Add(c.ttl)
+	if check.NextChange != nil && check.NextChange.Before(expiresAt) {
+		expiresAt = *check.NextChange
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	entry := &accessEntry{key: key, check: check, expiresAt: expiresAt}
+	if el, ok := c.entries[key]; ok {
+		el.Value = entry
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*accessEntry).key)
+		c.evictions.Add(1)
+	}
+}
+
+// Invalidate сбрасывает записи по уведомлению канала access_cache: 'user:<id>' - записи пользователя,
+// 'agent:<id>' - записи агента, остальные уведомления сбрасывают весь кэш.
+func (c *AccessCache) Invalidate(payload string) {
+	kind, id, _ := strings.Cut(payload, ":")
+	var match func(check *ent.AccessCheck) bool
+	switch kind {
+	case "user":
+		match = func(check *ent.AccessCheck) bool { return check.UserID == id }
+	case "agent":
+		match = func(check *ent.AccessCheck) bool { return strconv.Itoa(check.AgentID) == id }
+	default:
+		match = func(*ent.AccessCheck) bool { return true }
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for el := c.lru.Front(); el != nil; {
+		next := el.Next()
+		entry := el.Value.(*accessEntry)
+		if match(entry.check) {
+			c.lru.Remove(el)
+			delete(c.entries, entry.key)
+			c.invalidations.Add(1)
+		}
+		el = next
+	}
+}
+
+// Reset сбрасывает весь кэш. Вызывается после подписки на канал access_cache, так как уведомления,
+// отправленные до подписки, потеряны.
+func (c *AccessCache) Reset() {
+	c.Invalidate("all")
+}
+
+// Stats возвращает счетчики кэша.
+func (c *AccessCache) Stats() *ent.AccessCacheStats {
+	c.mu.Lock()
+	size := c.lru.Len()
+	c.mu.Unlock()
+	stats := &ent.AccessCacheStats{
+		Enabled:       c.Enabled(),
+		Size:          size,
+		Capacity:      c.capacity,
+		TTL:           c.ttl.String(),
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Evictions:     c.evictions.Load(),
+		Invalidations: c.invalidations.Load(),
+	}
+	if total := stats.Hits + stats.Misses; total != 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
@@ -4,37 +4,68 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 
 	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
 	"github.com/cantylv/authorization-service/internal/repo/agent"
 	"github.com/cantylv/authorization-service/internal/repo/group"
 	"github.com/cantylv/authorization-service/internal/repo/privelege"
 	"github.com/cantylv/authorization-service/internal/repo/user"
+	"github.com/cantylv/authorization-service/internal/usecase/audit"
+	"github.com/cantylv/authorization-service/internal/usecase/decision"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
 	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/cantylv/authorization-service/services/postgres"
 	"github.com/spf13/viper"
 )
 
 type Usecase interface {
-	AddAgentToGroup(ctx context.Context, agentName, groupName, emailAdd string) error
-	AddAgentToUser(ctx context.Context, agentName, email, emailAdd string) error
-	DeleteAgentFromGroup(ctx context.Context, agentName, groupName, emailDelete string) error
-	DeleteAgentFromUser(ctx context.Context, agentName, email, emailDelete string) error
-	GetGroupAgents(ctx context.Context, groupName, emailAsk string) ([]*ent.Agent, error)
-	GetUserAgents(ctx context.Context, email string, emailAsk string) ([]*ent.Agent, error)
-	CanExecute(ctx context.Context, userEmail, agentName string) (bool, error)
+	AddAgentToGroup(ctx context.Context, agentName, action, groupName string, period *dto.GrantPeriodData) error
+	AddAgentToUser(ctx context.Context, agentName, action, email string, period *dto.GrantPeriodData) error
+	DeleteAgentFromGroup(ctx context.Context, agentName, action, groupName string) error
+	DeleteAgentFromUser(ctx context.Context, agentName, action, email string) error
+	GetGroupAgents(ctx context.Context, groupName string) ([]*ent.Agent, error)
+	GetUserAgents(ctx context.Context, email string) ([]*ent.Agent, error)
+	AddDenyToGroup(ctx context.Context, agentName, action, groupName string) error
+	AddDenyToUser(ctx context.Context, agentName, action, email string) error
+	DeleteDenyFromGroup(ctx context.Context, agentName, action, groupName string) error
+	DeleteDenyFromUser(ctx context.Context, agentName, action, email string) error
+	CanExecute(ctx context.Context, userEmail, agentName, action string) (bool, error)
+	ExplainAccess(ctx context.Context, userEmail, agentName, action string) (*ent.AccessExplanation, error)
+	CanExecuteBatch(ctx context.Context, checks []dto.AccessCheckItem) ([]*ent.AccessCheckResult, error)
+	GetAgentAccessors(ctx context.Context, agentName string, page *dto.Pagination) ([]*ent.AgentAccessor, error)
+	ExportAgentAccessors(ctx context.Context, agentName string, fn func(a *ent.AgentAccessor) error) error
+	GetAccessCacheStats(ctx context.Context) (*ent.AccessCacheStats, error)
 }
 
 var _ Usecase = (*UsecaseLayer)(nil)
 
 type UsecaseLayer struct {
-	repoAgent agent.Repo
+	uow           postgres.UnitOfWork
+	policy        policy.Policy
+	audit         audit.Recorder
+	decisions     decision.Logger
+	cache         *AccessCache
+	repoAgent     agent.Repo
 	repoPrivelege privelege.Repo
 	repoUser      user.Repo
 	repoGroup     group.Repo
 }
 
-func NewUsecaseLayer(repoAgent agent.Repo, repoPrivelege privelege.Repo, repoUser user.Repo, repoGroup group.Repo) *UsecaseLayer {
+// NewUsecaseLayer возвращает структуру уровня usecase, управляющую привелегиями.
+// Изменяющие методы выполняются в одной транзакции через uow, права проверяются политикой доступа,
+// изменения записываются в журнал аудита в той же транзакции. Решения CanExecute записываются в журнал решений о доступе,
+// результаты проверок CanExecute кэшируются в cache.
+func NewUsecaseLayer(uow postgres.UnitOfWork, policy policy.Policy, audit audit.Recorder, decisions decision.Logger, cache *AccessCache, repoAgent agent.Repo, repoPrivelege privelege.Repo, repoUser user.Repo, repoGroup group.Repo) *UsecaseLayer {
 	return &UsecaseLayer{
+		uow:           uow,
+		policy:        policy,
+		audit:         audit,
+		decisions:     decisions,
+		cache:         cache,
 		repoAgent:     repoAgent,
 		repoPrivelege: repoPrivelege,
 		repoUser:      repoUser,
@@ -42,10 +73,17 @@ func NewUsecaseLayer(repoAgent agent.Repo, repoPrivelege privelege.Repo, repoUse
 	}
 }
 
-func (u *UsecaseLayer) AddAgentToGroup(ctx context.Context, agentName, groupName, emailAdd string) error {
-	// только root может добавить агента к группе
-	if emailAdd != viper.GetString("root_email") {
-		return me.ErrOnlyRootCanAddAgent
+// AddAgentToGroup выдает группе доступ к агенту или к его действию на срок period.
+// Пустой period означает бессрочный доступ с момента выдачи.
+func (u *UsecaseLayer) AddAgentToGroup(ctx context.Context, agentName, action, groupName string, period *dto.GrantPeriodData) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.addAgentToGroup(ctx, agentName, action, groupName, period)
+	})
+}
+
+func (u *UsecaseLayer) addAgentToGroup(ctx context.Context, agentName, action, groupName string, period *dto.GrantPeriodData) error {
+	if err := u.policy.Authorize(ctx, mc.PermPrivilegeGrant); err != nil {
+		return err
 	}
 	// проверим, есть ли agent с таким именем
 	a, err := u.repoAgent.Read(ctx, agentName)
@@ -63,6 +101,20 @@ func (u *UsecaseLayer) AddAgentToGroup(ctx context.Context, agentName, groupName
 		}
 		return err
 	}
+	if action != "" {
+		act, err := u.readAction(ctx, a.ID, action)
+		if err != nil {
+			return err
+		}
+		created, err := u.repoPrivelege.CreateGroupAgentAction(ctx, g.ID, act.ID, period)
+		if err != nil {
+			return err
+		}
+		if !created {
+			return me.ErrGroupActionAlreadyExist
+		}
+		return u.audit.Record(ctx, mc.AuditPrivilegeGrant, mc.AuditTargetGroup, g.Name, nil, grantState(agentName, action, period))
+	}
 	// проверим, что у группы еще нет такого агента
 	isAlreadyGroupAgent, err := u.repoAgent.IsGroupAgent(ctx, g.ID, a.ID)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -72,17 +124,24 @@ func (u *UsecaseLayer) AddAgentToGroup(ctx context.Context, agentName, groupName
 		return me.ErrGroupAgentAlreadyExist
 	}
 	// создаем запись
-	_, err = u.repoPrivelege.CreateGroupAgent(ctx, g.ID, a.ID)
+	_, err = u.repoPrivelege.CreateGroupAgent(ctx, g.ID, a.ID, period)
 	if err != nil {
 		return err
 	}
-	return nil
+	return u.audit.Record(ctx, mc.AuditPrivilegeGrant, mc.AuditTargetGroup, g.Name, nil, grantState(agentName, action, period))
+}
+
+// AddAgentToUser выдает пользователю доступ к агенту или к его действию на срок period.
+// Пустой period означает бессрочный доступ с момента выдачи.
+func (u *UsecaseLayer) AddAgentToUser(ctx context.Context, agentName, action, email string, period *dto.GrantPeriodData) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.addAgentToUser(ctx, agentName, action, email, period)
+	})
 }
 
-func (u *UsecaseLayer) AddAgentToUser(ctx context.Context, agentName, email, emailAdd string) error {
-	// только root может добавить агента к пользователю
-	if emailAdd != viper.GetString("root_email") {
-		return me.ErrOnlyRootCanAddAgent
+func (u *UsecaseLayer) addAgentToUser(ctx context.Context, agentName, action, email string, period *dto.GrantPeriodData) error {
+	if err := u.policy.Authorize(ctx, mc.PermPrivilegeGrant); err != nil {
+		return err
 	}
 	// проверим, есть ли agent с таким именем
 	a, err := u.repoAgent.Read(ctx, agentName)
@@ -100,6 +159,20 @@ func (u *UsecaseLayer) AddAgentToUser(ctx context.Context, agentName, email, ema
 		}
 		return err
 	}
+	if action != "" {
+		act, err := u.readAction(ctx, a.ID, action)
+		if err != nil {
+			return err
+		}
+		created, err := u.repoPrivelege.CreateUserAgentAction(ctx, usr.ID, act.ID, period)
+		if err != nil {
+			return err
+		}
+		if !created {
+			return me.ErrUserActionAlreadyExist
+		}
+		return u.audit.Record(ctx, mc.AuditPrivilegeGrant, mc.AuditTargetUser, usr.Email, nil, grantState(agentName, action, period))
+	}
 	// проверим, что у пользователя еще нет такого агента
 	// проверка идет только по привелегиям пользователя, не затрагивая привелегии групп, в которые он входит
 	isAlreadyUserAgent, err := u.repoAgent.IsUserAgent(ctx, usr.ID, a.ID)
@@ -110,17 +183,22 @@ func (u *UsecaseLayer) AddAgentToUser(ctx context.Context, agentName, email, ema
 		return me.ErrUserAgentAlreadyExist
 	}
 	// добавляет агента к пользовательскиим привелегиям
-	_, err = u.repoPrivelege.CreateUserAgent(ctx, usr.ID, a.ID)
+	_, err = u.repoPrivelege.CreateUserAgent(ctx, usr.ID, a.ID, period)
 	if err != nil {
 		return err
 	}
-	return nil
+	return u.audit.Record(ctx, mc.AuditPrivilegeGrant, mc.AuditTargetUser, usr.Email, nil, grantState(agentName, action, period))
+}
+
+func (u *UsecaseLayer) DeleteAgentFromGroup(ctx context.Context, agentName, action, groupName string) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.deleteAgentFromGroup(ctx, agentName, action, groupName)
+	})
 }
 
-func (u *UsecaseLayer) DeleteAgentFromGroup(ctx context.Context, agentName, groupName, emailDelete string) error {
-	// только root может удалить агента у группы
-	if emailDelete != viper.GetString("root_email") {
-		return me.ErrOnlyRootCanDeleteAgent
+func (u *UsecaseLayer) deleteAgentFromGroup(ctx context.Context, agentName, action, groupName string) error {
+	if err := u.policy.Authorize(ctx, mc.PermPrivilegeRevoke); err != nil {
+		return err
 	}
 	// проверим, есть ли agent с таким именем
 	a, err := u.repoAgent.Read(ctx, agentName)
@@ -138,6 +216,20 @@ func (u *UsecaseLayer) DeleteAgentFromGroup(ctx context.Context, agentName, grou
 		}
 		return err
 	}
+	if action != "" {
+		act, err := u.readAction(ctx, a.ID, action)
+		if err != nil {
+			return err
+		}
+		deleted, err := u.repoPrivelege.DeleteGroupAgentAction(ctx, g.ID, act.ID)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return me.ErrGroupActionNotExist
+		}
+		return u.audit.Record(ctx, mc.AuditPrivilegeRevoke, mc.AuditTargetGroup, g.Name, grantState(agentName, action, nil), nil)
+	}
 	// проверим, что у группы есть такой агент
 	_, err = u.repoAgent.IsGroupAgent(ctx, g.ID, a.ID)
 	if err != nil {
@@ -151,13 +243,18 @@ func (u *UsecaseLayer) DeleteAgentFromGroup(ctx context.Context, agentName, grou
 	if err != nil {
 		return err
 	}
-	return nil
+	return u.audit.Record(ctx, mc.AuditPrivilegeRevoke, mc.AuditTargetGroup, g.Name, grantState(agentName, action, nil), nil)
+}
+
+func (u *UsecaseLayer) DeleteAgentFromUser(ctx context.Context, agentName, action, email string) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.deleteAgentFromUser(ctx, agentName, action, email)
+	})
 }
 
-func (u *UsecaseLayer) DeleteAgentFromUser(ctx context.Context, agentName, email, emailDelete string) error {
-	// только root может удалить агента у пользователя
-	if emailDelete != viper.GetString("root_email") {
-		return me.ErrOnlyRootCanDeleteAgent
+func (u *UsecaseLayer) deleteAgentFromUser(ctx context.Context, agentName, action, email string) error {
+	if err := u.policy.Authorize(ctx, mc.PermPrivilegeRevoke); err != nil {
+		return err
 	}
 	// проверим, есть ли agent с таким именем
 	a, err := u.repoAgent.Read(ctx, agentName)
@@ -175,7 +272,21 @@ func (u *UsecaseLayer) DeleteAgentFromUser(ctx context.Context, agentName, email
 		}
 		return err
 	}
-	// проверим, что у группы есть такой агент
+	if action != "" {
+		act, err := u.readAction(ctx, a.ID, action)
+		if err != nil {
+			return err
+		}
+		deleted, err := u.repoPrivelege.DeleteUserAgentAction(ctx, usr.ID, act.ID)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return me.ErrUserActionNotExist
+		}
+		return u.audit.Record(ctx, mc.AuditPrivilegeRevoke, mc.AuditTargetUser, usr.Email, grantState(agentName, action, nil), nil)
+	}
+	// проверим, что у пользователя есть такой агент
 	_, err = u.repoAgent.IsUserAgent(ctx, usr.ID, a.ID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -188,10 +299,16 @@ func (u *UsecaseLayer) DeleteAgentFromUser(ctx context.Context, agentName, email
 	if err != nil {
 		return err
 	}
-	return nil
+	return u.audit.Record(ctx, mc.AuditPrivilegeRevoke, mc.AuditTargetUser, usr.Email, grantState(agentName, action, nil), nil)
 }
 
-func (u *UsecaseLayer) GetGroupAgents(ctx context.Context, groupName, emailAsk string) ([]*ent.Agent, error) {
+// GetGroupAgents запрашивать список агентов группы может ее ответственный или пользователь с правом privilege.read.
+func (u *UsecaseLayer) GetGroupAgents(ctx context.Context, groupName string) ([]*ent.Agent, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	emailAsk := principal.Email
 	// проверим, есть ли group с таким именем
 	g, err := u.repoGroup.GetGroup(ctx, groupName)
 	if err != nil {
@@ -200,7 +317,11 @@ func (u *UsecaseLayer) GetGroupAgents(ctx context.Context, groupName, emailAsk s
 		}
 		return nil, err
 	}
-	if emailAsk == viper.GetString("root_email") {
+	canRead, err := u.policy.HasPermission(ctx, mc.PermPrivilegeRead)
+	if err != nil {
+		return nil, err
+	}
+	if canRead {
 		return u.repoPrivelege.GetGroupAgents(ctx, g.ID)
 	}
 	// проверим, существует ли пользователь
@@ -215,15 +336,20 @@ func (u *UsecaseLayer) GetGroupAgents(ctx context.Context, groupName, emailAsk s
 	_, err = u.repoGroup.IsOwnerOfGroup(ctx, uDB.ID, groupName)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, me.ErrUserIsNotOwner
+			return nil, policy.Denied(mc.PermPrivilegeRead)
 		}
 		return nil, err
 	}
 	return u.repoPrivelege.GetGroupAgents(ctx, g.ID)
 }
 
-// GetUserAgents запрашивать список агентов может только сам пользователь или root.
-func (u *UsecaseLayer) GetUserAgents(ctx context.Context, email string, emailAsk string) ([]*ent.Agent, error) {
+// GetUserAgents запрашивать список агентов может сам пользователь или пользователь с правом privilege.read.
+func (u *UsecaseLayer) GetUserAgents(ctx context.Context, email string) ([]*ent.Agent, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	emailAsk := principal.Email
 	// проверим, есть ли пользователь с такой почтой
 	uDB, err := u.repoUser.GetByEmail(ctx, email)
 	if err != nil {
@@ -232,101 +358,414 @@ func (u *UsecaseLayer) GetUserAgents(ctx context.Context, email string, emailAsk
 		}
 		return nil, err
 	}
-	if emailAsk != viper.GetString("root_email") && email != emailAsk {
-		return nil, me.ErrGetUserAgents
+	if email != emailAsk {
+		if err := u.policy.Authorize(ctx, mc.PermPrivilegeRead); err != nil {
+			return nil, err
+		}
 	}
-	// схема получения привелегий пользователя
-	// 1) получаем список его групп
-	// 2) суммируем привелегии групп
-	// 3) получаем индивидуальные привелегии пользователя
-	// 4) суммируем индивидуальные привелегии и групповые
-	agentsResult := make(map[int]*ent.Agent)
-	uGroups, err := u.repoGroup.GetUserGroups(ctx, uDB.ID)
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+	// индивидуальные привелегии пользователя и привелегии его групп получаем одним запросом
+	return u.repoPrivelege.GetEffectiveUserAgents(ctx, uDB.ID)
+}
+
+// GetAgentAccessors возвращает страницу пользователей с действующим доступом к агенту или к его действиям
+// с разделением на индивидуальные привелегии и привелегии групп. Требует права privilege.read_accessors.
+func (u *UsecaseLayer) GetAgentAccessors(ctx context.Context, agentName string, page *dto.Pagination) ([]*ent.AgentAccessor, error) {
+	a, err := u.readAgentForAccessors(ctx, agentName)
+	if err != nil {
 		return nil, err
 	}
-	// у пользователя нет групп
-	if errors.Is(err, sql.ErrNoRows) {
-		return u.repoPrivelege.GetUserAgents(ctx, uDB.ID)
+	return u.repoPrivelege.GetAgentAccessors(ctx, a.ID, page)
+}
+
+// ExportAgentAccessors передает в fn всех пользователей с действующим доступом к агенту или к его действиям.
+// Требует права privilege.read_accessors.
+func (u *UsecaseLayer) ExportAgentAccessors(ctx context.Context, agentName string, fn func(a *ent.AgentAccessor) error) error {
+	a, err := u.readAgentForAccessors(ctx, agentName)
+	if err != nil {
+		return err
 	}
-	// суммируем привелегии групп
-	for _, g := range uGroups {
-		agents, err := u.repoPrivelege.GetGroupAgents(ctx, g.ID)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				continue
-			}
-			return nil, err
+	return u.repoPrivelege.ExportAgentAccessors(ctx, a.ID, fn)
+}
+
+// readAgentForAccessors проверяет право privilege.read_accessors, так как список раскрывает права всех
+// пользователей, и возвращает агента.
+func (u *UsecaseLayer) readAgentForAccessors(ctx context.Context, agentName string) (*ent.Agent, error) {
+	if err := u.policy.Authorize(ctx, mc.PermPrivilegeReadAccessors); err != nil {
+		return nil, err
+	}
+	a, err := u.repoAgent.Read(ctx, agentName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrAgentNotExist
 		}
-		for _, a := range agents {
-			agentsResult[a.ID] = a
+		return nil, err
+	}
+	return a, nil
+}
+
+func (u *UsecaseLayer) AddDenyToGroup(ctx context.Context, agentName, action, groupName string) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.addDenyToGroup(ctx, agentName, action, groupName)
+	})
+}
+
+// addDenyToGroup запрещает группе доступ к агенту или к его действию. Запрет отнимает доступ,
+// поэтому для него нужно право privilege.revoke.
+func (u *UsecaseLayer) addDenyToGroup(ctx context.Context, agentName, action, groupName string) error {
+	if err := u.policy.Authorize(ctx, mc.PermPrivilegeRevoke); err != nil {
+		return err
+	}
+	a, actionID, err := u.readAgentAction(ctx, agentName, action)
+	if err != nil {
+		return err
+	}
+	g, err := u.repoGroup.GetGroup(ctx, groupName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return me.ErrGroupNotExist
 		}
+		return err
 	}
-	// получим индвивидуальные привелегии пользователя
-	agents, err := u.repoPrivelege.GetUserAgents(ctx, uDB.ID)
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		return nil, err
+	created, err := u.repoPrivelege.CreateGroupDeny(ctx, g.ID, a.ID, actionID)
+	if err != nil {
+		return err
 	}
-	for _, a := range agents {
-		agentsResult[a.ID] = a
+	if !created {
+		return me.ErrGroupDenyAlreadyExist
 	}
+	return u.audit.Record(ctx, mc.AuditDenyAdd, mc.AuditTargetGroup, g.Name, nil, grantState(agentName, action, nil))
+}
+
+func (u *UsecaseLayer) AddDenyToUser(ctx context.Context, agentName, action, email string) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.addDenyToUser(ctx, agentName, action, email)
+	})
+}
 
-	resAgents := make([]*ent.Agent, 0, len(agentsResult))
-	for _, agentEntity := range agentsResult {
-		resAgents = append(resAgents, agentEntity)
+// addDenyToUser запрещает пользователю доступ к агенту или к его действию, запрет пользователя
+// сильнее любых разрешений. Для этого нужно право privilege.revoke.
+func (u *UsecaseLayer) addDenyToUser(ctx context.Context, agentName, action, email string) error {
+	if err := u.policy.Authorize(ctx, mc.PermPrivilegeRevoke); err != nil {
+		return err
+	}
+	// root должен сохранять доступ ко всем агентам
+	if email == viper.GetString("root_email") {
+		return me.ErrCantDenyRoot
 	}
-	return resAgents, nil
+	a, actionID, err := u.readAgentAction(ctx, agentName, action)
+	if err != nil {
+		return err
+	}
+	usr, err := u.repoUser.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return me.ErrUserNotExist
+		}
+		return err
+	}
+	created, err := u.repoPrivelege.CreateUserDeny(ctx, usr.ID, a.ID, actionID)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return me.ErrUserDenyAlreadyExist
+	}
+	return u.audit.Record(ctx, mc.AuditDenyAdd, mc.AuditTargetUser, usr.Email, nil, grantState(agentName, action, nil))
+}
+
+func (u *UsecaseLayer) DeleteDenyFromGroup(ctx context.Context, agentName, action, groupName string) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.deleteDenyFromGroup(ctx, agentName, action, groupName)
+	})
 }
 
-func (u *UsecaseLayer) CanExecute(ctx context.Context, userEmail, agentName string) (bool, error) {
-	// проверяем, существует ли пользователь, права которого хотим проверить
-	uDB, err := u.repoUser.GetByEmail(ctx, userEmail)
+// deleteDenyFromGroup снимает запрет с группы. Снятие запрета возвращает доступ, поэтому для него нужно
+// право privilege.grant.
+func (u *UsecaseLayer) deleteDenyFromGroup(ctx context.Context, agentName, action, groupName string) error {
+	if err := u.policy.Authorize(ctx, mc.PermPrivilegeGrant); err != nil {
+		return err
+	}
+	a, actionID, err := u.readAgentAction(ctx, agentName, action)
+	if err != nil {
+		return err
+	}
+	g, err := u.repoGroup.GetGroup(ctx, groupName)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return false, me.ErrUserNotExist
+			return me.ErrGroupNotExist
 		}
-		return false, err
+		return err
 	}
-	// проверяем, существует ли агент, к которому хочет обратиться пользователь
-	_, err = u.repoAgent.Read(ctx, agentName)
+	deleted, err := u.repoPrivelege.DeleteGroupDeny(ctx, g.ID, a.ID, actionID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return me.ErrGroupDenyNotExist
+	}
+	return u.audit.Record(ctx, mc.AuditDenyRemove, mc.AuditTargetGroup, g.Name, grantState(agentName, action, nil), nil)
+}
+
+func (u *UsecaseLayer) DeleteDenyFromUser(ctx context.Context, agentName, action, email string) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.deleteDenyFromUser(ctx, agentName, action, email)
+	})
+}
+
+// deleteDenyFromUser снимает запрет с пользователя, для этого нужно право privilege.grant.
+func (u *UsecaseLayer) deleteDenyFromUser(ctx context.Context, agentName, action, email string) error {
+	if err := u.policy.Authorize(ctx, mc.PermPrivilegeGrant); err != nil {
+		return err
+	}
+	a, actionID, err := u.readAgentAction(ctx, agentName, action)
+	if err != nil {
+		return err
+	}
+	usr, err := u.repoUser.GetByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return false, me.ErrAgentNotExist
+			return me.ErrUserNotExist
 		}
-		return false, err
+		return err
 	}
-	// получим список групп, в которые пользователь входит
-	// и после для каждой группы получим список доступных агентов
-	// если хоть в одном из них окажется agentName, то пользователь имеет доступ к агенту
-	groups, err := u.repoGroup.GetUserGroups(ctx, uDB.ID)
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		return false, err
+	deleted, err := u.repoPrivelege.DeleteUserDeny(ctx, usr.ID, a.ID, actionID)
+	if err != nil {
+		return err
 	}
-	// ключ - идентификатор агента, значение - пустая структура, которая не занимает память
-	userAvaliableAgents := make(map[string]struct{})
-	for _, g := range groups {
-		as, err := u.repoPrivelege.GetGroupAgents(ctx, g.ID)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				continue
-			}
-			return false, err
+	if !deleted {
+		return me.ErrUserDenyNotExist
+	}
+	return u.audit.Record(ctx, mc.AuditDenyRemove, mc.AuditTargetUser, usr.Email, grantState(agentName, action, nil), nil)
+}
+
+// grantState состояние разрешения или запрета для журнала аудита, period может быть nil.
+func grantState(agentName, action string, period *dto.GrantPeriodData) *ent.AuditGrant {
+	state := &ent.AuditGrant{Agent: agentName, Action: action}
+	if period != nil {
+		state.ValidFrom = period.ValidFrom
+		state.ValidUntil = period.ValidUntil
+	}
+	return state
+}
+
+// readAgentAction возвращает агента и идентификатор его действия. Для пустого действия идентификатор равен nil,
+// что означает агента целиком.
+func (u *UsecaseLayer) readAgentAction(ctx context.Context, agentName, action string) (*ent.Agent, *int, error) {
+	a, err := u.repoAgent.Read(ctx, agentName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, me.ErrAgentNotExist
 		}
-		for _, a := range as {
-			userAvaliableAgents[a.Name] = struct{}{}
+		return nil, nil, err
+	}
+	if action == "" {
+		return a, nil, nil
+	}
+	act, err := u.readAction(ctx, a.ID, action)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, &act.ID, nil
+}
+
+// readAction возвращает действие агента, если его нет, то ErrAgentActionNotExist.
+func (u *UsecaseLayer) readAction(ctx context.Context, agentID int, action string) (*ent.AgentAction, error) {
+	act, err := u.repoAgent.GetAction(ctx, agentID, action)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrAgentActionNotExist
 		}
+		return nil, err
 	}
-	// получим индвивидуальные привелегии пользователя
-	agents, err := u.repoPrivelege.GetUserAgents(ctx, uDB.ID)
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+	return act, nil
+}
+
+// CanExecute проверяет доступ пользователя к действию агента. Пустое действие означает проверку доступа
+// ко всему агенту, такой доступ дают только права на агента целиком. Решение, в том числе непройденная
+// проверка существования, записывается в журнал решений о доступе.
+func (u *UsecaseLayer) CanExecute(ctx context.Context, userEmail, agentName, action string) (bool, error) {
+	explanation, err := u.explainAccess(ctx, userEmail, agentName, action, false)
+	if explanation != nil {
+		u.decisions.Log(ctx, explanation)
+	}
+	if err != nil {
 		return false, err
 	}
-	for _, a := range agents {
-		userAvaliableAgents[a.Name] = struct{}{}
+	return explanation.CanExecute, nil
+}
+
+// ExplainAccess возвращает полный вывод решения о доступе: сработавшее правило и все подходящие строки
+// привелегий и запретов пользователя и его групп, либо проверку, которая не прошла. Объяснение может
+// запросить сам пользователь или пользователь с правом privilege.read.
+func (u *UsecaseLayer) ExplainAccess(ctx context.Context, userEmail, agentName, action string) (*ent.AccessExplanation, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if principal.Email != userEmail {
+		if err := u.policy.Authorize(ctx, mc.PermPrivilegeRead); err != nil {
+			return nil, err
+		}
+	}
+	explanation, err := u.explainAccess(ctx, userEmail, agentName, action, true)
+	// непройденная проверка существования является частью объяснения, а не ошибкой
+	if err != nil && explanation == nil {
+		return nil, err
+	}
+	return explanation, nil
+}
+
+// explainAccess принимает решение о доступе. Если не существует пользователь, агент или действие, возвращает
+// объяснение с непройденной проверкой вместе с соответствующей ошибкой. Строки правил запрашиваются только
+// при withRules, чтобы обычная проверка доступа оставалась одним запросом. Без withRules результат проверки
+// берется из кэша решений о доступе.
+func (u *UsecaseLayer) explainAccess(ctx context.Context, userEmail, agentName, action string, withRules bool) (*ent.AccessExplanation, error) {
+	check, err := u.checkAccess(ctx, userEmail, agentName, action, !withRules)
+	if err != nil {
+		return nil, err
+	}
+	explanation, err := explain(check, userEmail, agentName, action)
+	if err != nil || !withRules {
+		return explanation, err
+	}
+	rules, err := u.repoPrivelege.GetAccessRules(ctx, userEmail, agentName, action)
+	if err != nil {
+		return nil, err
+	}
+	// решение принимает уровень правил с наивысшим приоритетом, например все разрешения пользователя
+	for _, rule := range rules {
+		rule.Applied = rule.Source+"_"+rule.Effect == explanation.Decision
 	}
+	explanation.Rules = rules
+	return explanation, nil
+}
+
+// explain возвращает объяснение решения по результату проверки без строк правил. Если не существует пользователь,
+// агент или действие, объяснение содержит непройденную проверку и возвращается вместе с соответствующей ошибкой.
+func explain(check *ent.AccessCheck, userEmail, agentName, action string) (*ent.AccessExplanation, error) {
+	explanation := &ent.AccessExplanation{
+		Email:    userEmail,
+		Agent:    agentName,
+		Action:   action,
+		Decision: mc.DecisionCheckFailed,
+		Rules:    make([]*ent.AccessRule, 0),
+	}
+	if !check.UserExists {
+		explanation.FailedCheck = mc.FailedCheckUserNotExist
+		return explanation, me.ErrUserNotExist
+	}
+	if !check.AgentExists {
+		explanation.FailedCheck = mc.FailedCheckAgentNotExist
+		return explanation, me.ErrAgentNotExist
+	}
+	if action != "" && !check.ActionExists {
+		explanation.FailedCheck = mc.FailedCheckActionNotExist
+		return explanation, me.ErrAgentActionNotExist
+	}
+	explanation.CanExecute, explanation.Decision = decide(check)
+	return explanation, nil
+}
+
+// checkAccess одним запросом проверяет существование пользователя, агента и действия, а также запреты и разрешения
+// пользователя и всех его групп. При useCache результат берется из кэша, а в кэш попадают только проверки, в которых
+// пользователь, агент и действие существуют: изменения остальных не отслеживаются уведомлениями.
+func (u *UsecaseLayer) checkAccess(ctx context.Context, userEmail, agentName, action string, useCache bool) (*ent.AccessCheck, error) {
+	if !useCache {
+		return u.repoPrivelege.CheckAccess(ctx, userEmail, agentName, action)
+	}
+	key := accessKey{email: userEmail, agent: agentName, action: action}
+	check, generation, ok := u.cache.get(key)
+	if ok {
+		return check, nil
+	}
+	check, err := u.repoPrivelege.CheckAccess(ctx, userEmail, agentName, action)
+	if err != nil {
+		return nil, err
+	}
+	u.cacheCheck(key, generation, check)
+	return check, nil
+}
+
+// cacheCheck сохраняет в кэш проверку, в которой пользователь, агент и действие существуют.
+func (u *UsecaseLayer) cacheCheck(key accessKey, generation uint64, check *ent.AccessCheck) {
+	if check.UserExists && check.AgentExists && (key.action == "" || check.ActionExists) {
+		u.cache.put(key, generation, check)
+	}
+}
+
+// CanExecuteBatch принимает решения о доступе для набора проверок. Проверки, которых нет в кэше, выполняются одним
+// запросом. Несуществующий пользователь, агент или действие не прерывают набор, а указываются в FailedCheck
+// результата. Все решения записываются в журнал решений о доступе.
+func (u *UsecaseLayer) CanExecuteBatch(ctx context.Context, checks []dto.AccessCheckItem) ([]*ent.AccessCheckResult, error) {
+	found := make([]*ent.AccessCheck, len(checks))
+	var (
+		missed      []dto.AccessCheckItem
+		missedIdx   []int
+		generations []uint64
+	)
+	for i, item := range checks {
+		check, generation, ok := u.cache.get(accessKey{email: item.Email, agent: item.Agent, action: item.Action})
+		if ok {
+			found[i] = check
+			continue
+		}
+		missed = append(missed, item)
+		missedIdx = append(missedIdx, i)
+		generations = append(generations, generation)
+	}
+	if len(missed) != 0 {
+		fetched, err := u.repoPrivelege.CheckAccessBatch(ctx, missed)
+		if err != nil {
+			return nil, err
+		}
+		if len(fetched) != len(missed) {
+			return nil, fmt.Errorf("access check batch returned %d results for %d checks", len(fetched), len(missed))
+		}
+		for j, check := range fetched {
+			item := missed[j]
+			u.cacheCheck(accessKey{email: item.Email, agent: item.Agent, action: item.Action}, generations[j], check)
+			found[missedIdx[j]] = check
+		}
+	}
+	results := make([]*ent.AccessCheckResult, 0, len(checks))
+	for i, item := range checks {
+		// ошибка о несуществующем пользователе, агенте или действии уже отражена в объяснении
+		explanation, _ := explain(found[i], item.Email, item.Agent, item.Action)
+		u.decisions.Log(ctx, explanation)
+		results = append(results, &ent.AccessCheckResult{
+			Email:       explanation.Email,
+			Agent:       explanation.Agent,
+			Action:      explanation.Action,
+			CanExecute:  explanation.CanExecute,
+			Decision:    explanation.Decision,
+			FailedCheck: explanation.FailedCheck,
+		})
+	}
+	return results, nil
+}
+
+// GetAccessCacheStats возвращает счетчики попаданий и промахов кэша решений о доступе этого экземпляра сервиса.
+// Доступно пользователю с правом privilege.read.
+func (u *UsecaseLayer) GetAccessCacheStats(ctx context.Context) (*ent.AccessCacheStats, error) {
+	if err := u.policy.Authorize(ctx, mc.PermPrivilegeRead); err != nil {
+		return nil, err
+	}
+	return u.cache.Stats(), nil
+}
 
-	if _, ok := userAvaliableAgents[agentName]; !ok {
-		return false, nil
+// decide применяет правила в порядке: запрет пользователя > разрешение пользователя > запрет группы >
+// разрешение группы. Если ни одно правило не подошло, доступа нет.
+func decide(check *ent.AccessCheck) (bool, string) {
+	switch {
+	case check.UserDeny:
+		return false, mc.DecisionUserDeny
+	case check.UserAllow:
+		return true, mc.DecisionUserAllow
+	case check.GroupDeny:
+		return false, mc.DecisionGroupDeny
+	case check.GroupAllow:
+		return true, mc.DecisionGroupAllow
+	default:
+		return false, mc.DecisionNoGrant
 	}
-	return true, nil
 }
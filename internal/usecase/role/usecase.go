@@ -0,0 +1,374 @@
+package role
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/internal/repo/group"
+	"github.com/cantylv/authorization-service/internal/repo/role"
+	"github.com/cantylv/authorization-service/internal/repo/user"
+	"github.com/cantylv/authorization-service/internal/usecase/audit"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/cantylv/authorization-service/services/postgres"
+	"github.com/spf13/viper"
+)
+
+type Usecase interface {
+	GetPermissions(ctx context.Context) ([]*ent.Permission, error)
+	GetRoles(ctx context.Context) ([]*ent.Role, error)
+	CreateRole(ctx context.Context, roleData *dto.CreateRoleData) (*ent.Role, error)
+	DeleteRole(ctx context.Context, roleName string) error
+	SetRolePermissions(ctx context.Context, roleName string, permissions []string) (*ent.Role, error)
+	AssignRoleToUser(ctx context.Context, roleName, email string) error
+	RevokeRoleFromUser(ctx context.Context, roleName, email string) error
+	AssignRoleToGroup(ctx context.Context, roleName, groupName string) error
+	RevokeRoleFromGroup(ctx context.Context, roleName, groupName string) error
+	GetUserPermissions(ctx context.Context, email string) ([]string, error)
+}
+
+var _ Usecase = (*UsecaseLayer)(nil)
+
+type UsecaseLayer struct {
+	uow       postgres.UnitOfWork
+	policy    policy.Policy
+	audit     audit.Recorder
+	repoRole  role.Repo
+	repoUser  user.Repo
+	repoGroup group.Repo
+}
+
+// NewUsecaseLayer возвращает структуру уровня usecase, управляющую ролями и их назначением пользователям и группам.
+// Управлять ролями может пользователь с правом role.manage, изменения записываются в журнал аудита.
+func NewUsecaseLayer(uow postgres.UnitOfWork, policy policy.Policy, audit audit.Recorder, repoRole role.Repo, repoUser user.Repo, repoGroup group.Repo) *UsecaseLayer {
+	return &UsecaseLayer{
+		uow:       uow,
+		policy:    policy,
+		audit:     audit,
+		repoRole:  repoRole,
+		repoUser:  repoUser,
+		repoGroup: repoGroup,
+	}
+}
+
+// GetPermissions возвращает список всех прав, которые можно включить в роль.
+func (u *UsecaseLayer) GetPermissions(ctx context.Context) ([]*ent.Permission, error) {
+	if err := u.policy.Authorize(ctx, mc.PermRoleManage); err != nil {
+		return nil, err
+	}
+	return u.repoRole.GetPermissions(ctx)
+}
+
+// GetRoles возвращает список ролей вместе с их правами.
+func (u *UsecaseLayer) GetRoles(ctx context.Context) ([]*ent.Role, error) {
+	if err := u.policy.Authorize(ctx, mc.PermRoleManage); err != nil {
+		return nil, err
+	}
+	return u.repoRole.GetRoles(ctx)
+}
+
+// CreateRole создает пользовательскую роль с указанным набором прав.
+func (u *UsecaseLayer) CreateRole(ctx context.Context, roleData *dto.CreateRoleData) (*ent.Role, error) {
+	var res *ent.Role
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = u.createRole(ctx, roleData)
+		return err
+	})
+	return res, err
+}
+
+func (u *UsecaseLayer) createRole(ctx context.Context, roleData *dto.CreateRoleData) (*ent.Role, error) {
+	if err := u.policy.Authorize(ctx, mc.PermRoleManage); err != nil {
+		return nil, err
+	}
+	// проверяем, есть ли уже роль с таким именем
+	r, err := u.repoRole.GetRole(ctx, roleData.Name)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if r != nil {
+		return nil, me.ErrRoleAlreadyExist
+	}
+	if err := u.checkPermissions(ctx, roleData.Permissions); err != nil {
+		return nil, err
+	}
+	r, err = u.repoRole.CreateRole(ctx, roleData.Name, roleData.Description)
+	if err != nil {
+		return nil, err
+	}
+	if len(roleData.Permissions) > 0 {
+		err = u.repoRole.SetRolePermissions(ctx, r.ID, roleData.Permissions)
+		if err != nil {
+			return nil, err
+		}
+		r, err = u.repoRole.GetRole(ctx, r.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := u.audit.Record(ctx, mc.AuditRoleCreate, mc.AuditTargetRole, r.Name, nil, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// DeleteRole удаляет роль. Пользователи и группы, которым она была назначена, теряют ее права.
+// Системные роли удалить нельзя.
+func (u *UsecaseLayer) DeleteRole(ctx context.Context, roleName string) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.deleteRole(ctx, roleName)
+	})
+}
+
+func (u *UsecaseLayer) deleteRole(ctx context.Context, roleName string) error {
+	if err := u.policy.Authorize(ctx, mc.PermRoleManage); err != nil {
+		return err
+	}
+	r, err := u.getRole(ctx, roleName)
+	if err != nil {
+		return err
+	}
+	if r.IsSystem {
+		return me.ErrCantChangeSystemRole
+	}
+	if err := u.repoRole.DeleteRole(ctx, r.ID); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, mc.AuditRoleDelete, mc.AuditTargetRole, r.Name, r, nil)
+}
+
+// SetRolePermissions заменяет набор прав роли. Права системных ролей изменить нельзя.
+func (u *UsecaseLayer) SetRolePermissions(ctx context.Context, roleName string, permissions []string) (*ent.Role, error) {
+	var res *ent.Role
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = u.setRolePermissions(ctx, roleName, permissions)
+		return err
+	})
+	return res, err
+}
+
+func (u *UsecaseLayer) setRolePermissions(ctx context.Context, roleName string, permissions []string) (*ent.Role, error) {
+	if err := u.policy.Authorize(ctx, mc.PermRoleManage); err != nil {
+		return nil, err
+	}
+	r, err := u.getRole(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsSystem {
+		return nil, me.ErrCantChangeSystemRole
+	}
+	if err := u.checkPermissions(ctx, permissions); err != nil {
+		return nil, err
+	}
+	err = u.repoRole.SetRolePermissions(ctx, r.ID, permissions)
+	if err != nil {
+		return nil, err
+	}
+	roleNew, err := u.repoRole.GetRole(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.audit.Record(ctx, mc.AuditRolePermissionsSet, mc.AuditTargetRole, r.Name, r, roleNew); err != nil {
+		return nil, err
+	}
+	return roleNew, nil
+}
+
+// AssignRoleToUser назначает роль пользователю.
+func (u *UsecaseLayer) AssignRoleToUser(ctx context.Context, roleName, email string) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.assignRoleToUser(ctx, roleName, email)
+	})
+}
+
+func (u *UsecaseLayer) assignRoleToUser(ctx context.Context, roleName, email string) error {
+	if err := u.policy.Authorize(ctx, mc.PermRoleManage); err != nil {
+		return err
+	}
+	r, err := u.getRole(ctx, roleName)
+	if err != nil {
+		return err
+	}
+	uDB, err := u.getUser(ctx, email)
+	if err != nil {
+		return err
+	}
+	assigned, err := u.repoRole.AssignToUser(ctx, r.ID, uDB.ID)
+	if err != nil {
+		return err
+	}
+	if !assigned {
+		return me.ErrUserRoleAlreadyExist
+	}
+	return u.audit.Record(ctx, mc.AuditRoleAssign, mc.AuditTargetUser, uDB.Email, nil, &ent.AuditRole{Role: r.Name})
+}
+
+// RevokeRoleFromUser отзывает роль у пользователя. Роль администратора у root пользователя отозвать нельзя,
+// иначе в сервисе может не остаться ни одного администратора.
+func (u *UsecaseLayer) RevokeRoleFromUser(ctx context.Context, roleName, email string) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.revokeRoleFromUser(ctx, roleName, email)
+	})
+}
+
+func (u *UsecaseLayer) revokeRoleFromUser(ctx context.Context, roleName, email string) error {
+	if err := u.policy.Authorize(ctx, mc.PermRoleManage); err != nil {
+		return err
+	}
+	if roleName == mc.AdminRole && email == viper.GetString("root_email") {
+		return me.ErrCantRevokeRootAdmin
+	}
+	r, err := u.getRole(ctx, roleName)
+	if err != nil {
+		return err
+	}
+	uDB, err := u.getUser(ctx, email)
+	if err != nil {
+		return err
+	}
+	revoked, err := u.repoRole.RevokeFromUser(ctx, r.ID, uDB.ID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return me.ErrUserRoleNotExist
+	}
+	return u.audit.Record(ctx, mc.AuditRoleRevoke, mc.AuditTargetUser, uDB.Email, &ent.AuditRole{Role: r.Name}, nil)
+}
+
+// AssignRoleToGroup назначает роль группе, ее права получают все участники группы и ее подгрупп.
+func (u *UsecaseLayer) AssignRoleToGroup(ctx context.Context, roleName, groupName string) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.assignRoleToGroup(ctx, roleName, groupName)
+	})
+}
+
+func (u *UsecaseLayer) assignRoleToGroup(ctx context.Context, roleName, groupName string) error {
+	if err := u.policy.Authorize(ctx, mc.PermRoleManage); err != nil {
+		return err
+	}
+	r, err := u.getRole(ctx, roleName)
+	if err != nil {
+		return err
+	}
+	g, err := u.getGroup(ctx, groupName)
+	if err != nil {
+		return err
+	}
+	assigned, err := u.repoRole.AssignToGroup(ctx, r.ID, g.ID)
+	if err != nil {
+		return err
+	}
+	if !assigned {
+		return me.ErrGroupRoleAlreadyExist
+	}
+	return u.audit.Record(ctx, mc.AuditRoleAssign, mc.AuditTargetGroup, g.Name, nil, &ent.AuditRole{Role: r.Name})
+}
+
+// RevokeRoleFromGroup отзывает роль у группы.
+func (u *UsecaseLayer) RevokeRoleFromGroup(ctx context.Context, roleName, groupName string) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.revokeRoleFromGroup(ctx, roleName, groupName)
+	})
+}
+
+func (u *UsecaseLayer) revokeRoleFromGroup(ctx context.Context, roleName, groupName string) error {
+	if err := u.policy.Authorize(ctx, mc.PermRoleManage); err != nil {
+		return err
+	}
+	r, err := u.getRole(ctx, roleName)
+	if err != nil {
+		return err
+	}
+	g, err := u.getGroup(ctx, groupName)
+	if err != nil {
+		return err
+	}
+	revoked, err := u.repoRole.RevokeFromGroup(ctx, r.ID, g.ID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return me.ErrGroupRoleNotExist
+	}
+	return u.audit.Record(ctx, mc.AuditRoleRevoke, mc.AuditTargetGroup, g.Name, &ent.AuditRole{Role: r.Name}, nil)
+}
+
+// GetUserPermissions возвращает права пользователя с учетом ролей его групп. Получить их может сам
+// пользователь или пользователь с правом role.manage.
+func (u *UsecaseLayer) GetUserPermissions(ctx context.Context, email string) ([]string, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if principal.Email != email {
+		if err := u.policy.Authorize(ctx, mc.PermRoleManage); err != nil {
+			return nil, err
+		}
+	}
+	uDB, err := u.getUser(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	return u.repoRole.GetUserPermissions(ctx, uDB.ID)
+}
+
+// checkPermissions проверяет, что все переданные права существуют.
+func (u *UsecaseLayer) checkPermissions(ctx context.Context, permissions []string) error {
+	if len(permissions) == 0 {
+		return nil
+	}
+	unique := make(map[string]struct{}, len(permissions))
+	for _, p := range permissions {
+		unique[p] = struct{}{}
+	}
+	count, err := u.repoRole.CountPermissions(ctx, permissions)
+	if err != nil {
+		return err
+	}
+	if count != len(unique) {
+		return me.ErrPermissionNotExist
+	}
+	return nil
+}
+
+func (u *UsecaseLayer) getRole(ctx context.Context, roleName string) (*ent.Role, error) {
+	r, err := u.repoRole.GetRole(ctx, roleName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrRoleNotExist
+		}
+		return nil, err
+	}
+	return r, nil
+}
+
+func (u *UsecaseLayer) getUser(ctx context.Context, email string) (*ent.User, error) {
+	uDB, err := u.repoUser.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrUserNotExist
+		}
+		return nil, err
+	}
+	return uDB, nil
+}
+
+func (u *UsecaseLayer) getGroup(ctx context.Context, groupName string) (*ent.Group, error) {
+	g, err := u.repoGroup.GetGroup(ctx, groupName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrGroupNotExist
+		}
+		return nil, err
+	}
+	return g, nil
+}
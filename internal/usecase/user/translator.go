@@ -1,8 +1,11 @@
 package user
 
 import (
+	"unicode/utf8"
+
 	"github.com/cantylv/authorization-service/internal/entity"
 	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/services/oidc"
 )
 
 func newUserFromSignUpForm(data *dto.CreateData, hashedPassword string) *entity.User {
@@ -13,3 +16,31 @@ func newUserFromSignUpForm(data *dto.CreateData, hashedPassword string) *entity.
 		LastName:  data.LastName,
 	}
 }
+
+// newUserFromOpenIDClaims создает пользователя по данным OpenID провайдера. Если провайдер не передал
+// имя или фамилию подходящей длины, подставляются значения по умолчанию.
+func newUserFromOpenIDClaims(claims *oidc.Claims, hashedPassword string) *entity.User {
+	firstName, lastName := claims.GivenName, claims.FamilyName
+	if n := utf8.RuneCountInString(firstName); n < 2 || n > 50 {
+		firstName = "OpenID"
+	}
+	if n := utf8.RuneCountInString(lastName); n < 2 || n > 50 {
+		lastName = "User"
+	}
+	return &entity.User{
+		Email:     claims.Email,
+		Password:  hashedPassword,
+		FirstName: firstName,
+		LastName:  lastName,
+	}
+}
+
+// auditState состояние пользователя для журнала аудита, хэш пароля в журнал не попадает.
+func auditState(u *entity.User) *entity.AuditUser {
+	return &entity.AuditUser{
+		ID:        u.ID,
+		Email:     u.Email,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+	}
+}
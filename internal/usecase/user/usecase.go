@@ -4,40 +4,83 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	ent "github.com/cantylv/authorization-service/internal/entity"
 	"github.com/cantylv/authorization-service/internal/entity/dto"
 	"github.com/cantylv/authorization-service/internal/repo/group"
+	rOidc "github.com/cantylv/authorization-service/internal/repo/oidc"
+	"github.com/cantylv/authorization-service/internal/repo/privelege"
+	"github.com/cantylv/authorization-service/internal/repo/token"
 	"github.com/cantylv/authorization-service/internal/repo/user"
+	"github.com/cantylv/authorization-service/internal/usecase/audit"
+	"github.com/cantylv/authorization-service/internal/usecase/policy"
 	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
 	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/cantylv/authorization-service/services/oidc"
+	"github.com/cantylv/authorization-service/services/postgres"
 	"github.com/spf13/viper"
+	"golang.org/x/oauth2"
 )
 
 type Usecase interface {
 	Create(ctx context.Context, authData *dto.CreateData) (*ent.User, error)
 	Read(ctx context.Context, email string) (*ent.User, error)
-	Delete(ctx context.Context, userEmail, userEmailDelete string) error
+	Delete(ctx context.Context, userEmail string) error
+	Login(ctx context.Context, loginData *dto.LoginData) (*dto.TokenPair, error)
+	Refresh(ctx context.Context, refreshData *dto.RefreshData) (*dto.TokenPair, error)
+	Logout(ctx context.Context, refreshData *dto.RefreshData) error
+	WhoAmI(ctx context.Context) (*ent.User, error)
+	OpenIDLogin(ctx context.Context) (string, error)
+	OpenIDCallback(ctx context.Context, callbackData *dto.OpenIDCallbackData) (*dto.TokenPair, error)
 }
 
 var _ Usecase = (*UsecaseLayer)(nil)
 
 type UsecaseLayer struct {
-	repoUser  user.Repo
-	repoGroup group.Repo
+	uow           postgres.UnitOfWork
+	policy        policy.Policy
+	audit         audit.Recorder
+	repoUser      user.Repo
+	repoGroup     group.Repo
+	repoPrivelege privelege.Repo
+	repoToken     token.Repo
+	repoOidc      rOidc.Repo
+	oidcProvider  oidc.Provider
 }
 
-// NewUsecaseLayer возращает структуру уровня usecase для работы с пользователями
-func NewUsecaseLayer(repoUser user.Repo, repoGroup group.Repo) *UsecaseLayer {
+// NewUsecaseLayer возращает структуру уровня usecase для работы с пользователями.
+// Изменяющие методы выполняются в одной транзакции через uow, права проверяются политикой доступа,
+// изменения записываются в журнал аудита в той же транзакции.
+func NewUsecaseLayer(uow postgres.UnitOfWork, policy policy.Policy, audit audit.Recorder, repoUser user.Repo, repoGroup group.Repo, repoPrivelege privelege.Repo, repoToken token.Repo,
+	repoOidc rOidc.Repo, oidcProvider oidc.Provider) *UsecaseLayer {
 	return &UsecaseLayer{
-		repoUser:  repoUser,
-		repoGroup: repoGroup,
+		uow:           uow,
+		policy:        policy,
+		audit:         audit,
+		repoUser:      repoUser,
+		repoGroup:     repoGroup,
+		repoPrivelege: repoPrivelege,
+		repoToken:     repoToken,
+		repoOidc:      repoOidc,
+		oidcProvider:  oidcProvider,
 	}
 }
 
 // Create создает пользователя. Пароль, передаваемый в теле запроса, хэшируется с помощью соли
 // алгоритмом Argon2.
 func (u *UsecaseLayer) Create(ctx context.Context, authData *dto.CreateData) (*ent.User, error) {
+	var res *ent.User
+	err := u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = u.create(ctx, authData)
+		return err
+	})
+	return res, err
+}
+
+func (u *UsecaseLayer) create(ctx context.Context, authData *dto.CreateData) (*ent.User, error) {
 	// проверяем, существует ли уже пользователь c такой почтой
 	// если да, то возвращаем ошибку
 	uDB, err := u.repoUser.GetByEmail(ctx, authData.Email)
@@ -56,6 +99,9 @@ func (u *UsecaseLayer) Create(ctx context.Context, authData *dto.CreateData) (*e
 	if err != nil {
 		return nil, err
 	}
+	if err := u.audit.Record(ctx, mc.AuditUserCreate, mc.AuditTargetUser, userNew.Email, nil, auditState(userNew)); err != nil {
+		return nil, err
+	}
 	return userNew, nil
 }
 
@@ -72,12 +118,29 @@ func (u *UsecaseLayer) Read(ctx context.Context, email string) (*ent.User, error
 }
 
 // Delete удаляет пользователя из системы.
-// Нельзя удалить root пользователя, а также любого ответственного за группу. Также удалить пользователя
-// может только root, либо пользователь сам себя удаляет.
-func (u *UsecaseLayer) Delete(ctx context.Context, userEmail, userEmailDelete string) error {
+// Нельзя удалить root пользователя, а также единственного ответственного за группу. Если у группы есть другие
+// ответственные, то пользователь перестает быть ответственным за нее вместе с удалением. Удалить пользователя может
+// пользователь с правом user.delete, либо пользователь сам себя удаляет.
+func (u *UsecaseLayer) Delete(ctx context.Context, userEmail string) error {
+	return u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		return u.deleteUser(ctx, userEmail)
+	})
+}
+
+func (u *UsecaseLayer) deleteUser(ctx context.Context, userEmail string) error {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return err
+	}
 	if userEmail == viper.GetString("root_email") {
 		return me.ErrCantDeleteRoot
 	}
+	// удалить пользователя из системы может пользователь с правом user.delete, либо сам пользователь
+	if userEmail != principal.Email {
+		if err := u.policy.Authorize(ctx, mc.PermUserDelete); err != nil {
+			return err
+		}
+	}
 	// проверка существования пользователя, которого удаляем
 	uDB, err := u.repoUser.GetByEmail(ctx, userEmail)
 	if err != nil {
@@ -86,21 +149,257 @@ func (u *UsecaseLayer) Delete(ctx context.Context, userEmail, userEmailDelete st
 		}
 		return err
 	}
-	// проверяем, что пользователь не является ответственным за организации
+	// проверяем, что пользователь не является единственным ответственным за организации
 	groups, err := u.repoGroup.OwnerGroups(ctx, uDB.ID)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return err
 	}
-	if len(groups) != 0 {
-		return me.ErrUserIsResponsible
+	for _, g := range groups {
+		owners, err := u.repoGroup.CountOwners(ctx, g.ID)
+		if err != nil {
+			return err
+		}
+		if owners <= 1 {
+			return me.ErrUserIsResponsible
+		}
+	}
+	// основным ответственным за группу становится один из оставшихся совладельцев
+	for _, g := range groups {
+		if err := u.repoGroup.RemoveOwner(ctx, g.ID, uDB.ID); err != nil {
+			return err
+		}
+	}
+	// отзываем все refresh токены пользователя, чтобы он не смог продлить уже выданные токены доступа
+	if err := u.repoToken.RevokeAllByUser(ctx, uDB.ID); err != nil {
+		return err
+	}
+	if err := u.repoUser.DeleteByEmail(ctx, userEmail); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, mc.AuditUserDelete, mc.AuditTargetUser, uDB.Email, auditState(uDB), nil)
+}
+
+// Login проверяет пару почта/пароль и выпускает подписанный токен доступа.
+// Для несуществующего пользователя и неверного пароля возвращается одна и та же ошибка,
+// чтобы нельзя было перебором узнать, зарегистрирована ли почта.
+func (u *UsecaseLayer) Login(ctx context.Context, loginData *dto.LoginData) (*dto.TokenPair, error) {
+	uDB, err := u.repoUser.GetByEmail(ctx, loginData.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrInvalidCredentials
+		}
+		return nil, err
+	}
+	if !f.IsPasswordsEqual(loginData.Password, uDB.Password) {
+		return nil, me.ErrInvalidCredentials
+	}
+	return u.issueTokenPair(ctx, uDB)
+}
+
+// Refresh обменивает refresh токен на новую пару токенов. Предъявленный refresh токен отзывается.
+// Повторное предъявление уже отозванного токена означает, что он был украден, поэтому в этом случае
+// отзываются все refresh токены пользователя.
+func (u *UsecaseLayer) Refresh(ctx context.Context, refreshData *dto.RefreshData) (*dto.TokenPair, error) {
+	tDB, err := u.repoToken.GetByHash(ctx, f.HashRefreshToken(refreshData.RefreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	if tDB.RevokedAt != nil {
+		if err := u.repoToken.RevokeAllByUser(ctx, tDB.UserID); err != nil {
+			return nil, err
+		}
+		return nil, me.ErrInvalidRefreshToken
+	}
+	if time.Now().After(tDB.ExpiresAt) {
+		return nil, me.ErrInvalidRefreshToken
+	}
+	uDB, err := u.repoUser.GetByID(ctx, tDB.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	refreshToken, err := f.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	_, err = u.repoToken.Rotate(ctx, tDB.ID, f.HashRefreshToken(refreshToken), time.Now().Add(viper.GetDuration("auth.refresh_ttl")))
+	if err != nil {
+		return nil, err
+	}
+	return u.newTokenPair(ctx, uDB, refreshToken)
+}
+
+// Logout отзывает refresh токен. Выданный ранее токен доступа остается действительным до истечения своего срока.
+func (u *UsecaseLayer) Logout(ctx context.Context, refreshData *dto.RefreshData) error {
+	tDB, err := u.repoToken.GetByHash(ctx, f.HashRefreshToken(refreshData.RefreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return me.ErrInvalidRefreshToken
+		}
+		return err
+	}
+	return u.repoToken.Revoke(ctx, tDB.ID)
+}
+
+// OpenIDLogin начинает вход через OpenID провайдера: сохраняет state, nonce и PKCE verifier попытки
+// входа и возвращает адрес страницы входа провайдера.
+func (u *UsecaseLayer) OpenIDLogin(ctx context.Context) (string, error) {
+	state, err := f.NewRandomToken()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := f.NewRandomToken()
+	if err != nil {
+		return "", err
+	}
+	attempt := &ent.OpenIDState{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: oauth2.GenerateVerifier(),
+		ExpiresAt:    time.Now().Add(viper.GetDuration("oidc.state_ttl")),
+	}
+	authURL, err := u.oidcProvider.AuthCodeURL(ctx, attempt.State, attempt.Nonce, attempt.CodeVerifier)
+	if err != nil {
+		return "", err
+	}
+	if err := u.repoOidc.SaveState(ctx, attempt); err != nil {
+		return "", err
+	}
+	return authURL, nil
+}
+
+// OpenIDCallback завершает вход через OpenID провайдера. Пользователь ищется по привязанной учетной записи
+// провайдера, затем по подтвержденной почте. Если пользователя нет, он создается и добавляется в группу 'users'.
+func (u *UsecaseLayer) OpenIDCallback(ctx context.Context, callbackData *dto.OpenIDCallbackData) (*dto.TokenPair, error) {
+	attempt, err := u.repoOidc.PopState(ctx, callbackData.State)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, me.ErrInvalidOpenIDState
+		}
+		return nil, err
+	}
+	if time.Now().After(attempt.ExpiresAt) {
+		return nil, me.ErrInvalidOpenIDState
+	}
+	claims, err := u.oidcProvider.Exchange(ctx, callbackData.Code, attempt.Nonce, attempt.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, me.ErrOpenIDEmailNotVerify
+	}
+	// поиск, создание и привязка пользователя выполняются атомарно, чтобы параллельные входы
+	// одного пользователя не создали его дважды
+	var uDB *ent.User
+	err = u.uow.Do(ctx, postgres.Serializable, func(ctx context.Context) error {
+		var err error
+		uDB, err = u.getOrCreateOpenIDUser(ctx, claims)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.issueTokenPair(ctx, uDB)
+}
+
+func (u *UsecaseLayer) getOrCreateOpenIDUser(ctx context.Context, claims *oidc.Claims) (*ent.User, error) {
+	userID, err := u.repoOidc.GetUserID(ctx, claims.Issuer, claims.Subject)
+	if err == nil {
+		return u.repoUser.GetByID(ctx, userID)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	// учетная запись провайдера еще не привязана, ищем пользователя по почте
+	uDB, err := u.repoUser.GetByEmail(ctx, claims.Email)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		// пользователь входит по паролю только после его сброса, поэтому пароль генерируется случайно
+		password, err := f.NewRandomToken()
+		if err != nil {
+			return nil, err
+		}
+		hashedPassword, err := f.GetHashedPassword(password)
+		if err != nil {
+			return nil, err
+		}
+		uDB, err = u.repoUser.Create(ctx, newUserFromOpenIDClaims(claims, hashedPassword))
+		if err != nil {
+			return nil, err
+		}
+		if err := u.audit.Record(ctx, mc.AuditUserCreate, mc.AuditTargetUser, uDB.Email, nil, auditState(uDB)); err != nil {
+			return nil, err
+		}
 	}
-	// случай, когда пользователь удаляет сам себя
-	if userEmail == userEmailDelete {
-		return u.repoUser.DeleteByEmail(ctx, userEmail)
+	if err := u.repoOidc.LinkIdentity(ctx, uDB.ID, claims.Issuer, claims.Subject); err != nil {
+		return nil, err
 	}
-	// удалить пользователя из системы может только root пользователь
-	if userEmailDelete != viper.GetString("root_email") {
-		return me.ErrOnlyRootCanDeleteUser
+	return uDB, nil
+}
+
+// issueTokenPair выпускает пользователю новый refresh токен и токен доступа.
+func (u *UsecaseLayer) issueTokenPair(ctx context.Context, uDB *ent.User) (*dto.TokenPair, error) {
+	refreshToken, err := f.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	_, err = u.repoToken.Create(ctx, uDB.ID, f.HashRefreshToken(refreshToken), time.Now().Add(viper.GetDuration("auth.refresh_ttl")))
+	if err != nil {
+		return nil, err
+	}
+	return u.newTokenPair(ctx, uDB, refreshToken)
+}
+
+// newTokenPair выпускает токен доступа с группами и агентами пользователя и объединяет его с refresh токеном.
+func (u *UsecaseLayer) newTokenPair(ctx context.Context, uDB *ent.User, refreshToken string) (*dto.TokenPair, error) {
+	principal, err := u.newPrincipal(ctx, uDB)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := f.NewAccessToken(principal)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(viper.GetDuration("auth.access_ttl").Seconds()),
+	}, nil
+}
+
+// newPrincipal собирает группы пользователя и агентов, доступных ему напрямую или через группы.
+func (u *UsecaseLayer) newPrincipal(ctx context.Context, uDB *ent.User) (*ent.Principal, error) {
+	groups, err := u.repoGroup.GetUserGroups(ctx, uDB.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	agents, err := u.repoPrivelege.GetEffectiveUserAgents(ctx, uDB.ID)
+	if err != nil {
+		return nil, err
+	}
+	principal := &ent.Principal{ID: uDB.ID, Email: uDB.Email}
+	for _, g := range groups {
+		principal.Groups = append(principal.Groups, g.Name)
+	}
+	for _, a := range agents {
+		principal.Agents = append(principal.Agents, a.Name)
+	}
+	return principal, nil
+}
+
+// WhoAmI возвращает данные аутентифицированного пользователя.
+func (u *UsecaseLayer) WhoAmI(ctx context.Context) (*ent.User, error) {
+	principal, err := f.GetCtxPrincipal(ctx)
+	if err != nil {
+		return nil, err
 	}
-	return u.repoUser.DeleteByEmail(ctx, userEmail)
+	return u.Read(ctx, principal.Email)
 }
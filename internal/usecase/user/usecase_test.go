@@ -0,0 +1,342 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	"github.com/cantylv/authorization-service/internal/repo/group"
+	"github.com/cantylv/authorization-service/internal/repo/privelege"
+	"github.com/cantylv/authorization-service/internal/repo/token"
+	"github.com/cantylv/authorization-service/internal/repo/user"
+	f "github.com/cantylv/authorization-service/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/cantylv/authorization-service/services/oidc"
+	"github.com/cantylv/authorization-service/services/oidc/oidctest"
+	"github.com/cantylv/authorization-service/services/postgres"
+	"github.com/spf13/viper"
+)
+
+// Тесты входа через OpenID провайдера: провайдер поднимается на httptest сервере, ID токен проверяет настоящий
+// oidc.ProviderLayer, а репозитории заменены хранилищами в памяти.
+
+type fakeUnitOfWork struct{}
+
+func (fakeUnitOfWork) Do(ctx context.Context, _ postgres.IsoLevel, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeOidcRepo struct {
+	states     map[string]*ent.OpenIDState
+	identities map[string]string
+}
+
+func (r *fakeOidcRepo) SaveState(_ context.Context, state *ent.OpenIDState) error {
+	r.states[state.State] = state
+	return nil
+}
+
+func (r *fakeOidcRepo) PopState(_ context.Context, state string) (*ent.OpenIDState, error) {
+	s, ok := r.states[state]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	delete(r.states, state)
+	return s, nil
+}
+
+func (r *fakeOidcRepo) GetUserID(_ context.Context, issuer, subject string) (string, error) {
+	userID, ok := r.identities[issuer+" "+subject]
+	if !ok {
+		return "", sql.ErrNoRows
+	}
+	return userID, nil
+}
+
+func (r *fakeOidcRepo) LinkIdentity(_ context.Context, userID, issuer, subject string) error {
+	r.identities[issuer+" "+subject] = userID
+	return nil
+}
+
+type fakeUserRepo struct {
+	user.Repo
+	users   map[string]*ent.User
+	created []*ent.User
+}
+
+func (r *fakeUserRepo) GetByEmail(_ context.Context, email string) (*ent.User, error) {
+	u, ok := r.users[email]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return u, nil
+}
+
+func (r *fakeUserRepo) GetByID(_ context.Context, id string) (*ent.User, error) {
+	for _, u := range r.users {
+		if u.ID == id {
+			return u, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *fakeUserRepo) Create(_ context.Context, initData *ent.User) (*ent.User, error) {
+	u := *initData
+	u.ID = fmt.Sprintf("00000000-0000-0000-0000-%012d", len(r.users)+1)
+	r.users[u.Email] = &u
+	r.created = append(r.created, &u)
+	return &u, nil
+}
+
+type fakeGroupRepo struct {
+	group.Repo
+}
+
+func (fakeGroupRepo) GetUserGroups(context.Context, string) ([]*ent.Group, error) {
+	return nil, nil
+}
+
+type fakePrivelegeRepo struct {
+	privelege.Repo
+}
+
+func (fakePrivelegeRepo) GetEffectiveUserAgents(context.Context, string) ([]*ent.Agent, error) {
+	return nil, nil
+}
+
+type fakeTokenRepo struct {
+	token.Repo
+}
+
+func (fakeTokenRepo) Create(_ context.Context, userID, tokenHash string, expiresAt time.Time) (*ent.RefreshToken, error) {
+	return &ent.RefreshToken{UserID: userID, TokenHash: tokenHash, ExpiresAt: expiresAt}, nil
+}
+
+type fakeRecorder struct {
+	actions []string
+}
+
+func (r *fakeRecorder) Record(_ context.Context, action, _, _ string, _, _ any) error {
+	r.actions = append(r.actions, action)
+	return nil
+}
+
+type openIDFixture struct {
+	provider *oidctest.Provider
+	repoOidc *fakeOidcRepo
+	repoUser *fakeUserRepo
+	audit    *fakeRecorder
+	usecase  *UsecaseLayer
+}
+
+func newOpenIDFixture(t *testing.T) *openIDFixture {
+	t.Helper()
+	fx := &openIDFixture{
+		provider: oidctest.NewProvider(t),
+		repoOidc: &fakeOidcRepo{states: make(map[string]*ent.OpenIDState), identities: make(map[string]string)},
+		repoUser: &fakeUserRepo{users: make(map[string]*ent.User)},
+		audit:    &fakeRecorder{},
+	}
+	viper.Set("oidc.issuer", fx.provider.Issuer())
+	viper.Set("oidc.client_id", oidctest.ClientID)
+	viper.Set("oidc.client_secret", oidctest.ClientSecret)
+	viper.Set("oidc.redirect_url", "http://localhost/api/v1/oidc/callback")
+	viper.Set("oidc.state_ttl", time.Minute)
+	viper.Set("auth.secret", "test-secret")
+	viper.Set("auth.access_ttl", time.Minute)
+	viper.Set("auth.refresh_ttl", time.Hour)
+	t.Cleanup(func() { viper.Set("oidc.issuer", "") })
+
+	fx.usecase = NewUsecaseLayer(fakeUnitOfWork{}, nil, fx.audit, fx.repoUser, fakeGroupRepo{}, fakePrivelegeRepo{},
+		fakeTokenRepo{}, fx.repoOidc, oidc.NewProviderLayer())
+	return fx
+}
+
+// login начинает вход и возвращает данные обратного вызова провайдера после входа пользователя с claims.
+func (fx *openIDFixture) login(t *testing.T, claims *oidctest.Claims) *dto.OpenIDCallbackData {
+	t.Helper()
+	authURL, err := fx.usecase.OpenIDLogin(context.Background())
+	if err != nil {
+		t.Fatalf("OpenIDLogin: %v", err)
+	}
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse auth url: %v", err)
+	}
+	return &dto.OpenIDCallbackData{
+		Code:  fx.provider.Authorize(t, authURL, claims),
+		State: u.Query().Get("state"),
+	}
+}
+
+func verifiedUser() *oidctest.Claims {
+	return &oidctest.Claims{
+		Subject:       "subject",
+		Email:         "user@example.com",
+		EmailVerified: true,
+		GivenName:     "Ivan",
+		FamilyName:    "Ivanov",
+	}
+}
+
+func TestOpenIDCallbackStateMismatch(t *testing.T) {
+	fx := newOpenIDFixture(t)
+	callbackData := fx.login(t, verifiedUser())
+	callbackData.State = "unknown-state"
+
+	if _, err := fx.usecase.OpenIDCallback(context.Background(), callbackData); !errors.Is(err, me.ErrInvalidOpenIDState) {
+		t.Fatalf("OpenIDCallback error = %v, want %v", err, me.ErrInvalidOpenIDState)
+	}
+}
+
+func TestOpenIDCallbackExpiredState(t *testing.T) {
+	fx := newOpenIDFixture(t)
+	callbackData := fx.login(t, verifiedUser())
+	fx.repoOidc.states[callbackData.State].ExpiresAt = time.Now().Add(-time.Second)
+
+	if _, err := fx.usecase.OpenIDCallback(context.Background(), callbackData); !errors.Is(err, me.ErrInvalidOpenIDState) {
+		t.Fatalf("OpenIDCallback error = %v, want %v", err, me.ErrInvalidOpenIDState)
+	}
+}
+
+func TestOpenIDCallbackRejectsUnauthenticated(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims func(c *oidctest.Claims)
+		// attempt меняет сохраненную попытку входа
+		attempt func(a *ent.OpenIDState)
+	}{
+		{
+			name:   "nonce mismatch",
+			claims: func(c *oidctest.Claims) { c.Nonce = "other-nonce" },
+		},
+		{
+			name:    "pkce verifier does not match challenge",
+			attempt: func(a *ent.OpenIDState) { a.CodeVerifier = "other-verifier-other-verifier-other-verifier" },
+		},
+		{
+			name:   "id token with bad signature",
+			claims: func(c *oidctest.Claims) { c.SigningKey = oidctest.NewKey(t) },
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fx := newOpenIDFixture(t)
+			claims := verifiedUser()
+			if tc.claims != nil {
+				tc.claims(claims)
+			}
+			callbackData := fx.login(t, claims)
+			if tc.attempt != nil {
+				tc.attempt(fx.repoOidc.states[callbackData.State])
+			}
+
+			_, err := fx.usecase.OpenIDCallback(context.Background(), callbackData)
+			if !errors.Is(err, me.ErrOpenIDAuthentication) {
+				t.Fatalf("OpenIDCallback error = %v, want %v", err, me.ErrOpenIDAuthentication)
+			}
+			if len(fx.repoUser.created) != 0 || len(fx.repoOidc.identities) != 0 {
+				t.Fatalf("user must not be created or linked, created %d, linked %d", len(fx.repoUser.created), len(fx.repoOidc.identities))
+			}
+		})
+	}
+}
+
+func TestOpenIDCallbackRejectsUnverifiedEmail(t *testing.T) {
+	fx := newOpenIDFixture(t)
+	claims := verifiedUser()
+	claims.EmailVerified = false
+
+	_, err := fx.usecase.OpenIDCallback(context.Background(), fx.login(t, claims))
+	if !errors.Is(err, me.ErrOpenIDEmailNotVerify) {
+		t.Fatalf("OpenIDCallback error = %v, want %v", err, me.ErrOpenIDEmailNotVerify)
+	}
+	if len(fx.repoUser.created) != 0 || len(fx.repoOidc.identities) != 0 {
+		t.Fatalf("user must not be created or linked, created %d, linked %d", len(fx.repoUser.created), len(fx.repoOidc.identities))
+	}
+}
+
+func TestOpenIDCallbackProvisionsNewUser(t *testing.T) {
+	fx := newOpenIDFixture(t)
+	claims := verifiedUser()
+
+	pair, err := fx.usecase.OpenIDCallback(context.Background(), fx.login(t, claims))
+	if err != nil {
+		t.Fatalf("OpenIDCallback: %v", err)
+	}
+	if len(fx.repoUser.created) != 1 {
+		t.Fatalf("created %d users, want 1", len(fx.repoUser.created))
+	}
+	created := fx.repoUser.created[0]
+	if created.Email != claims.Email || created.FirstName != claims.GivenName || created.LastName != claims.FamilyName {
+		t.Fatalf("created user = %+v, want data from id token %+v", created, claims)
+	}
+	if created.Password == "" {
+		t.Fatal("created user must have a random password hash")
+	}
+	if userID := fx.repoOidc.identities[fx.provider.Issuer()+" "+claims.Subject]; userID != created.ID {
+		t.Fatalf("identity linked to %q, want %q", userID, created.ID)
+	}
+	if len(fx.audit.actions) != 1 || fx.audit.actions[0] != mc.AuditUserCreate {
+		t.Fatalf("audit actions = %v, want [%s]", fx.audit.actions, mc.AuditUserCreate)
+	}
+	assertAccessTokenFor(t, pair, created)
+
+	// повторный вход находит пользователя по привязанной учетной записи
+	if _, err := fx.usecase.OpenIDCallback(context.Background(), fx.login(t, claims)); err != nil {
+		t.Fatalf("second OpenIDCallback: %v", err)
+	}
+	if len(fx.repoUser.created) != 1 {
+		t.Fatalf("second login created %d users, want none", len(fx.repoUser.created)-1)
+	}
+}
+
+func TestOpenIDCallbackLinksExistingUserByEmail(t *testing.T) {
+	fx := newOpenIDFixture(t)
+	claims := verifiedUser()
+	existing := &ent.User{
+		ID:        "11111111-1111-1111-1111-111111111111",
+		Email:     claims.Email,
+		Password:  "hash",
+		FirstName: "Petr",
+		LastName:  "Petrov",
+	}
+	fx.repoUser.users[existing.Email] = existing
+
+	pair, err := fx.usecase.OpenIDCallback(context.Background(), fx.login(t, claims))
+	if err != nil {
+		t.Fatalf("OpenIDCallback: %v", err)
+	}
+	if len(fx.repoUser.created) != 0 {
+		t.Fatalf("created %d users, want none", len(fx.repoUser.created))
+	}
+	if userID := fx.repoOidc.identities[fx.provider.Issuer()+" "+claims.Subject]; userID != existing.ID {
+		t.Fatalf("identity linked to %q, want %q", userID, existing.ID)
+	}
+	if len(fx.audit.actions) != 0 {
+		t.Fatalf("audit actions = %v, want none", fx.audit.actions)
+	}
+	assertAccessTokenFor(t, pair, existing)
+}
+
+func assertAccessTokenFor(t *testing.T, pair *dto.TokenPair, u *ent.User) {
+	t.Helper()
+	if pair.RefreshToken == "" {
+		t.Fatal("refresh token is empty")
+	}
+	principal, err := f.ParseAccessToken(pair.AccessToken)
+	if err != nil {
+		t.Fatalf("ParseAccessToken: %v", err)
+	}
+	if principal.ID != u.ID || principal.Email != u.Email {
+		t.Fatalf("access token principal = %+v, want user %s %s", principal, u.ID, u.Email)
+	}
+}
@@ -1,8 +1,10 @@
 package functions
 
 import (
+	"context"
 	"net/http"
 
+	ent "github.com/cantylv/authorization-service/internal/entity"
 	mc "github.com/cantylv/authorization-service/internal/utils/myconstants"
 	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
 )
@@ -16,3 +18,30 @@ func GetCtxRequestID(r *http.Request) (string, error) {
 	}
 	return requestID, nil
 }
+
+// GetRequestIDFromCtx возвращает идентификатор запроса, который middleware положил в контекст, или пустую строку.
+func GetRequestIDFromCtx(ctx context.Context) string {
+	requestID, _ := ctx.Value(mc.AccessKey(mc.RequestID)).(string)
+	return requestID
+}
+
+// GetRealIPFromCtx возвращает IP-адрес клиента из заголовка X-Real-IP, который middleware положил в контекст,
+// или пустую строку.
+func GetRealIPFromCtx(ctx context.Context) string {
+	realIP, _ := ctx.Value(mc.AccessKey(mc.RealIP)).(string)
+	return realIP
+}
+
+// GetCtxPrincipal возвращает аутентифицированного пользователя, которого middleware положил в контекст запроса.
+func GetCtxPrincipal(ctx context.Context) (*ent.Principal, error) {
+	principal, ok := ctx.Value(mc.AccessKey(mc.Principal)).(*ent.Principal)
+	if !ok || principal == nil {
+		return nil, me.ErrUnauthorized
+	}
+	return principal, nil
+}
+
+// SetCtxPrincipal кладет аутентифицированного пользователя в контекст.
+func SetCtxPrincipal(ctx context.Context, principal *ent.Principal) context.Context {
+	return context.WithValue(ctx, mc.AccessKey(mc.Principal), principal)
+}
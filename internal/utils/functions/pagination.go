@@ -0,0 +1,29 @@
+package functions
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cantylv/authorization-service/internal/entity/dto"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+)
+
+// GetQueryPagination читает необязательные параметры запроса limit и offset и проверяет страницу.
+func GetQueryPagination(r *http.Request) (dto.Pagination, error) {
+	var page dto.Pagination
+	var err error
+	query := r.URL.Query()
+	if limit := query.Get("limit"); limit != "" {
+		page.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return page, me.ErrInvalidPagination
+		}
+	}
+	if offset := query.Get("offset"); offset != "" {
+		page.Offset, err = strconv.Atoi(offset)
+		if err != nil {
+			return page, me.ErrInvalidPagination
+		}
+	}
+	return page, page.Validate()
+}
@@ -0,0 +1,80 @@
+package functions
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/viper"
+)
+
+type accessClaims struct {
+	Email  string   `json:"email"`
+	Groups []string `json:"groups"`
+	Agents []string `json:"agents"`
+	jwt.RegisteredClaims
+}
+
+// NewAccessToken выпускает подписанный (HS256) токен доступа для пользователя. Время жизни токена
+// задается параметром 'auth.access_ttl'.
+func NewAccessToken(principal *ent.Principal) (string, error) {
+	now := time.Now()
+	claims := accessClaims{
+		Email:  principal.Email,
+		Groups: principal.Groups,
+		Agents: principal.Agents,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   principal.ID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(viper.GetDuration("auth.access_ttl"))),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(viper.GetString("auth.secret")))
+}
+
+// ParseAccessToken проверяет подпись и срок действия токена, возвращает пользователя, которому он был выдан.
+func ParseAccessToken(tokenString string) (*ent.Principal, error) {
+	var claims accessClaims
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(t *jwt.Token) (any, error) {
+		return []byte(viper.GetString("auth.secret")), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, errors.Join(me.ErrInvalidToken, err)
+	}
+	if claims.Subject == "" || claims.Email == "" {
+		return nil, me.ErrInvalidToken
+	}
+	return &ent.Principal{
+		ID:     claims.Subject,
+		Email:  claims.Email,
+		Groups: claims.Groups,
+		Agents: claims.Agents,
+	}, nil
+}
+
+// NewRefreshToken генерирует случайный непрозрачный refresh токен. В базе данных хранится только его хэш.
+func NewRefreshToken() (string, error) {
+	return NewRandomToken()
+}
+
+// NewRandomToken генерирует криптографически стойкую случайную строку, безопасную для использования в URL.
+func NewRandomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashRefreshToken возвращает хэш refresh токена, по которому токен ищется в базе данных.
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -4,8 +4,12 @@ type AccessKey string
 
 // Частые переменные
 const (
-	RequestID = "request_id"
-	XRealIP   = "X-Real-IP"
+	RequestID     = "request_id"
+	XRealIP       = "X-Real-IP"
+	RealIP        = "real_ip"
+	Principal     = "principal"
+	Authorization = "Authorization"
+	BearerPrefix  = "Bearer "
 )
 
 // Настройка хэширования с помощью Argon2
@@ -17,6 +21,160 @@ const (
 	HashLetters = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-"
 )
 
+// Права, которые проверяет политика доступа. Список должен совпадать с таблицей permission.
+const (
+	PermAgentCreate     = "agent.create"
+	PermAgentDelete     = "agent.delete"
+	PermAgentRead       = "agent.read"
+	PermPrivilegeGrant  = "privilege.grant"
+	PermPrivilegeRevoke = "privilege.revoke"
+	PermPrivilegeRead   = "privilege.read"
+	PermGroupCreate     = "group.create"
+	PermGroupApproveBid = "group.approve_bid"
+	PermGroupManage     = "group.manage"
+	PermUserDelete      = "user.delete"
+	PermRoleManage      = "role.manage"
+	PermAuditRead       = "audit.read"
+	PermDecisionLogRead = "decision_log.read"
+
+	PermPrivilegeReadAccessors = "privilege.read_accessors"
+)
+
+// Правила, по которым принимается решение о доступе к агенту, в порядке убывания приоритета
+const (
+	DecisionUserDeny   = "user_deny"
+	DecisionUserAllow  = "user_allow"
+	DecisionGroupDeny  = "group_deny"
+	DecisionGroupAllow = "group_allow"
+	DecisionNoGrant    = "no_grant"
+	// DecisionCheckFailed проверка не дошла до правил, причина в AccessExplanation.FailedCheck
+	DecisionCheckFailed = "check_failed"
+)
+
+// Проверки, которые могут не пройти до применения правил доступа
+const (
+	FailedCheckUserNotExist   = "user_not_exist"
+	FailedCheckAgentNotExist  = "agent_not_exist"
+	FailedCheckActionNotExist = "action_not_exist"
+)
+
+// Статусы заявки на доступ к агенту
+const (
+	AccessRequestInProgress = "in_progress"
+	AccessRequestApproved   = "approved"
+	AccessRequestRejected   = "rejected"
+	AccessRequestCanceled   = "canceled"
+)
+
+// Статусы заявки на создание группы
+const (
+	BidInProgress = "in_progress"
+	BidApproved   = "approved"
+	BidRejected   = "rejected"
+	BidCancelled  = "cancelled"
+)
+
+// Статусы приглашения в группу
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationDeclined = "declined"
+	InvitationRevoked  = "revoked"
+	InvitationExpired  = "expired"
+)
+
+// События, о которых пользователи получают уведомления
+const (
+	EventInvitationCreated  = "group.invitation.created"
+	EventInvitationAccepted = "group.invitation.accepted"
+	EventInvitationDeclined = "group.invitation.declined"
+	EventInvitationRevoked  = "group.invitation.revoked"
+	EventInvitationExpired  = "group.invitation.expired"
+	EventMemberLeft         = "group.member.left"
+	EventMemberKicked       = "group.member.kicked"
+)
+
+// Действия, которые записываются в журнал аудита
+const (
+	AuditAgentCreate         = "agent.create"
+	AuditAgentDelete         = "agent.delete"
+	AuditGroupCreate         = "group.create"
+	AuditGroupRename         = "group.rename"
+	AuditGroupDelete         = "group.delete"
+	AuditGroupMemberAdd      = "group.member.add"
+	AuditGroupMemberRemove   = "group.member.remove"
+	AuditGroupOwnerChange    = "group.owner.change"
+	AuditGroupOwnerAdd       = "group.owner.add"
+	AuditGroupOwnerRemove    = "group.owner.remove"
+	AuditGroupSubgroupAttach = "group.subgroup.attach"
+	AuditGroupSubgroupDetach = "group.subgroup.detach"
+	AuditBidCreate           = "bid.create"
+	AuditBidReview           = "bid.review"
+	AuditBidCancel           = "bid.cancel"
+	AuditPrivilegeGrant      = "privilege.grant"
+	AuditPrivilegeRevoke     = "privilege.revoke"
+	AuditDenyAdd             = "deny.add"
+	AuditDenyRemove          = "deny.remove"
+	AuditRoleCreate          = "role.create"
+	AuditRoleDelete          = "role.delete"
+	AuditRolePermissionsSet  = "role.permissions.set"
+	AuditRoleAssign          = "role.assign"
+	AuditRoleRevoke          = "role.revoke"
+	AuditUserCreate          = "user.create"
+	AuditUserDelete          = "user.delete"
+)
+
+// Типы объектов, над которыми выполняется действие журнала аудита
+const (
+	AuditTargetAgent = "agent"
+	AuditTargetGroup = "group"
+	AuditTargetUser  = "user"
+	AuditTargetBid   = "bid"
+	AuditTargetRole  = "role"
+)
+
+// AccessCacheChannel канал PostgreSQL, в который триггеры отправляют уведомления о сбросе кэша решений о доступе
+const AccessCacheChannel = "access_cache"
+
+// EventGrantExpired событие outbox об удалении привелегии или участия в группе по истечении срока действия
+const EventGrantExpired = "grant.expired"
+
+// OutboxActions действия журнала аудита, о которых подписчики узнают через outbox: изменения агентов,
+// привелегий, запретов, участия в группах и пользователей, то есть все, что меняет результат проверки доступа
+var OutboxActions = map[string]struct{}{
+	AuditAgentCreate:         {},
+	AuditAgentDelete:         {},
+	AuditGroupDelete:         {},
+	AuditGroupMemberAdd:      {},
+	AuditGroupMemberRemove:   {},
+	AuditGroupSubgroupAttach: {},
+	AuditGroupSubgroupDetach: {},
+	AuditPrivilegeGrant:      {},
+	AuditPrivilegeRevoke:     {},
+	AuditDenyAdd:             {},
+	AuditDenyRemove:          {},
+	AuditUserCreate:          {},
+	AuditUserDelete:          {},
+}
+
+// Форматы выгрузки журнала решений о доступе
+const (
+	ExportFormatCSV   = "csv"
+	ExportFormatJSONL = "jsonl"
+)
+
+// Размер страницы списков с пагинацией
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// MaxAccessCheckBatch максимальное количество проверок в одном запросе пакетной проверки доступа
+const MaxAccessCheckBatch = 100
+
+// AdminRole системная роль со всеми правами, назначается root пользователю
+const AdminRole = "admin"
+
 var AllowedStatus = map[string]struct{}{
 	"approved": {},
 	"rejected": {},
@@ -7,46 +7,119 @@ var (
 	ErrInternal             = errors.New("internal server error, please try again later")
 	ErrInvalidData          = errors.New("you has passed invalid data in request data")
 	ErrNoRequestIdInContext = errors.New("no request_id in request context")
+	ErrUnauthorized         = errors.New("you need to log in to perform this action")
+	ErrInvalidToken         = errors.New("access token is invalid or expired")
+	ErrInvalidCredentials   = errors.New("incorrect email or password")
+	ErrInvalidRefreshToken  = errors.New("refresh token is invalid, expired or revoked")
+	ErrOpenIDDisabled       = errors.New("login via openid provider is not configured")
+	ErrInvalidOpenIDState   = errors.New("openid login attempt is invalid or expired, please try again")
+	ErrOpenIDAuthentication = errors.New("openid provider did not authenticate the user")
+	ErrOpenIDEmailNotVerify = errors.New("email of openid account is not verified")
+	ErrPermissionDenied     = errors.New("you don't have permission to perform this action")
 	// CUSTOM
-	ErrOnlyRootCanDeleteUser           = errors.New("only root user can delete user from system")
-	ErrOnlyOwnerCanAddUserToGroup      = errors.New("only owner of group can add user to his group")
-	ErrOnlyOwnerCanDeleteUserFromGroup = errors.New("only owner of group can delete user from his group")
-	ErrOnlyOwnerCanAppointNewOwner     = errors.New("only owner can attain new owner")
-	ErrOnlyRootCanBeOwnerOfUsersGroup  = errors.New("only root can be an owner of users group")
-	ErrOnlyRootCanChangeBidStatus      = errors.New("only root user can approve or reject bid")
-	ErrOnlyRootCanAddAgent             = errors.New("only root user can add server agent")
-	ErrOnlyRootCanDeleteAgent          = errors.New("only root user can delete server agent")
-	ErrOnlyRootCanGetAgents            = errors.New("only root user can get server agents")
-	ErrGetUserAgents                   = errors.New("you can't get user agents")
-	ErrCantDeleteRoot                  = errors.New("cant't delete root user")
-	ErrUserEmailMustBeDiff             = errors.New("user emails must be different")
-	ErrUserAlreadyInGroup              = errors.New("user already in group")
-	ErrUserIsNotInGroup                = errors.New("user is not in group")
-	ErrUserIsAlreadyOwner              = errors.New("user is already an owner")
-	ErrUserIsNotOwner                  = errors.New("user is not an owner")
-	ErrUserIsResponsible               = errors.New("user is responsible for group/groups, so root user need to appoint new owner")
-	ErrDeleteRootFromGroup             = errors.New("user doesn't have enough rights to delete root user from group")
+	ErrOnlyRootCanBeOwnerOfUsersGroup = errors.New("only root can be an owner of users group")
+	ErrCantDeleteRoot                 = errors.New("cant't delete root user")
+	ErrUserEmailMustBeDiff            = errors.New("user emails must be different")
+	ErrUserAlreadyInGroup             = errors.New("user already in group")
+	ErrUserIsNotInGroup               = errors.New("user is not in group")
+	ErrUserIsAlreadyOwner             = errors.New("user is already an owner")
+	ErrUserIsResponsible              = errors.New("user is responsible for group/groups, so root user need to appoint new owner")
+	ErrDeleteRootFromGroup            = errors.New("user doesn't have enough rights to delete root user from group")
+	ErrCantChangeSystemRole           = errors.New("system role can't be changed or deleted")
+	ErrCantRevokeRootAdmin            = errors.New("admin role can't be revoked from root user")
+	ErrCantDenyRoot                   = errors.New("access of root user can't be denied")
 	// DATABASE
-	ErrNoRowsAffected         = errors.New("no rows were affected")
-	ErrUserNotExist           = errors.New("user is not exist")
-	ErrGroupNotExist          = errors.New("group is not exist")
-	ErrAgentNotExist          = errors.New("agent is not exist")
-	ErrBidNotExist            = errors.New("user doesn't have bid with this name")
-	ErrOwnerCantExitFromGroup = errors.New("to leave a group you need to remove the rights of the group owner")
-	ErrUserAlreadyExist       = errors.New("user with this email already exist")
-	ErrGroupAlreadyExist      = errors.New("group with this name already exist")
-	ErrBidAlreadyExist        = errors.New("bid with this name already exist")
-	ErrAgentAlreadyExist      = errors.New("agent with this name already exist")
-	ErrGroupAgentAlreadyExist = errors.New("agent with this name already belongs to the selected group")
-	ErrUserAgentAlreadyExist  = errors.New("agent with this name already belongs to the selected user")
-	ErrGroupAgentNotExist     = errors.New("agent with this name not belongs to the selected group")
-	ErrUserAgentNotExist      = errors.New("agent with this name not belongs to the selected user")
+	ErrNoRowsAffected          = errors.New("no rows were affected")
+	ErrUserNotExist            = errors.New("user is not exist")
+	ErrGroupNotExist           = errors.New("group is not exist")
+	ErrAgentNotExist           = errors.New("agent is not exist")
+	ErrBidNotExist             = errors.New("user doesn't have bid with this name")
+	ErrOwnerCantExitFromGroup  = errors.New("to leave a group you need to remove the rights of the group owner")
+	ErrUserAlreadyExist        = errors.New("user with this email already exist")
+	ErrGroupAlreadyExist       = errors.New("group with this name already exist")
+	ErrBidAlreadyExist         = errors.New("bid with this name already exist")
+	ErrAgentAlreadyExist       = errors.New("agent with this name already exist")
+	ErrGroupAgentAlreadyExist  = errors.New("agent with this name already belongs to the selected group")
+	ErrUserAgentAlreadyExist   = errors.New("agent with this name already belongs to the selected user")
+	ErrGroupAgentNotExist      = errors.New("agent with this name not belongs to the selected group")
+	ErrUserAgentNotExist       = errors.New("agent with this name not belongs to the selected user")
+	ErrAgentActionNotExist     = errors.New("agent doesn't have action with this name")
+	ErrUserActionAlreadyExist  = errors.New("action of agent already belongs to the selected user")
+	ErrGroupActionAlreadyExist = errors.New("action of agent already belongs to the selected group")
+	ErrUserActionNotExist      = errors.New("action of agent not belongs to the selected user")
+	ErrGroupActionNotExist     = errors.New("action of agent not belongs to the selected group")
+	ErrUserDenyAlreadyExist    = errors.New("access to agent is already denied for the selected user")
+	ErrGroupDenyAlreadyExist   = errors.New("access to agent is already denied for the selected group")
+	ErrUserDenyNotExist        = errors.New("access to agent is not denied for the selected user")
+	ErrGroupDenyNotExist       = errors.New("access to agent is not denied for the selected group")
+	ErrRoleNotExist            = errors.New("role is not exist")
+	ErrRoleAlreadyExist        = errors.New("role with this name already exist")
+	ErrPermissionNotExist      = errors.New("unknown permission was passed")
+	ErrUserRoleAlreadyExist    = errors.New("role is already assigned to the selected user")
+	ErrGroupRoleAlreadyExist   = errors.New("role is already assigned to the selected group")
+	ErrUserRoleNotExist        = errors.New("role is not assigned to the selected user")
+	ErrGroupRoleNotExist       = errors.New("role is not assigned to the selected group")
 	// DTO
-	ErrInvalidEmail     = errors.New("incorrect email was sent, correct format is username@domain.extension, e.g.: gref@sber.ru")
-	ErrInvalidStatus    = errors.New("status must be in range(approved, rejected)")
-	ErrInvalidFirstName = errors.New("incorrect first name was sent, it must start with a capital letter and be between 2 and 50 characters long")
-	ErrInvalidLastName  = errors.New("incorrect last name was sent, it must start with a capital letter and be between 2 and 50 characters long")
-	ErrPasswordTooLong  = errors.New("password is too long, it must be between 8 and 30 characters long")
-	ErrPasswordTooShort = errors.New("password is too short, it must be between 8 and 30 characters long")
-	ErrPasswordFormat   = errors.New("password must contain at least one digit and one capital letter")
+	ErrInvalidEmail       = errors.New("incorrect email was sent, correct format is username@domain.extension, e.g.: gref@sber.ru")
+	ErrInvalidStatus      = errors.New("status must be in range(approved, rejected)")
+	ErrInvalidFirstName   = errors.New("incorrect first name was sent, it must start with a capital letter and be between 2 and 50 characters long")
+	ErrInvalidLastName    = errors.New("incorrect last name was sent, it must start with a capital letter and be between 2 and 50 characters long")
+	ErrPasswordTooLong    = errors.New("password is too long, it must be between 8 and 30 characters long")
+	ErrPasswordTooShort   = errors.New("password is too short, it must be between 8 and 30 characters long")
+	ErrInvalidActionName  = errors.New("incorrect action name was sent, it must start with a lowercase letter and contain up to 50 characters: a-z, 0-9, '_'")
+	ErrInvalidRoleName    = errors.New("incorrect role name was sent, it must start with a lowercase letter and contain 2 to 50 characters: a-z, 0-9, '_', '.', '-'")
+	ErrInvalidGrantPeriod = errors.New("incorrect grant period was sent, valid_until must be later than valid_from and the current time")
+	ErrPasswordFormat     = errors.New("password must contain at least one digit and one capital letter")
+	// ACCESS CHECK
+	ErrInvalidAccessCheckBatch = errors.New("checks must contain from 1 to 100 items")
+
+	// ACCESS REQUEST
+	ErrAccessRequestNotExist      = errors.New("access request is not exist")
+	ErrAccessRequestAlreadyExist  = errors.New("you already have an access request in progress for this agent")
+	ErrAccessRequestIsReviewed    = errors.New("access request has already been reviewed or canceled")
+	ErrCantReviewOwnAccessRequest = errors.New("you can't review your own access request")
+	ErrGroupHasNoAccess           = errors.New("the selected group doesn't grant access to this agent or action")
+	ErrOnlyRequesterCanCancel     = errors.New("only the author of the access request can cancel it")
+	ErrInvalidJustification       = errors.New("incorrect justification was sent, it must be between 10 and 500 characters long")
+	ErrInvalidRequestDuration     = errors.New("incorrect duration was sent, it must be a positive duration like '4h' not exceeding the allowed maximum")
+	ErrInvalidRequestStatus       = errors.New("status must be in range(in_progress, approved, rejected, canceled)")
+	ErrInvalidAccessRequestID     = errors.New("incorrect access request id was sent, it must be a positive integer")
+
+	// BID
+	ErrBidIsReviewed          = errors.New("bid has already been reviewed or cancelled")
+	ErrOnlyBidAuthorCanCancel = errors.New("only the author of the bid can cancel it")
+	ErrInvalidBidID           = errors.New("incorrect bid id was sent, it must be a positive integer")
+	ErrInvalidBidStatus       = errors.New("status must be in range(in_progress, approved, rejected, cancelled)")
+	ErrInvalidReviewComment   = errors.New("incorrect comment was sent, it must be up to 500 characters long")
+	ErrInvalidPagination      = errors.New("incorrect pagination was sent, limit must be between 1 and 100, offset must not be negative")
+
+	// GROUP HIERARCHY
+	ErrGroupCycle              = errors.New("group can't be attached to itself or to one of its subgroups")
+	ErrGroupAlreadyHasParent   = errors.New("group is already a subgroup of another group, detach it first")
+	ErrSubgroupAlreadyAttached = errors.New("group is already a subgroup of the selected group")
+	ErrSubgroupNotAttached     = errors.New("group is not a subgroup of the selected group")
+
+	// GROUP ADMIN
+	ErrUserIsNotOwner    = errors.New("user is not an owner of the group")
+	ErrLastGroupOwner    = errors.New("group must have at least one owner, appoint another owner first")
+	ErrUsersGroupIsFixed = errors.New("users group is a system group, it can't be renamed, deleted or have co-owners")
+	ErrInvalidGroupName  = errors.New("incorrect group name was sent, it must be between 2 and 30 characters long")
+
+	// INVITATION
+	ErrInvitationNotExist      = errors.New("invitation is not exist")
+	ErrInvitationAlreadyExist  = errors.New("user already has a pending invitation to this group")
+	ErrInvitationIsResolved    = errors.New("invitation has already been accepted, declined, revoked or has expired")
+	ErrOnlyInviteeCanRespond   = errors.New("only the invited user can accept or decline the invitation")
+	ErrInvalidInvitationID     = errors.New("incorrect invitation id was sent, it must be a positive integer")
+	ErrInvalidInvitationStatus = errors.New("status must be in range(pending, accepted, declined, revoked, expired)")
+	ErrInvalidNotificationID   = errors.New("incorrect notification id was sent, it must be a positive integer")
+	ErrNotificationNotExist    = errors.New("notification is not exist")
+	ErrDirectAddRequiresManage = errors.New("group owners add users through invitations, adding without consent requires 'group.manage'")
+
+	// AUDIT
+	ErrInvalidTimeRange   = errors.New("incorrect time range was sent, 'from' and 'to' must be RFC3339 timestamps and 'from' must be before 'to'")
+	ErrInvalidAuditTarget = errors.New("target_type must be in range(agent, group, user, bid, role)")
+	// DECISION LOG
+	ErrInvalidExportFormat  = errors.New("format must be in range(csv, jsonl)")
+	ErrInvalidAllowedFilter = errors.New("allowed must be true or false")
 )
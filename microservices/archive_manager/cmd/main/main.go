@@ -1,6 +1,8 @@
 package main
 
 import (
+	"os"
+
 	"github.com/cantylv/authorization-service/microservices/archive_manager/config"
 	"github.com/cantylv/authorization-service/microservices/archive_manager/internal/app"
 	"go.uber.org/zap"
@@ -9,5 +11,9 @@ import (
 func main() {
 	logger := zap.Must(zap.NewProduction())
 	config.Read("./config/config.yaml", logger)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		app.Migrate(logger, os.Args[2:])
+		return
+	}
 	app.Run(logger)
 }
@@ -33,6 +33,17 @@ func readEnvAndSetDefault(logger *zap.Logger) {
 	}
 
 	viper.SetDefault("postgres.sslmode", "disable")
+	// пул соединений
+	viper.SetDefault("postgres.pool.max_conns", 10)
+	viper.SetDefault("postgres.pool.min_conns", 2)
+	viper.SetDefault("postgres.pool.max_conn_lifetime", time.Hour)
+	viper.SetDefault("postgres.pool.max_conn_idle_time", 30*time.Minute)
+	viper.SetDefault("postgres.pool.health_check_period", time.Minute)
+	viper.SetDefault("postgres.pool.connect_timeout", 5*time.Second)
+	viper.SetDefault("postgres.pool.statement_timeout", 5*time.Second)
+
+	viper.SetDefault("postgres.migrate_on_start", true)
+	viper.SetDefault("postgres.migrate_timeout", time.Minute)
 	// SERVER
 	if address := os.Getenv("AM_SERVER_ADDRESS"); address != "" {
 		viper.SetDefault("archive_manager.address", address)
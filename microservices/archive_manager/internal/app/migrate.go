@@ -0,0 +1,33 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cantylv/authorization-service/microservices/archive_manager/services/postgres"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// Migrate выполняет команду миграций схемы базы данных без запуска сервера.
+// Формат аргументов: up | down [количество миграций, по умолчанию 1] | status
+func Migrate(logger *zap.Logger, args []string) {
+	if len(args) == 0 {
+		logger.Fatal("usage: migrate up | down [steps] | status")
+	}
+	steps := 1
+	if args[0] == "down" && len(args) > 1 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			logger.Fatal(fmt.Sprintf("invalid number of steps: %s", args[1]))
+		}
+		steps = n
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("postgres.migrate_timeout"))
+	defer cancel()
+	if err := postgres.Migrate(ctx, logger, args[0], steps); err != nil {
+		logger.Fatal(fmt.Sprintf("error while running migrations: %v", err))
+	}
+}
@@ -17,6 +17,7 @@ import (
 func Run(logger *zap.Logger) {
 	// init psql
 	postgresClient := postgres.Init(logger)
+	defer postgresClient.Close()
 	r := mux.NewRouter()
 	// инициализуруем серверные ручки
 	handler := route.InitHTTPHandlers(r, postgresClient, logger)
@@ -4,7 +4,7 @@ import (
 	"context"
 
 	ent "github.com/cantylv/authorization-service/microservices/archive_manager/internal/entity"
-	"github.com/jackc/pgx/v5"
+	"github.com/cantylv/authorization-service/microservices/archive_manager/services/postgres"
 )
 
 type Repo interface {
@@ -14,10 +14,10 @@ type Repo interface {
 var _ Repo = (*RepoLayer)(nil)
 
 type RepoLayer struct {
-	dbconn *pgx.Conn
+	dbconn postgres.DB
 }
 
-func NewRepoLayer(conn *pgx.Conn) *RepoLayer {
+func NewRepoLayer(conn postgres.DB) *RepoLayer {
 	return &RepoLayer{
 		dbconn: conn,
 	}
@@ -28,6 +28,7 @@ func (r *RepoLayer) Get(ctx context.Context) ([]*ent.Record, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 	var records []*ent.Record
 	for rows.Next() {
 		var rec ent.Record
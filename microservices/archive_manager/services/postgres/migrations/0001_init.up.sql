@@ -1,6 +1,19 @@
+CREATE TABLE record (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    "text" TEXT
+);
+
+ALTER TABLE record
+ADD CONSTRAINT record_unique_name CHECK (LENGTH("text")>=10 AND LENGTH("text") <= 500);
+
+ALTER TABLE record
+ALTER COLUMN "text" SET NOT NULL;
+
+
+-------- DATA --------
 INSERT INTO record("text") VALUES 
     ('Без труда не вытащишь и рыбку из пруда'),
     ('На безрыбье и рак рыба'),
     ('Не всё то золото, что блестит'),
     ('Семь раз отмерь, один раз отрежь'),
-    ('Лучше синица в руках, чем журавль в небе');
\ No newline at end of file
+    ('Лучше синица в руках, чем журавль в небе');
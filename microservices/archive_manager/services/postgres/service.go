@@ -3,15 +3,44 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
 
-// Init инициализирует клиента PostgreSQL.
-func Init(logger *zap.Logger) *pgx.Conn {
+// DB набор методов для работы с базой данных, от которого зависят репозитории. Ему удовлетворяют
+// как пул соединений, так и транзакция.
+type DB interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+var _ DB = (*pgxpool.Pool)(nil)
+
+// Init инициализирует пул соединений PostgreSQL. Пул безопасен для конкурентного использования,
+// сам проверяет соединения и заменяет разорванные новыми. Если 'postgres.migrate_on_start' включен,
+// перед началом работы применяются миграции схемы.
+func Init(logger *zap.Logger) *pgxpool.Pool {
+	pool := Connect(logger)
+	if viper.GetBool("postgres.migrate_on_start") {
+		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("postgres.migrate_timeout"))
+		defer cancel()
+		if err := migrateUp(ctx, pool, logger); err != nil {
+			logger.Fatal(fmt.Sprintf("error while applying migrations: %v", err))
+		}
+	}
+	return pool
+}
+
+// Connect открывает пул соединений PostgreSQL и проверяет соединение.
+func Connect(logger *zap.Logger) *pgxpool.Pool {
 	connString := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
 		viper.GetString("postgres.user"),
 		viper.GetString("postgres.password"),
@@ -20,9 +49,13 @@ func Init(logger *zap.Logger) *pgx.Conn {
 		viper.GetString("postgres.database_name"),
 		viper.GetString("postgres.sslmode"),
 	)
+	poolConfig, err := newPoolConfig(connString)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("error while parsing postgresql config: %v", err))
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	conn, err := pgx.Connect(ctx, connString)
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		logger.Fatal(fmt.Sprintf("error while connecting to postgresql: %v", err))
 	}
@@ -31,18 +64,38 @@ func Init(logger *zap.Logger) *pgx.Conn {
 	var successConn bool
 	for i := 0; i < maxPingAttempts; i++ {
 		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-		err := conn.Ping(ctx)
+		err := pool.Ping(ctx)
 		cancel()
 		if err == nil {
 			successConn = true
 			break
 		}
 		logger.Warn(fmt.Sprintf("error while ping to postgresql: %v", err))
+		time.Sleep(time.Duration(i+1) * time.Second)
 	}
 	if !successConn {
 		logger.Fatal("can't establish connection to postgresql")
 	}
 
 	logger.Info("postgresql connected successfully")
-	return conn
+	return pool
+}
+
+// newPoolConfig настраивает пул соединений параметрами 'postgres.pool.*'.
+func newPoolConfig(connString string) (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, err
+	}
+	poolConfig.MaxConns = viper.GetInt32("postgres.pool.max_conns")
+	poolConfig.MinConns = viper.GetInt32("postgres.pool.min_conns")
+	poolConfig.MaxConnLifetime = viper.GetDuration("postgres.pool.max_conn_lifetime")
+	poolConfig.MaxConnIdleTime = viper.GetDuration("postgres.pool.max_conn_idle_time")
+	poolConfig.HealthCheckPeriod = viper.GetDuration("postgres.pool.health_check_period")
+	poolConfig.ConnConfig.ConnectTimeout = viper.GetDuration("postgres.pool.connect_timeout")
+	// запросы, выполняющиеся дольше statement_timeout, прерываются самим PostgreSQL
+	if timeout := viper.GetDuration("postgres.pool.statement_timeout"); timeout > 0 {
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(timeout.Milliseconds(), 10)
+	}
+	return poolConfig, nil
 }
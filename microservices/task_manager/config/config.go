@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/spf13/viper"
@@ -15,6 +16,54 @@ func readEnvAndSetDefault(logger *zap.Logger) {
 	// CLIENTS HOSTS AND PORTS
 	viper.SetDefault("microservice_privelege.host", os.Getenv("PS_SERVER_CONNECTION_HOST"))
 	viper.SetDefault("microservice_privelege.port", os.Getenv("PS_SERVER_PORT"))
+	// протокол проверок доступа и чтения агентов, пользователей и групп: http или grpc
+	if transport := os.Getenv("PS_CLIENT_TRANSPORT"); transport != "" {
+		if transport != "http" && transport != "grpc" {
+			logger.Info("you've passed incorrect value of env variable 'PS_CLIENT_TRANSPORT', so it will be with default value http")
+			viper.SetDefault("microservice_privelege.transport", "http")
+		} else {
+			viper.SetDefault("microservice_privelege.transport", transport)
+		}
+	} else {
+		viper.SetDefault("microservice_privelege.transport", "http")
+	}
+	viper.SetDefault("microservice_privelege.grpc_port", os.Getenv("PS_GRPC_PORT"))
+	// кэш проверок доступа клиента микросервиса привелегий, по умолчанию выключен
+	if cacheTTL := os.Getenv("PS_CLIENT_CACHE_TTL"); cacheTTL != "" {
+		ttl, err := time.ParseDuration(cacheTTL)
+		if err != nil {
+			logger.Info("you've passed incorrect value of env variable 'PS_CLIENT_CACHE_TTL', so it will be with default value 0s")
+			viper.SetDefault("microservice_privelege.cache_ttl", time.Duration(0))
+		} else {
+			viper.SetDefault("microservice_privelege.cache_ttl", ttl)
+		}
+	} else {
+		viper.SetDefault("microservice_privelege.cache_ttl", time.Duration(0))
+	}
+
+	if cacheNegativeTTL := os.Getenv("PS_CLIENT_CACHE_NEGATIVE_TTL"); cacheNegativeTTL != "" {
+		ttl, err := time.ParseDuration(cacheNegativeTTL)
+		if err != nil {
+			logger.Info("you've passed incorrect value of env variable 'PS_CLIENT_CACHE_NEGATIVE_TTL', so it will be equal to 'PS_CLIENT_CACHE_TTL'")
+			viper.SetDefault("microservice_privelege.cache_negative_ttl", time.Duration(0))
+		} else {
+			viper.SetDefault("microservice_privelege.cache_negative_ttl", ttl)
+		}
+	} else {
+		viper.SetDefault("microservice_privelege.cache_negative_ttl", time.Duration(0))
+	}
+
+	if cacheSize := os.Getenv("PS_CLIENT_CACHE_SIZE"); cacheSize != "" {
+		size, err := strconv.Atoi(cacheSize)
+		if err != nil || size < 0 {
+			logger.Info("you've passed incorrect value of env variable 'PS_CLIENT_CACHE_SIZE', so it will be with default value 10000")
+			viper.SetDefault("microservice_privelege.cache_size", 10000)
+		} else {
+			viper.SetDefault("microservice_privelege.cache_size", size)
+		}
+	} else {
+		viper.SetDefault("microservice_privelege.cache_size", 10000)
+	}
 
 	viper.SetDefault("microservice_archive.host", os.Getenv("AM_SERVER_CONNECTION_HOST"))
 	viper.SetDefault("microservice_archive.port", os.Getenv("AM_SERVER_PORT"))
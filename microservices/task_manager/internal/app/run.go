@@ -44,6 +44,9 @@ func Run(logger *zap.Logger) {
 	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("task_manager.shutdown_duration"))
 	defer cancel()
 	err := srv.Shutdown(ctx)
+	if err := clientCluster.PrivelegeClient.Close(); err != nil {
+		logger.Error(fmt.Sprintf("error while closing connection to microservice 'privelege': %v", err))
+	}
 	if err != nil {
 		logger.Error(fmt.Sprintf("server has shut down with an error: %v", err))
 		os.Exit(1)
@@ -12,10 +12,21 @@ type Cluster struct {
 }
 
 func InitCluster() *Cluster {
+	// проверки доступа и чтение агентов, пользователей и групп переключаются на gRPC API переменной PS_CLIENT_TRANSPORT
+	var grpcOpts *pClient.GRPCOpts
+	if viper.GetString("microservice_privelege.transport") == "grpc" {
+		grpcOpts = pClient.NewGRPCOpts(viper.GetInt("microservice_privelege.grpc_port"))
+	}
 	privelegeClient := pClient.NewClient(&pClient.ClientOpts{
 		Host:   viper.GetString("microservice_privelege.host"),
 		Port:   viper.GetInt("microservice_privelege.port"),
 		UseSsl: false,
+		Cache: pClient.NewCacheOpts(
+			viper.GetDuration("microservice_privelege.cache_ttl"),
+			viper.GetDuration("microservice_privelege.cache_negative_ttl"),
+			viper.GetInt("microservice_privelege.cache_size"),
+		),
+		GRPC: grpcOpts,
 	})
 	privelegeClient.CheckConnection()
 
@@ -10,7 +10,6 @@ import (
 	f "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/functions"
 	mc "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/myconstants"
 	me "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/myerrors"
-	"github.com/gorilla/mux"
 	"go.uber.org/zap"
 )
 
@@ -42,16 +41,21 @@ func (h *ArchiveProxyManager) GetArchive(w http.ResponseWriter, r *http.Request)
 	if err != nil {
 		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
 	}
-	pathVars := mux.Vars(r)
-	emailAsk := pathVars["email_ask"]
-	// убедимся, что пользователь имеет доступ к архиву
-	canExecute, status := h.privelegeClient.Privelege.CanUserExecute(emailAsk, "archive", &metaPrivelege)
+	// определим пользователя по токену доступа, а после убедимся, что он имеет доступ к архиву
+	user, status := h.privelegeClient.Auth.WhoAmI(&metaPrivelege)
+	if status.Err != nil {
+		h.logger.Info(status.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: status.Err.Error()}, status.StatusCode)
+		return
+	}
+	canExecute, status := h.privelegeClient.Privelege.CanUserExecuteAction(user.Email, "archive", "read", &metaPrivelege)
 	if status.Err != nil {
 		h.logger.Info(status.Err.Error(), zap.String(mc.RequestID, requestID))
 		f.Response(w, dto.ResponseError{Error: status.Err.Error()}, status.StatusCode)
 		return
 	}
 	if !canExecute {
+		h.logExplanation(user.Email, "archive", "read", &metaPrivelege, requestID)
 		h.logger.Info(me.ErrUserDoesntHaveEnoughPrivelege.Error(), zap.String(mc.RequestID, requestID))
 		f.Response(w, dto.ResponseError{Error: me.ErrUserDoesntHaveEnoughPrivelege.Error()}, http.StatusForbidden)
 		return
@@ -64,3 +68,22 @@ func (h *ArchiveProxyManager) GetArchive(w http.ResponseWriter, r *http.Request)
 	}
 	f.Response(w, agent, reqStatus.StatusCode)
 }
+
+// logExplanation логирует, почему пользователю отказано в доступе, чтобы поддержка могла разобрать отказ
+// без повторного запроса. Ошибка получения объяснения не влияет на ответ пользователю.
+func (h *ArchiveProxyManager) logExplanation(email, agentName, action string, meta *pClient.RequestMeta, requestID string) {
+	explanation, status := h.privelegeClient.Privelege.ExplainAccess(email, agentName, action, meta)
+	if status.Err != nil {
+		h.logger.Warn(status.Err.Error(), zap.String(mc.RequestID, requestID))
+		return
+	}
+	h.logger.Info("access to agent was denied",
+		zap.String(mc.RequestID, requestID),
+		zap.String("email", explanation.Email),
+		zap.String("agent", explanation.Agent),
+		zap.String("action", explanation.Action),
+		zap.String("decision", explanation.Decision),
+		zap.String("failed_check", explanation.FailedCheck),
+		zap.Any("rules", explanation.Rules),
+	)
+}
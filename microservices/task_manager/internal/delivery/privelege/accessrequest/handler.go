@@ -0,0 +1,142 @@
+package accessrequest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cantylv/authorization-service/client"
+	"github.com/cantylv/authorization-service/microservices/task_manager/internal/entity/dto"
+	f "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/myerrors"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+type AccessRequestProxyManager struct {
+	logger          *zap.Logger
+	privelegeClient *client.Client
+}
+
+// NewAccessRequestProxyManager возвращает прокси менеджер, отвечающий за проксирование запросов к заявкам на доступ.
+func NewAccessRequestProxyManager(logger *zap.Logger, privelegeClient *client.Client) *AccessRequestProxyManager {
+	return &AccessRequestProxyManager{
+		logger:          logger,
+		privelegeClient: privelegeClient,
+	}
+}
+
+func (h *AccessRequestProxyManager) CreateAccessRequest(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	accessRequest, reqStatus := h.privelegeClient.AccessRequest.Create(r.Body, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, accessRequest, reqStatus.StatusCode)
+}
+
+func (h *AccessRequestProxyManager) GetAccessRequests(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	// параметры фильтрации передаем как есть
+	requests, reqStatus := h.privelegeClient.AccessRequest.List(r.URL.Query(), &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, requests, reqStatus.StatusCode)
+}
+
+func (h *AccessRequestProxyManager) ApproveAccessRequest(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	id, err := strconv.Atoi(pathVars["request_id"])
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	accessRequest, reqStatus := h.privelegeClient.AccessRequest.Approve(id, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, accessRequest, reqStatus.StatusCode)
+}
+
+func (h *AccessRequestProxyManager) RejectAccessRequest(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	id, err := strconv.Atoi(pathVars["request_id"])
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	accessRequest, reqStatus := h.privelegeClient.AccessRequest.Reject(id, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, accessRequest, reqStatus.StatusCode)
+}
+
+func (h *AccessRequestProxyManager) CancelAccessRequest(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	id, err := strconv.Atoi(pathVars["request_id"])
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	accessRequest, reqStatus := h.privelegeClient.AccessRequest.Cancel(id, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, accessRequest, reqStatus.StatusCode)
+}
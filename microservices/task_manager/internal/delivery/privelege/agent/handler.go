@@ -35,8 +35,8 @@ func (h *AgentProxyManager) CreateAgent(w http.ResponseWriter, r *http.Request)
 	}
 	pathVars := mux.Vars(r)
 	agentName := pathVars["agent_name"]
-	emailCreate := pathVars["email_create"]
-	agent, reqStatus := h.privelegeClient.Agent.Create(agentName, emailCreate, &meta)
+	// тело запроса с действиями агента передаем как есть
+	agent, reqStatus := h.privelegeClient.Agent.CreateWithActions(agentName, r.Body, &meta)
 	if reqStatus.Err != nil {
 		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
 		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
@@ -56,8 +56,7 @@ func (h *AgentProxyManager) DeleteAgent(w http.ResponseWriter, r *http.Request)
 	}
 	pathVars := mux.Vars(r)
 	agentName := pathVars["agent_name"]
-	emailDelete := pathVars["email_delete"]
-	detailMsg, reqStatus := h.privelegeClient.Agent.Delete(agentName, emailDelete, &meta)
+	detailMsg, reqStatus := h.privelegeClient.Agent.Delete(agentName, &meta)
 	if reqStatus.Err != nil {
 		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
 		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
@@ -75,9 +74,7 @@ func (h *AgentProxyManager) GetAgents(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
 	}
-	pathVars := mux.Vars(r)
-	emailRead := pathVars["email_read"]
-	agents, reqStatus := h.privelegeClient.Agent.GetAll(emailRead, &meta)
+	agents, reqStatus := h.privelegeClient.Agent.GetAll(&meta)
 	if reqStatus.Err != nil {
 		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
 		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
@@ -0,0 +1,61 @@
+package audit
+
+import (
+	"net/http"
+
+	"github.com/cantylv/authorization-service/client"
+	"github.com/cantylv/authorization-service/microservices/task_manager/internal/entity/dto"
+	f "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/myconstants"
+	"go.uber.org/zap"
+)
+
+type AuditProxyManager struct {
+	logger          *zap.Logger
+	privelegeClient *client.Client
+}
+
+// NewAuditProxyManager возвращает прокси менеджер, отвечающий за проксирование запросов к журналу аудита.
+func NewAuditProxyManager(logger *zap.Logger, privelegeClient *client.Client) *AuditProxyManager {
+	return &AuditProxyManager{
+		logger:          logger,
+		privelegeClient: privelegeClient,
+	}
+}
+
+func (h *AuditProxyManager) GetAuditLog(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	// параметры фильтрации передаем как есть
+	entries, reqStatus := h.privelegeClient.Audit.List(r.URL.Query(), &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, entries, reqStatus.StatusCode)
+}
+
+func (h *AuditProxyManager) VerifyAuditLog(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	verification, reqStatus := h.privelegeClient.Audit.Verify(&meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, verification, reqStatus.StatusCode)
+}
@@ -0,0 +1,67 @@
+package decision
+
+import (
+	"net/http"
+
+	"github.com/cantylv/authorization-service/client"
+	"github.com/cantylv/authorization-service/microservices/task_manager/internal/entity/dto"
+	f "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/myconstants"
+	"go.uber.org/zap"
+)
+
+type DecisionProxyManager struct {
+	logger          *zap.Logger
+	privelegeClient *client.Client
+}
+
+// NewDecisionProxyManager возвращает прокси менеджер, отвечающий за проксирование запросов к журналу решений о доступе.
+func NewDecisionProxyManager(logger *zap.Logger, privelegeClient *client.Client) *DecisionProxyManager {
+	return &DecisionProxyManager{
+		logger:          logger,
+		privelegeClient: privelegeClient,
+	}
+}
+
+func (h *DecisionProxyManager) GetDecisions(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	// параметры фильтрации передаем как есть
+	decisions, reqStatus := h.privelegeClient.Decision.List(r.URL.Query(), &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, decisions, reqStatus.StatusCode)
+}
+
+func (h *DecisionProxyManager) ExportDecisions(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	export, reqStatus := h.privelegeClient.Decision.Export(r.URL.Query(), &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	// выгрузку отдаем в том формате, в котором ее вернул сервис привелегий
+	w.Header().Set("Content-Type", export.ContentType)
+	w.Header().Set("Content-Disposition", export.ContentDisposition)
+	w.WriteHeader(reqStatus.StatusCode)
+	if _, err := w.Write(export.Data); err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+}
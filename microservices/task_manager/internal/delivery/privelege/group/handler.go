@@ -2,11 +2,13 @@ package group
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/cantylv/authorization-service/client"
 	"github.com/cantylv/authorization-service/microservices/task_manager/internal/entity/dto"
 	f "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/functions"
 	mc "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/myerrors"
 	"github.com/gorilla/mux"
 	"go.uber.org/zap"
 )
@@ -35,8 +37,8 @@ func (h *GroupProxyManager) AddUserToGroup(w http.ResponseWriter, r *http.Reques
 	pathVars := mux.Vars(r)
 	groupName := pathVars["group_name"]
 	email := pathVars["email"]
-	emailInvite := pathVars["email_invite"]
-	detailMsg, reqStatus := h.privelegeClient.Group.AddUserToGroup(groupName, email, emailInvite, &meta)
+	// тело запроса со сроком действия передаем как есть
+	detailMsg, reqStatus := h.privelegeClient.Group.AddUserToGroupWithPeriod(groupName, email, r.Body, &meta)
 	if reqStatus.Err != nil {
 		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
 		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
@@ -56,8 +58,7 @@ func (h *GroupProxyManager) GetUserGroups(w http.ResponseWriter, r *http.Request
 	}
 	pathVars := mux.Vars(r)
 	email := pathVars["email"]
-	emailAsk := pathVars["email_ask"]
-	groups, reqStatus := h.privelegeClient.Group.UserList(email, emailAsk, &meta)
+	groups, reqStatus := h.privelegeClient.Group.UserList(email, &meta)
 	if reqStatus.Err != nil {
 		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
 		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
@@ -78,8 +79,27 @@ func (h *GroupProxyManager) KickOutUser(w http.ResponseWriter, r *http.Request)
 	pathVars := mux.Vars(r)
 	groupName := pathVars["group_name"]
 	email := pathVars["email"]
-	emailKick := pathVars["email_kick"]
-	detailMsg, reqStatus := h.privelegeClient.Group.KickOutUser(groupName, email, emailKick, &meta)
+	detailMsg, reqStatus := h.privelegeClient.Group.KickOutUser(groupName, email, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
+
+func (h *GroupProxyManager) LeaveGroup(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	detailMsg, reqStatus := h.privelegeClient.Group.LeaveGroup(groupName, &meta)
 	if reqStatus.Err != nil {
 		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
 		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
@@ -99,8 +119,7 @@ func (h *GroupProxyManager) RequestToCreateGroup(w http.ResponseWriter, r *http.
 	}
 	pathVars := mux.Vars(r)
 	groupName := pathVars["group_name"]
-	emailAdd := pathVars["email_add"]
-	bid, reqStatus := h.privelegeClient.Group.MakeBidToCreateGroup(groupName, emailAdd, &meta)
+	bid, reqStatus := h.privelegeClient.Group.MakeBidToCreateGroup(groupName, &meta)
 	if reqStatus.Err != nil {
 		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
 		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
@@ -121,9 +140,140 @@ func (h *GroupProxyManager) ChangeBidStatus(w http.ResponseWriter, r *http.Reque
 	pathVars := mux.Vars(r)
 	groupName := pathVars["group_name"]
 	email := pathVars["email"]
-	emailChangeStatus := pathVars["email_change_status"]
 	newStatus := r.URL.Query().Get("status")
-	bid, reqStatus := h.privelegeClient.Group.ChangeBidStatus(groupName, email, emailChangeStatus, newStatus, &meta)
+	bid, reqStatus := h.privelegeClient.Group.ChangeBidStatusWithComment(groupName, email, newStatus, r.Body, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, bid, reqStatus.StatusCode)
+}
+
+func (h *GroupProxyManager) AttachSubgroup(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	subgroupName := pathVars["subgroup_name"]
+	detailMsg, reqStatus := h.privelegeClient.Group.AttachSubgroup(groupName, subgroupName, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
+
+func (h *GroupProxyManager) DetachSubgroup(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	subgroupName := pathVars["subgroup_name"]
+	detailMsg, reqStatus := h.privelegeClient.Group.DetachSubgroup(groupName, subgroupName, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
+
+func (h *GroupProxyManager) GetSubgroups(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	groups, reqStatus := h.privelegeClient.Group.Subgroups(groupName, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, groups, reqStatus.StatusCode)
+}
+
+func (h *GroupProxyManager) GetBids(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	bids, reqStatus := h.privelegeClient.Group.Bids(r.URL.Query(), &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, bids, reqStatus.StatusCode)
+}
+
+func (h *GroupProxyManager) GetBid(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	id, err := strconv.Atoi(pathVars["bid_id"])
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	bid, reqStatus := h.privelegeClient.Group.GetBid(id, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, bid, reqStatus.StatusCode)
+}
+
+func (h *GroupProxyManager) CancelBid(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	id, err := strconv.Atoi(pathVars["bid_id"])
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	bid, reqStatus := h.privelegeClient.Group.CancelBid(id, r.Body, &meta)
 	if reqStatus.Err != nil {
 		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
 		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
@@ -144,8 +294,89 @@ func (h *GroupProxyManager) ChangeOwner(w http.ResponseWriter, r *http.Request)
 	pathVars := mux.Vars(r)
 	groupName := pathVars["group_name"]
 	email := pathVars["email"]
-	emailChangeOwner := pathVars["email_change_owner"]
-	group, reqStatus := h.privelegeClient.Group.ChangeOwner(groupName, email, emailChangeOwner, &meta)
+	group, reqStatus := h.privelegeClient.Group.ChangeOwner(groupName, email, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, group, reqStatus.StatusCode)
+}
+
+func (h *GroupProxyManager) AddOwner(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	email := pathVars["email"]
+	detailMsg, reqStatus := h.privelegeClient.Group.AddOwner(groupName, email, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
+
+func (h *GroupProxyManager) RemoveOwner(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	email := pathVars["email"]
+	detailMsg, reqStatus := h.privelegeClient.Group.RemoveOwner(groupName, email, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
+
+func (h *GroupProxyManager) GetMembers(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	groupName := mux.Vars(r)["group_name"]
+	members, reqStatus := h.privelegeClient.Group.Members(groupName, r.URL.Query(), &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, members, reqStatus.StatusCode)
+}
+
+func (h *GroupProxyManager) RenameGroup(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	newGroupName := pathVars["new_group_name"]
+	group, reqStatus := h.privelegeClient.Group.Rename(groupName, newGroupName, &meta)
 	if reqStatus.Err != nil {
 		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
 		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
@@ -153,3 +384,22 @@ func (h *GroupProxyManager) ChangeOwner(w http.ResponseWriter, r *http.Request)
 	}
 	f.Response(w, group, reqStatus.StatusCode)
 }
+
+func (h *GroupProxyManager) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	groupName := mux.Vars(r)["group_name"]
+	detailMsg, reqStatus := h.privelegeClient.Group.Delete(groupName, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
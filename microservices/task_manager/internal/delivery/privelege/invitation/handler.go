@@ -0,0 +1,143 @@
+package invitation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cantylv/authorization-service/client"
+	"github.com/cantylv/authorization-service/microservices/task_manager/internal/entity/dto"
+	f "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/myerrors"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+type InvitationProxyManager struct {
+	logger          *zap.Logger
+	privelegeClient *client.Client
+}
+
+// NewInvitationProxyManager возвращает прокси менеджер, отвечающий за проксирование запросов к приглашениям в группы.
+func NewInvitationProxyManager(logger *zap.Logger, privelegeClient *client.Client) *InvitationProxyManager {
+	return &InvitationProxyManager{
+		logger:          logger,
+		privelegeClient: privelegeClient,
+	}
+}
+
+func (h *InvitationProxyManager) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	pathVars := mux.Vars(r)
+	invitation, reqStatus := h.privelegeClient.Invitation.Create(pathVars["group_name"], pathVars["email"], r.Body, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, invitation, reqStatus.StatusCode)
+}
+
+func (h *InvitationProxyManager) GetInvitations(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	// параметры фильтрации передаем как есть
+	invitations, reqStatus := h.privelegeClient.Invitation.List(r.URL.Query(), &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, invitations, reqStatus.StatusCode)
+}
+
+func (h *InvitationProxyManager) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	id, err := strconv.Atoi(pathVars["invitation_id"])
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	invitation, reqStatus := h.privelegeClient.Invitation.Accept(id, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, invitation, reqStatus.StatusCode)
+}
+
+func (h *InvitationProxyManager) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	id, err := strconv.Atoi(pathVars["invitation_id"])
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	invitation, reqStatus := h.privelegeClient.Invitation.Decline(id, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, invitation, reqStatus.StatusCode)
+}
+
+func (h *InvitationProxyManager) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	id, err := strconv.Atoi(pathVars["invitation_id"])
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	invitation, reqStatus := h.privelegeClient.Invitation.Revoke(id, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, invitation, reqStatus.StatusCode)
+}
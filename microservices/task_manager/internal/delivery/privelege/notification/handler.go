@@ -0,0 +1,72 @@
+package notification
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cantylv/authorization-service/client"
+	"github.com/cantylv/authorization-service/microservices/task_manager/internal/entity/dto"
+	f "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/functions"
+	mc "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/myerrors"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+type NotificationProxyManager struct {
+	logger          *zap.Logger
+	privelegeClient *client.Client
+}
+
+// NewNotificationProxyManager возвращает прокси менеджер, отвечающий за проксирование запросов к уведомлениям пользователя.
+func NewNotificationProxyManager(logger *zap.Logger, privelegeClient *client.Client) *NotificationProxyManager {
+	return &NotificationProxyManager{
+		logger:          logger,
+		privelegeClient: privelegeClient,
+	}
+}
+
+func (h *NotificationProxyManager) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	// параметры фильтрации передаем как есть
+	notifications, reqStatus := h.privelegeClient.Notification.List(r.URL.Query(), &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, notifications, reqStatus.StatusCode)
+}
+
+func (h *NotificationProxyManager) ReadNotification(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	id, err := strconv.ParseInt(pathVars["notification_id"], 10, 64)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	detailMsg, reqStatus := h.privelegeClient.Notification.Read(id, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
@@ -3,12 +3,14 @@ package privelege
 import (
 	// "github.com/cantylv/authorization-service/internal/usecase/role"
 
+	"encoding/json"
 	"net/http"
 
 	"github.com/cantylv/authorization-service/client"
 	"github.com/cantylv/authorization-service/microservices/task_manager/internal/entity/dto"
 	f "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/functions"
 	mc "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/myconstants"
+	me "github.com/cantylv/authorization-service/microservices/task_manager/internal/utils/myerrors"
 	"github.com/gorilla/mux"
 	"go.uber.org/zap"
 )
@@ -38,8 +40,32 @@ func (h *PrivelegeProxyManager) AddAgentToGroup(w http.ResponseWriter, r *http.R
 	pathVars := mux.Vars(r)
 	groupName := pathVars["group_name"]
 	agentName := pathVars["agent_name"]
-	emailAdd := pathVars["email_add"]
-	detailMsg, reqStatus := h.privelegeClient.Privelege.AddAgentToGroup(groupName, agentName, emailAdd, &meta)
+	// тело запроса со сроком действия передаем как есть
+	detailMsg, reqStatus := h.privelegeClient.Privelege.AddAgentToGroupWithPeriod(groupName, agentName, r.Body, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
+
+func (h *PrivelegeProxyManager) AddAgentActionToGroup(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	agentName := pathVars["agent_name"]
+	action := pathVars["action"]
+	// тело запроса со сроком действия передаем как есть
+	detailMsg, reqStatus := h.privelegeClient.Privelege.AddAgentActionToGroupWithPeriod(groupName, agentName, action, r.Body, &meta)
 	if reqStatus.Err != nil {
 		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
 		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
@@ -61,8 +87,30 @@ func (h *PrivelegeProxyManager) DeleteAgentFromGroup(w http.ResponseWriter, r *h
 	pathVars := mux.Vars(r)
 	groupName := pathVars["group_name"]
 	agentName := pathVars["agent_name"]
-	emailDelete := pathVars["email_delete"]
-	detailMsg, reqStatus := h.privelegeClient.Privelege.DeleteAgentFromGroup(groupName, agentName, emailDelete, &meta)
+	detailMsg, reqStatus := h.privelegeClient.Privelege.DeleteAgentFromGroup(groupName, agentName, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
+
+func (h *PrivelegeProxyManager) DeleteAgentActionFromGroup(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	agentName := pathVars["agent_name"]
+	action := pathVars["action"]
+	detailMsg, reqStatus := h.privelegeClient.Privelege.DeleteAgentActionFromGroup(groupName, agentName, action, &meta)
 	if reqStatus.Err != nil {
 		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
 		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
@@ -83,8 +131,7 @@ func (h *PrivelegeProxyManager) GetGroupAgents(w http.ResponseWriter, r *http.Re
 
 	pathVars := mux.Vars(r)
 	groupName := pathVars["group_name"]
-	emailAsk := pathVars["email_ask"]
-	agents, reqStatus := h.privelegeClient.Privelege.GetGroupAgents(groupName, emailAsk, &meta)
+	agents, reqStatus := h.privelegeClient.Privelege.GetGroupAgents(groupName, &meta)
 	if reqStatus.Err != nil {
 		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
 		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
@@ -106,8 +153,32 @@ func (h *PrivelegeProxyManager) AddAgentToUser(w http.ResponseWriter, r *http.Re
 	pathVars := mux.Vars(r)
 	email := pathVars["email"]
 	agentName := pathVars["agent_name"]
-	emailAdd := pathVars["email_add"]
-	detailMsg, reqStatus := h.privelegeClient.Privelege.AddAgentToUser(email, agentName, emailAdd, &meta)
+	// тело запроса со сроком действия передаем как есть
+	detailMsg, reqStatus := h.privelegeClient.Privelege.AddAgentToUserWithPeriod(email, agentName, r.Body, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
+
+func (h *PrivelegeProxyManager) AddAgentActionToUser(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	email := pathVars["email"]
+	agentName := pathVars["agent_name"]
+	action := pathVars["action"]
+	// тело запроса со сроком действия передаем как есть
+	detailMsg, reqStatus := h.privelegeClient.Privelege.AddAgentActionToUserWithPeriod(email, agentName, action, r.Body, &meta)
 	if reqStatus.Err != nil {
 		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
 		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
@@ -129,8 +200,30 @@ func (h *PrivelegeProxyManager) DeleteAgentFromUser(w http.ResponseWriter, r *ht
 	pathVars := mux.Vars(r)
 	email := pathVars["email"]
 	agentName := pathVars["agent_name"]
-	emailDelete := pathVars["email_delete"]
-	detailMsg, reqStatus := h.privelegeClient.Privelege.DeleteAgentFromUser(email, agentName, emailDelete, &meta)
+	detailMsg, reqStatus := h.privelegeClient.Privelege.DeleteAgentFromUser(email, agentName, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
+
+func (h *PrivelegeProxyManager) DeleteAgentActionFromUser(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	email := pathVars["email"]
+	agentName := pathVars["agent_name"]
+	action := pathVars["action"]
+	detailMsg, reqStatus := h.privelegeClient.Privelege.DeleteAgentActionFromUser(email, agentName, action, &meta)
 	if reqStatus.Err != nil {
 		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
 		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
@@ -151,8 +244,7 @@ func (h *PrivelegeProxyManager) GetUserAgents(w http.ResponseWriter, r *http.Req
 
 	pathVars := mux.Vars(r)
 	email := pathVars["email"]
-	emailAsk := pathVars["email_ask"]
-	agents, reqStatus := h.privelegeClient.Privelege.GetUserAgents(email, emailAsk, &meta)
+	agents, reqStatus := h.privelegeClient.Privelege.GetUserAgents(email, &meta)
 	if reqStatus.Err != nil {
 		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
 		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
@@ -182,3 +274,320 @@ func (h *PrivelegeProxyManager) CanUserExecute(w http.ResponseWriter, r *http.Re
 	}
 	f.Response(w, map[string]bool{"can_execute": canExecute}, reqStatus.StatusCode)
 }
+
+func (h *PrivelegeProxyManager) CanUserExecuteAction(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	email := pathVars["email"]
+	agentName := pathVars["agent_name"]
+	action := pathVars["action"]
+	canExecute, reqStatus := h.privelegeClient.Privelege.CanUserExecuteAction(email, agentName, action, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, map[string]bool{"can_execute": canExecute}, reqStatus.StatusCode)
+}
+
+func (h *PrivelegeProxyManager) ExplainAccess(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	email := pathVars["email"]
+	agentName := pathVars["agent_name"]
+	action := pathVars["action"]
+	explanation, reqStatus := h.privelegeClient.Privelege.ExplainAccess(email, agentName, action, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, explanation, reqStatus.StatusCode)
+}
+
+func (h *PrivelegeProxyManager) CanUserExecuteBatch(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	var requestData struct {
+		Checks []client.AccessCheckItem `json:"checks"`
+	}
+	err = json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil {
+		h.logger.Info(err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: me.ErrInvalidData.Error()}, http.StatusBadRequest)
+		return
+	}
+	results, reqStatus := h.privelegeClient.Privelege.CanUserExecuteBatch(requestData.Checks, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, results, reqStatus.StatusCode)
+}
+
+func (h *PrivelegeProxyManager) GetAgentAccessors(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	accessors, reqStatus := h.privelegeClient.Privelege.GetAgentAccessors(mux.Vars(r)["agent_name"], r.URL.Query(), &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, accessors, reqStatus.StatusCode)
+}
+
+func (h *PrivelegeProxyManager) ExportAgentAccessors(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	export, reqStatus := h.privelegeClient.Privelege.ExportAgentAccessors(mux.Vars(r)["agent_name"], &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	// выгрузку отдаем в том виде, в котором ее вернул сервис привелегий
+	w.Header().Set("Content-Type", export.ContentType)
+	w.Header().Set("Content-Disposition", export.ContentDisposition)
+	w.WriteHeader(reqStatus.StatusCode)
+	if _, err := w.Write(export.Data); err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+}
+
+func (h *PrivelegeProxyManager) GetAccessCacheStats(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	stats, reqStatus := h.privelegeClient.Privelege.GetAccessCacheStats(&meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, stats, reqStatus.StatusCode)
+}
+
+func (h *PrivelegeProxyManager) AddDenyToGroup(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	agentName := pathVars["agent_name"]
+	detailMsg, reqStatus := h.privelegeClient.Privelege.AddDenyToGroup(groupName, agentName, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
+
+func (h *PrivelegeProxyManager) DeleteDenyFromGroup(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	agentName := pathVars["agent_name"]
+	detailMsg, reqStatus := h.privelegeClient.Privelege.DeleteDenyFromGroup(groupName, agentName, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
+
+func (h *PrivelegeProxyManager) AddActionDenyToGroup(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	agentName := pathVars["agent_name"]
+	action := pathVars["action"]
+	detailMsg, reqStatus := h.privelegeClient.Privelege.AddActionDenyToGroup(groupName, agentName, action, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
+
+func (h *PrivelegeProxyManager) DeleteActionDenyFromGroup(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	groupName := pathVars["group_name"]
+	agentName := pathVars["agent_name"]
+	action := pathVars["action"]
+	detailMsg, reqStatus := h.privelegeClient.Privelege.DeleteActionDenyFromGroup(groupName, agentName, action, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
+
+func (h *PrivelegeProxyManager) AddDenyToUser(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	email := pathVars["email"]
+	agentName := pathVars["agent_name"]
+	detailMsg, reqStatus := h.privelegeClient.Privelege.AddDenyToUser(email, agentName, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
+
+func (h *PrivelegeProxyManager) DeleteDenyFromUser(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	email := pathVars["email"]
+	agentName := pathVars["agent_name"]
+	detailMsg, reqStatus := h.privelegeClient.Privelege.DeleteDenyFromUser(email, agentName, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
+
+func (h *PrivelegeProxyManager) AddActionDenyToUser(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	email := pathVars["email"]
+	agentName := pathVars["agent_name"]
+	action := pathVars["action"]
+	detailMsg, reqStatus := h.privelegeClient.Privelege.AddActionDenyToUser(email, agentName, action, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
+
+func (h *PrivelegeProxyManager) DeleteActionDenyFromUser(w http.ResponseWriter, r *http.Request) {
+	requestID, err := f.GetCtxRequestID(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+	meta, err := f.GetCtxRequestMeta(r)
+	if err != nil {
+		h.logger.Error(err.Error(), zap.String(mc.RequestID, requestID))
+	}
+
+	pathVars := mux.Vars(r)
+	email := pathVars["email"]
+	agentName := pathVars["agent_name"]
+	action := pathVars["action"]
+	detailMsg, reqStatus := h.privelegeClient.Privelege.DeleteActionDenyFromUser(email, agentName, action, &meta)
+	if reqStatus.Err != nil {
+		h.logger.Info(reqStatus.Err.Error(), zap.String(mc.RequestID, requestID))
+		f.Response(w, dto.ResponseError{Error: reqStatus.Err.Error()}, reqStatus.StatusCode)
+		return
+	}
+	f.Response(w, detailMsg, reqStatus.StatusCode)
+}
@@ -9,5 +9,5 @@ import (
 
 func InitHandlers(r *mux.Router, cluster *clients.Cluster, logger *zap.Logger) {
 	proxyManager := archive.NewArchiveProxyManager(logger, cluster)
-	r.HandleFunc("/archive/who_asks/{email_ask}", proxyManager.GetArchive).Methods("GET")
+	r.HandleFunc("/archive", proxyManager.GetArchive).Methods("GET")
 }
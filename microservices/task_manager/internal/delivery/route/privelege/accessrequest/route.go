@@ -0,0 +1,17 @@
+package accessrequest
+
+import (
+	"github.com/cantylv/authorization-service/client"
+	"github.com/cantylv/authorization-service/microservices/task_manager/internal/delivery/privelege/accessrequest"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func InitHandlers(r *mux.Router, privelegeClient *client.Client, logger *zap.Logger) {
+	proxyManager := accessrequest.NewAccessRequestProxyManager(logger, privelegeClient)
+	r.HandleFunc("/access_requests", proxyManager.CreateAccessRequest).Methods("POST")
+	r.HandleFunc("/access_requests", proxyManager.GetAccessRequests).Methods("GET")
+	r.HandleFunc("/access_requests/{request_id}/approve", proxyManager.ApproveAccessRequest).Methods("POST")
+	r.HandleFunc("/access_requests/{request_id}/reject", proxyManager.RejectAccessRequest).Methods("POST")
+	r.HandleFunc("/access_requests/{request_id}/cancel", proxyManager.CancelAccessRequest).Methods("POST")
+}
@@ -9,7 +9,7 @@ import (
 
 func InitHandlers(r *mux.Router, privelegeClient *client.Client, logger *zap.Logger) {
 	proxyManager := agent.NewAgentProxyManager(logger, privelegeClient)
-	r.HandleFunc("/agents/{agent_name}/who_creates/{email_create}", proxyManager.CreateAgent).Methods("POST")
-	r.HandleFunc("/agents/{agent_name}/who_deletes/{email_delete}", proxyManager.DeleteAgent).Methods("DELETE")
-	r.HandleFunc("/agents/who_reads/{email_read}", proxyManager.GetAgents).Methods("GET")
+	r.HandleFunc("/agents/{agent_name}", proxyManager.CreateAgent).Methods("POST")
+	r.HandleFunc("/agents/{agent_name}", proxyManager.DeleteAgent).Methods("DELETE")
+	r.HandleFunc("/agents", proxyManager.GetAgents).Methods("GET")
 }
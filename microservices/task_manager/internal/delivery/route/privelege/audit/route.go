@@ -0,0 +1,14 @@
+package audit
+
+import (
+	"github.com/cantylv/authorization-service/client"
+	"github.com/cantylv/authorization-service/microservices/task_manager/internal/delivery/privelege/audit"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func InitHandlers(r *mux.Router, privelegeClient *client.Client, logger *zap.Logger) {
+	proxyManager := audit.NewAuditProxyManager(logger, privelegeClient)
+	r.HandleFunc("/audit", proxyManager.GetAuditLog).Methods("GET")
+	r.HandleFunc("/audit/verify", proxyManager.VerifyAuditLog).Methods("GET")
+}
@@ -0,0 +1,14 @@
+package decision
+
+import (
+	"github.com/cantylv/authorization-service/client"
+	"github.com/cantylv/authorization-service/microservices/task_manager/internal/delivery/privelege/decision"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func InitHandlers(r *mux.Router, privelegeClient *client.Client, logger *zap.Logger) {
+	proxyManager := decision.NewDecisionProxyManager(logger, privelegeClient)
+	r.HandleFunc("/decisions", proxyManager.GetDecisions).Methods("GET")
+	r.HandleFunc("/decisions/export", proxyManager.ExportDecisions).Methods("GET")
+}
@@ -9,10 +9,22 @@ import (
 
 func InitHandlers(r *mux.Router, privelegeClient *client.Client, logger *zap.Logger) {
 	proxyManager := group.NewGroupProxyManager(logger, privelegeClient)
-	r.HandleFunc("/groups/{group_name}/add_user/{email}/who_invites/{email_invite}", proxyManager.AddUserToGroup).Methods("POST")
-	r.HandleFunc("/users/{email}/groups/who_asks/{email_ask}", proxyManager.GetUserGroups).Methods("GET")
-	r.HandleFunc("/groups/{group_name}/kick_user/{email}/who_kicks/{email_kick}", proxyManager.KickOutUser).Methods("POST")
-	r.HandleFunc("/groups/{group_name}/who_adds/{email_add}", proxyManager.RequestToCreateGroup).Methods("POST")
-	r.HandleFunc("/users/{email}/groups/{group_name}/who_change_status/{email_change_status}", proxyManager.ChangeBidStatus).Methods("PUT")
-	r.HandleFunc("/groups/{group_name}/users/{email}/who_change_owner/{email_change_owner}", proxyManager.ChangeOwner).Methods("PUT")
+	r.HandleFunc("/groups/{group_name}/add_user/{email}", proxyManager.AddUserToGroup).Methods("POST")
+	r.HandleFunc("/users/{email}/groups", proxyManager.GetUserGroups).Methods("GET")
+	r.HandleFunc("/groups/{group_name}/kick_user/{email}", proxyManager.KickOutUser).Methods("POST")
+	r.HandleFunc("/groups/{group_name}/leave", proxyManager.LeaveGroup).Methods("POST")
+	r.HandleFunc("/groups/{group_name}", proxyManager.RequestToCreateGroup).Methods("POST")
+	r.HandleFunc("/users/{email}/groups/{group_name}", proxyManager.ChangeBidStatus).Methods("PUT")
+	r.HandleFunc("/groups/{group_name}/add_subgroup/{subgroup_name}", proxyManager.AttachSubgroup).Methods("POST")
+	r.HandleFunc("/groups/{group_name}/remove_subgroup/{subgroup_name}", proxyManager.DetachSubgroup).Methods("POST")
+	r.HandleFunc("/groups/{group_name}/subgroups", proxyManager.GetSubgroups).Methods("GET")
+	r.HandleFunc("/bids", proxyManager.GetBids).Methods("GET")
+	r.HandleFunc("/bids/{bid_id}", proxyManager.GetBid).Methods("GET")
+	r.HandleFunc("/bids/{bid_id}/cancel", proxyManager.CancelBid).Methods("POST")
+	r.HandleFunc("/groups/{group_name}/change_owner/{email}", proxyManager.ChangeOwner).Methods("PUT")
+	r.HandleFunc("/groups/{group_name}/add_owner/{email}", proxyManager.AddOwner).Methods("POST")
+	r.HandleFunc("/groups/{group_name}/remove_owner/{email}", proxyManager.RemoveOwner).Methods("POST")
+	r.HandleFunc("/groups/{group_name}/members", proxyManager.GetMembers).Methods("GET")
+	r.HandleFunc("/groups/{group_name}/rename/{new_group_name}", proxyManager.RenameGroup).Methods("PUT")
+	r.HandleFunc("/groups/{group_name}", proxyManager.DeleteGroup).Methods("DELETE")
 }
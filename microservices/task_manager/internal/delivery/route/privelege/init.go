@@ -2,9 +2,15 @@ package privelege
 
 import (
 	"github.com/cantylv/authorization-service/client"
+	"github.com/cantylv/authorization-service/microservices/task_manager/internal/delivery/route/privelege/accessrequest"
 	"github.com/cantylv/authorization-service/microservices/task_manager/internal/delivery/route/privelege/agent"
+	"github.com/cantylv/authorization-service/microservices/task_manager/internal/delivery/route/privelege/audit"
+	"github.com/cantylv/authorization-service/microservices/task_manager/internal/delivery/route/privelege/decision"
 	"github.com/cantylv/authorization-service/microservices/task_manager/internal/delivery/route/privelege/group"
+	"github.com/cantylv/authorization-service/microservices/task_manager/internal/delivery/route/privelege/invitation"
+	"github.com/cantylv/authorization-service/microservices/task_manager/internal/delivery/route/privelege/notification"
 	"github.com/cantylv/authorization-service/microservices/task_manager/internal/delivery/route/privelege/privelege"
+	"github.com/cantylv/authorization-service/microservices/task_manager/internal/delivery/route/privelege/role"
 	"github.com/cantylv/authorization-service/microservices/task_manager/internal/delivery/route/privelege/user"
 	"github.com/gorilla/mux"
 	"go.uber.org/zap"
@@ -15,4 +21,10 @@ func InitHTTPHandlers(r *mux.Router, privelegeClient *client.Client, logger *zap
 	user.InitHandlers(r, privelegeClient, logger)
 	group.InitHandlers(r, privelegeClient, logger)
 	privelege.InitHandlers(r, privelegeClient, logger)
+	role.InitHandlers(r, privelegeClient, logger)
+	accessrequest.InitHandlers(r, privelegeClient, logger)
+	invitation.InitHandlers(r, privelegeClient, logger)
+	notification.InitHandlers(r, privelegeClient, logger)
+	audit.InitHandlers(r, privelegeClient, logger)
+	decision.InitHandlers(r, privelegeClient, logger)
 }
@@ -0,0 +1,17 @@
+package invitation
+
+import (
+	"github.com/cantylv/authorization-service/client"
+	"github.com/cantylv/authorization-service/microservices/task_manager/internal/delivery/privelege/invitation"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func InitHandlers(r *mux.Router, privelegeClient *client.Client, logger *zap.Logger) {
+	proxyManager := invitation.NewInvitationProxyManager(logger, privelegeClient)
+	r.HandleFunc("/groups/{group_name}/invitations/{email}", proxyManager.CreateInvitation).Methods("POST")
+	r.HandleFunc("/invitations", proxyManager.GetInvitations).Methods("GET")
+	r.HandleFunc("/invitations/{invitation_id}/accept", proxyManager.AcceptInvitation).Methods("POST")
+	r.HandleFunc("/invitations/{invitation_id}/decline", proxyManager.DeclineInvitation).Methods("POST")
+	r.HandleFunc("/invitations/{invitation_id}/revoke", proxyManager.RevokeInvitation).Methods("POST")
+}
@@ -0,0 +1,14 @@
+package notification
+
+import (
+	"github.com/cantylv/authorization-service/client"
+	"github.com/cantylv/authorization-service/microservices/task_manager/internal/delivery/privelege/notification"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func InitHandlers(r *mux.Router, privelegeClient *client.Client, logger *zap.Logger) {
+	proxyManager := notification.NewNotificationProxyManager(logger, privelegeClient)
+	r.HandleFunc("/notifications", proxyManager.GetNotifications).Methods("GET")
+	r.HandleFunc("/notifications/{notification_id}/read", proxyManager.ReadNotification).Methods("POST")
+}
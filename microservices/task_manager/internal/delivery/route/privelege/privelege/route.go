@@ -10,12 +10,37 @@ import (
 func InitHandlers(r *mux.Router, privelegeClient *client.Client, logger *zap.Logger) {
 	proxyManager := privelege.NewPrivelegeProxyManager(logger, privelegeClient)
 	// привелегии, которые назначаются группам
-	r.HandleFunc("/groups/{group_name}/priveleges/new/agents/{agent_name}/who_adds/{email_add}", proxyManager.AddAgentToGroup).Methods("POST")
-	r.HandleFunc("/groups/{group_name}/priveleges/delete/agents/{agent_name}/who_deletes/{email_delete}", proxyManager.DeleteAgentFromGroup).Methods("DELETE")
-	r.HandleFunc("/groups/{group_name}/priveleges/who_asks/{email_ask}", proxyManager.GetGroupAgents).Methods("GET")
+	r.HandleFunc("/groups/{group_name}/priveleges/new/agents/{agent_name}", proxyManager.AddAgentToGroup).Methods("POST")
+	r.HandleFunc("/groups/{group_name}/priveleges/delete/agents/{agent_name}", proxyManager.DeleteAgentFromGroup).Methods("DELETE")
+	r.HandleFunc("/groups/{group_name}/priveleges", proxyManager.GetGroupAgents).Methods("GET")
+	// привелегии группы на отдельные действия агента
+	r.HandleFunc("/groups/{group_name}/priveleges/new/agents/{agent_name}/actions/{action}", proxyManager.AddAgentActionToGroup).Methods("POST")
+	r.HandleFunc("/groups/{group_name}/priveleges/delete/agents/{agent_name}/actions/{action}", proxyManager.DeleteAgentActionFromGroup).Methods("DELETE")
 	// привелегии, которые назначаются конкретному пользователю
-	r.HandleFunc("/users/{email}/priveleges/new/agents/{agent_name}/who_adds/{email_add}", proxyManager.AddAgentToUser).Methods("POST")
-	r.HandleFunc("/users/{email}/priveleges/delete/agents/{agent_name}/who_deletes/{email_delete}", proxyManager.DeleteAgentFromUser).Methods("DELETE")
-	r.HandleFunc("/users/{email}/priveleges/who_asks/{email_ask}", proxyManager.GetUserAgents).Methods("GET")
+	r.HandleFunc("/users/{email}/priveleges/new/agents/{agent_name}", proxyManager.AddAgentToUser).Methods("POST")
+	r.HandleFunc("/users/{email}/priveleges/delete/agents/{agent_name}", proxyManager.DeleteAgentFromUser).Methods("DELETE")
+	r.HandleFunc("/users/{email}/priveleges", proxyManager.GetUserAgents).Methods("GET")
 	r.HandleFunc("/users/{email}/check_access/agents/{agent_name}", proxyManager.CanUserExecute).Methods("GET")
+	// запреты доступа
+	r.HandleFunc("/groups/{group_name}/priveleges/deny/agents/{agent_name}", proxyManager.AddDenyToGroup).Methods("POST")
+	r.HandleFunc("/groups/{group_name}/priveleges/deny/agents/{agent_name}", proxyManager.DeleteDenyFromGroup).Methods("DELETE")
+	r.HandleFunc("/groups/{group_name}/priveleges/deny/agents/{agent_name}/actions/{action}", proxyManager.AddActionDenyToGroup).Methods("POST")
+	r.HandleFunc("/groups/{group_name}/priveleges/deny/agents/{agent_name}/actions/{action}", proxyManager.DeleteActionDenyFromGroup).Methods("DELETE")
+	r.HandleFunc("/users/{email}/priveleges/deny/agents/{agent_name}", proxyManager.AddDenyToUser).Methods("POST")
+	r.HandleFunc("/users/{email}/priveleges/deny/agents/{agent_name}", proxyManager.DeleteDenyFromUser).Methods("DELETE")
+	r.HandleFunc("/users/{email}/priveleges/deny/agents/{agent_name}/actions/{action}", proxyManager.AddActionDenyToUser).Methods("POST")
+	r.HandleFunc("/users/{email}/priveleges/deny/agents/{agent_name}/actions/{action}", proxyManager.DeleteActionDenyFromUser).Methods("DELETE")
+	// привелегии пользователя на отдельные действия агента
+	r.HandleFunc("/users/{email}/priveleges/new/agents/{agent_name}/actions/{action}", proxyManager.AddAgentActionToUser).Methods("POST")
+	r.HandleFunc("/users/{email}/priveleges/delete/agents/{agent_name}/actions/{action}", proxyManager.DeleteAgentActionFromUser).Methods("DELETE")
+	r.HandleFunc("/users/{email}/check_access/agents/{agent_name}/actions/{action}", proxyManager.CanUserExecuteAction).Methods("GET")
+	r.HandleFunc("/users/{email}/check_access/agents/{agent_name}/explain", proxyManager.ExplainAccess).Methods("GET")
+	r.HandleFunc("/users/{email}/check_access/agents/{agent_name}/actions/{action}/explain", proxyManager.ExplainAccess).Methods("GET")
+	// пакетная проверка доступа
+	r.HandleFunc("/priveleges/check_access", proxyManager.CanUserExecuteBatch).Methods("POST")
+	// пользователи с доступом к агенту
+	r.HandleFunc("/agents/{agent_name}/priveleges", proxyManager.GetAgentAccessors).Methods("GET")
+	r.HandleFunc("/agents/{agent_name}/priveleges/export", proxyManager.ExportAgentAccessors).Methods("GET")
+	// счетчики кэша решений о доступе
+	r.HandleFunc("/priveleges/cache/stats", proxyManager.GetAccessCacheStats).Methods("GET")
 }
@@ -0,0 +1,22 @@
+package role
+
+import (
+	"github.com/cantylv/authorization-service/client"
+	"github.com/cantylv/authorization-service/microservices/task_manager/internal/delivery/privelege/role"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func InitHandlers(r *mux.Router, privelegeClient *client.Client, logger *zap.Logger) {
+	proxyManager := role.NewRoleProxyManager(logger, privelegeClient)
+	r.HandleFunc("/permissions", proxyManager.GetPermissions).Methods("GET")
+	r.HandleFunc("/roles", proxyManager.GetRoles).Methods("GET")
+	r.HandleFunc("/roles", proxyManager.CreateRole).Methods("POST")
+	r.HandleFunc("/roles/{role_name}", proxyManager.DeleteRole).Methods("DELETE")
+	r.HandleFunc("/roles/{role_name}/permissions", proxyManager.SetRolePermissions).Methods("PUT")
+	r.HandleFunc("/roles/{role_name}/users/{email}", proxyManager.AssignRoleToUser).Methods("POST")
+	r.HandleFunc("/roles/{role_name}/users/{email}", proxyManager.RevokeRoleFromUser).Methods("DELETE")
+	r.HandleFunc("/roles/{role_name}/groups/{group_name}", proxyManager.AssignRoleToGroup).Methods("POST")
+	r.HandleFunc("/roles/{role_name}/groups/{group_name}", proxyManager.RevokeRoleFromGroup).Methods("DELETE")
+	r.HandleFunc("/users/{email}/permissions", proxyManager.GetUserPermissions).Methods("GET")
+}
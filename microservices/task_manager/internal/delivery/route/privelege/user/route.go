@@ -1,8 +1,6 @@
 package user
 
 import (
-	"net/http"
-
 	"github.com/cantylv/authorization-service/client"
 	"github.com/cantylv/authorization-service/microservices/task_manager/internal/delivery/privelege/user"
 	"github.com/gorilla/mux"
@@ -13,6 +11,11 @@ func InitHandlers(r *mux.Router, privelegeClient *client.Client, logger *zap.Log
 	proxyManager := user.NewUserProxyManager(logger, privelegeClient)
 	r.HandleFunc("/users", proxyManager.Create).Methods("POST")
 	r.HandleFunc("/users/{email}", proxyManager.Read).Methods("GET")
-	r.HandleFunc("/users/{email}/who_deletes/{email_delete}", proxyManager.Delete).Methods("DELETE")
-	r.HandleFunc("/openid/callback", func(http.ResponseWriter, *http.Request) {}).Methods("POST")
+	r.HandleFunc("/users/{email}", proxyManager.Delete).Methods("DELETE")
+	r.HandleFunc("/openid/login", proxyManager.OpenIDLogin).Methods("GET")
+	r.HandleFunc("/openid/callback", proxyManager.OpenIDCallback).Methods("GET", "POST")
+	r.HandleFunc("/auth/login", proxyManager.Login).Methods("POST")
+	r.HandleFunc("/auth/refresh", proxyManager.Refresh).Methods("POST")
+	r.HandleFunc("/auth/logout", proxyManager.Logout).Methods("POST")
+	r.HandleFunc("/auth/whoami", proxyManager.WhoAmI).Methods("GET")
 }
@@ -56,8 +56,9 @@ func Access(h http.Handler, logger *zap.Logger) http.Handler {
 		}
 		LogInitRequest(startLog)
 		ctx = context.WithValue(ctx, mc.AccessKey(mc.RequestMeta), client.RequestMeta{
-			UserAgent: r.UserAgent(),
-			RealIp:    r.RemoteAddr,
+			UserAgent:     r.UserAgent(),
+			RealIp:        r.RemoteAddr,
+			Authorization: r.Header.Get(mc.Authorization),
 		})
 		r = r.WithContext(ctx)
 		h.ServeHTTP(rec, r)
@@ -3,6 +3,7 @@ package myconstants
 type AccessKey string
 
 const (
-	RequestID   = "request_id"
-	RequestMeta = "request_meta"
+	RequestID     = "request_id"
+	RequestMeta   = "request_meta"
+	Authorization = "Authorization"
 )
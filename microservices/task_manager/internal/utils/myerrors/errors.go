@@ -6,6 +6,7 @@ var (
 	ErrNoRequestIdInContext       = errors.New("no request_id in request context")
 	ErrNoMetaInContext            = errors.New("no meta in request context")
 	ErrInternal                   = errors.New("internal server error, please try again later")
+	ErrInvalidData                = errors.New("you has passed invalid data in request data")
 	ErrUserDoesntHaveEnoughPrivelege = errors.New("user doesn't have enough privelege to the target agent")
 )
 
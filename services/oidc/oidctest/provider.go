@@ -0,0 +1,198 @@
+// Package oidctest содержит OpenID провайдера для тестов: discovery, JWKS и token endpoint поднимаются
+// на httptest сервере в том же процессе.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	ClientID     = "authorization-service"
+	ClientSecret = "client-secret"
+	keyID        = "oidctest"
+)
+
+// Claims данные пользователя, которые провайдер кладет в ID токен.
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     string
+	FamilyName    string
+	// Nonce заменяет nonce из адреса страницы входа, если не пустой
+	Nonce string
+	// SigningKey подписывает ID токен вместо ключа провайдера, если не nil
+	SigningKey *rsa.PrivateKey
+}
+
+// authorization выданный провайдером код авторизации.
+type authorization struct {
+	challenge string
+	nonce     string
+	claims    *Claims
+}
+
+// Provider OpenID провайдер. Код авторизации выдается методом Authorize вместо страницы входа и обменивается
+// на токены один раз, только если code_verifier соответствует code_challenge (S256) из адреса страницы входа.
+type Provider struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]*authorization
+}
+
+// NewProvider запускает провайдера, сервер останавливается по завершении теста.
+func NewProvider(t testing.TB) *Provider {
+	t.Helper()
+	p := &Provider{
+		key:   NewKey(t),
+		codes: make(map[string]*authorization),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("GET /jwks", p.jwks)
+	mux.HandleFunc("POST /token", p.token)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+// NewKey возвращает ключ RSA, например для подписи ID токена ключом, которого нет в JWKS провайдера.
+func NewKey(t testing.TB) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+	return key
+}
+
+// Issuer возвращает адрес провайдера, с которого начинается discovery.
+func (p *Provider) Issuer() string {
+	return p.server.URL
+}
+
+// Authorize имитирует вход пользователя на странице провайдера по адресу authURL и возвращает код авторизации.
+func (p *Provider) Authorize(t testing.TB, authURL string, claims *Claims) string {
+	t.Helper()
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse auth url: %v", err)
+	}
+	query := u.Query()
+	if method := query.Get("code_challenge_method"); method != "S256" {
+		t.Fatalf("code_challenge_method = %q, want S256", method)
+	}
+	if clientID := query.Get("client_id"); clientID != ClientID {
+		t.Fatalf("client_id = %q, want %q", clientID, ClientID)
+	}
+	code := fmt.Sprintf("code-%d", time.Now().UnixNano())
+	p.mu.Lock()
+	p.codes[code] = &authorization{
+		challenge: query.Get("code_challenge"),
+		nonce:     query.Get("nonce"),
+		claims:    claims,
+	}
+	p.mu.Unlock()
+	return code
+}
+
+func (p *Provider) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                p.server.URL,
+		"authorization_endpoint":                p.server.URL + "/authorize",
+		"token_endpoint":                        p.server.URL + "/token",
+		"jwks_uri":                              p.server.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (p *Provider) jwks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": keyID,
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	p.mu.Lock()
+	auth, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+	if !ok || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != auth.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "pkce verification failed"})
+		return
+	}
+
+	nonce := auth.nonce
+	if auth.claims.Nonce != "" {
+		nonce = auth.claims.Nonce
+	}
+	key := p.key
+	if auth.claims.SigningKey != nil {
+		key = auth.claims.SigningKey
+	}
+	now := time.Now()
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            p.server.URL,
+		"sub":            auth.claims.Subject,
+		"aud":            ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Minute).Unix(),
+		"nonce":          nonce,
+		"email":          auth.claims.Email,
+		"email_verified": auth.claims.EmailVerified,
+		"given_name":     auth.claims.GivenName,
+		"family_name":    auth.claims.FamilyName,
+	})
+	idToken.Header["kid"] = keyID
+	rawIDToken, err := idToken.SignedString(key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"expires_in":   60,
+		"id_token":     rawIDToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
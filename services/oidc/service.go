@@ -0,0 +1,116 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/spf13/viper"
+	"golang.org/x/oauth2"
+)
+
+// Claims подтвержденные данные пользователя из ID токена OpenID провайдера.
+type Claims struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     string
+	FamilyName    string
+}
+
+type Provider interface {
+	AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error)
+	Exchange(ctx context.Context, code, nonce, codeVerifier string) (*Claims, error)
+}
+
+var _ Provider = (*ProviderLayer)(nil)
+
+// ProviderLayer клиент OpenID провайдера. Документ discovery запрашивается при первом обращении,
+// чтобы микросервис мог стартовать, даже если провайдер временно недоступен.
+type ProviderLayer struct {
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+// NewProviderLayer возвращает клиента OpenID провайдера, настроенного параметрами 'oidc.*'.
+func NewProviderLayer() *ProviderLayer {
+	return &ProviderLayer{}
+}
+
+// discover выполняет discovery провайдера и кэширует результат. Неудачная попытка не кэшируется.
+func (p *ProviderLayer) discover(ctx context.Context) (*oidc.Provider, *oauth2.Config, error) {
+	issuer := viper.GetString("oidc.issuer")
+	if issuer == "" {
+		return nil, nil, me.ErrOpenIDDisabled
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.provider == nil {
+		provider, err := oidc.NewProvider(ctx, issuer)
+		if err != nil {
+			return nil, nil, err
+		}
+		p.provider = provider
+	}
+	config := &oauth2.Config{
+		ClientID:     viper.GetString("oidc.client_id"),
+		ClientSecret: viper.GetString("oidc.client_secret"),
+		RedirectURL:  viper.GetString("oidc.redirect_url"),
+		Endpoint:     p.provider.Endpoint(),
+		Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+	}
+	return p.provider, config, nil
+}
+
+// AuthCodeURL возвращает адрес страницы входа провайдера. Используется authorization code flow с PKCE (S256).
+func (p *ProviderLayer) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	_, config, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	return config.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(codeVerifier)), nil
+}
+
+// Exchange обменивает код авторизации на токены и проверяет ID токен: подпись по ключам JWKS провайдера,
+// издателя, получателя, срок действия и nonce.
+func (p *ProviderLayer) Exchange(ctx context.Context, code, nonce, codeVerifier string) (*Claims, error) {
+	provider, config, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := config.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, errors.Join(me.ErrOpenIDAuthentication, err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.Join(me.ErrOpenIDAuthentication, errors.New("token response has no id_token"))
+	}
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: config.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, errors.Join(me.ErrOpenIDAuthentication, err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, errors.Join(me.ErrOpenIDAuthentication, errors.New("id_token nonce mismatch"))
+	}
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		GivenName     string `json:"given_name"`
+		FamilyName    string `json:"family_name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, errors.Join(me.ErrOpenIDAuthentication, err)
+	}
+	return &Claims{
+		Issuer:        idToken.Issuer,
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		GivenName:     claims.GivenName,
+		FamilyName:    claims.FamilyName,
+	}, nil
+}
@@ -0,0 +1,116 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
+	"github.com/cantylv/authorization-service/services/oidc/oidctest"
+	"github.com/spf13/viper"
+	"golang.org/x/oauth2"
+)
+
+const testNonce = "nonce"
+
+func configure(t *testing.T, provider *oidctest.Provider) {
+	t.Helper()
+	viper.Set("oidc.issuer", provider.Issuer())
+	viper.Set("oidc.client_id", oidctest.ClientID)
+	viper.Set("oidc.client_secret", oidctest.ClientSecret)
+	viper.Set("oidc.redirect_url", "http://localhost/api/v1/oidc/callback")
+	t.Cleanup(func() { viper.Set("oidc.issuer", "") })
+}
+
+func TestExchange(t *testing.T) {
+	provider := oidctest.NewProvider(t)
+	configure(t, provider)
+	user := oidctest.Claims{
+		Subject:       "subject",
+		Email:         "user@example.com",
+		EmailVerified: true,
+		GivenName:     "Ivan",
+		FamilyName:    "Ivanov",
+	}
+
+	tests := []struct {
+		name          string
+		claims        oidctest.Claims
+		wrongVerifier bool
+		wantErr       error
+	}{
+		{name: "valid id token", claims: user},
+		{name: "code verifier does not match challenge", claims: user, wrongVerifier: true, wantErr: me.ErrOpenIDAuthentication},
+		{name: "nonce mismatch", claims: with(user, func(c *oidctest.Claims) { c.Nonce = "other" }), wantErr: me.ErrOpenIDAuthentication},
+		{name: "id token signed by unknown key", claims: with(user, func(c *oidctest.Claims) { c.SigningKey = oidctest.NewKey(t) }), wantErr: me.ErrOpenIDAuthentication},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			p := NewProviderLayer()
+			verifier := oauth2.GenerateVerifier()
+			authURL, err := p.AuthCodeURL(ctx, "state", testNonce, verifier)
+			if err != nil {
+				t.Fatalf("AuthCodeURL: %v", err)
+			}
+			code := provider.Authorize(t, authURL, &tc.claims)
+			if tc.wrongVerifier {
+				verifier = oauth2.GenerateVerifier()
+			}
+
+			claims, err := p.Exchange(ctx, code, testNonce, verifier)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("Exchange error = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Exchange: %v", err)
+			}
+			want := Claims{
+				Issuer:        provider.Issuer(),
+				Subject:       user.Subject,
+				Email:         user.Email,
+				EmailVerified: true,
+				GivenName:     user.GivenName,
+				FamilyName:    user.FamilyName,
+			}
+			if *claims != want {
+				t.Fatalf("Exchange claims = %+v, want %+v", *claims, want)
+			}
+		})
+	}
+}
+
+func TestExchangeCodeIsSingleUse(t *testing.T) {
+	provider := oidctest.NewProvider(t)
+	configure(t, provider)
+	ctx := context.Background()
+	p := NewProviderLayer()
+	verifier := oauth2.GenerateVerifier()
+	authURL, err := p.AuthCodeURL(ctx, "state", testNonce, verifier)
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	code := provider.Authorize(t, authURL, &oidctest.Claims{Subject: "subject", Email: "user@example.com", EmailVerified: true})
+	if _, err := p.Exchange(ctx, code, testNonce, verifier); err != nil {
+		t.Fatalf("first Exchange: %v", err)
+	}
+	if _, err := p.Exchange(ctx, code, testNonce, verifier); !errors.Is(err, me.ErrOpenIDAuthentication) {
+		t.Fatalf("second Exchange error = %v, want %v", err, me.ErrOpenIDAuthentication)
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	viper.Set("oidc.issuer", "")
+	if _, err := NewProviderLayer().AuthCodeURL(context.Background(), "state", testNonce, oauth2.GenerateVerifier()); !errors.Is(err, me.ErrOpenIDDisabled) {
+		t.Fatalf("AuthCodeURL error = %v, want %v", err, me.ErrOpenIDDisabled)
+	}
+}
+
+// with возвращает копию claims, измененную fn.
+func with(claims oidctest.Claims, fn func(c *oidctest.Claims)) oidctest.Claims {
+	fn(&claims)
+	return claims
+}
@@ -1 +0,0 @@
-INSERT INTO agent(name) VALUES('privelege');
\ No newline at end of file
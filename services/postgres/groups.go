@@ -0,0 +1,18 @@
+package postgres
+
+// UserGroups определение CTE user_groups(group_id, participation_id) - группы пользователя u.id, через которые
+// он получает права: группы с действующим участием и все их родительские группы. participation_id указывает
+// участие, от которого унаследована группа. UNION отбрасывает повторы, поэтому обход завершается даже при цикле
+// в иерархии. Используется в WITH RECURSIVE после CTE u.
+var UserGroups = `
+	user_groups(group_id, participation_id) AS (
+		SELECT p.group_id, p.id
+		FROM participation p
+		WHERE p.user_id = (SELECT id FROM u) AND ` + ValidNow("p") + `
+		UNION
+		SELECT g.parent_id, ug.participation_id
+		FROM user_groups ug
+		JOIN "group" g ON g.id = ug.group_id
+		WHERE g.parent_id IS NOT NULL
+	)
+`
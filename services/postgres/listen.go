@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// Listen подписывается на канал channel и вызывает onNotify с полезной нагрузкой каждого уведомления, пока не будет
+// отменен ctx. Для подписки из пула забирается отдельное соединение. Если соединение разорвано, Listen
+// переподключается с паузой retry, а после каждой подписки вызывает onSubscribe: уведомления, отправленные
+// без подписки, потеряны, и подписчик должен сбросить все, что от них зависит.
+func (c *Client) Listen(ctx context.Context, channel string, retry time.Duration, onSubscribe func(), onNotify func(payload string), logger *zap.Logger) {
+	for {
+		err := c.listen(ctx, channel, onSubscribe, onNotify)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Error(fmt.Sprintf("error while listening to postgres channel %s: %v", channel, err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retry):
+		}
+	}
+}
+
+func (c *Client) listen(ctx context.Context, channel string, onSubscribe func(), onNotify func(payload string)) error {
+	poolConn, err := c.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// соединение с подпиской не возвращается в пул, чтобы его не получил обычный запрос
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return err
+	}
+	onSubscribe()
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		onNotify(notification.Payload)
+	}
+}
@@ -0,0 +1,326 @@
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrChecksumMismatch = errors.New("applied migration was changed after it had been applied")
+	ErrUnknownVersion   = errors.New("database contains migration that is unknown to this binary")
+	ErrNoDownMigration  = errors.New("migration has no down script")
+)
+
+// fileRegexp имя файла миграции: <версия>_<название>.<up|down>.sql, например 0001_init.up.sql
+var fileRegexp = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration версия схемы базы данных. Контрольная сумма считается по up скрипту.
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// Status состояние миграции в базе данных.
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Load читает миграции из каталога dir файловой системы (обычно embed.FS) и упорядочивает их по версии.
+func Load(fsys fs.FS, dir string) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int64]*Migration)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		match := fileRegexp.FindStringSubmatch(e.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file name %q", e.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has different names: %q and %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+			sum := sha256.Sum256(content)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(content)
+		}
+	}
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", m.Version, m.Name)
+		}
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Adoption признак того, что миграция уже выполнена в базе данных, созданной без мигратора (например старым
+// ddl скриптом). Probe запрос, возвращающий одно значение bool.
+type Adoption struct {
+	Version int64
+	Probe   string
+}
+
+// Migrator применяет и откатывает миграции. Работает через одно соединение, на время работы берет
+// advisory lock, поэтому несколько реплик, стартующих одновременно, применяют миграции по очереди.
+type Migrator struct {
+	conn       *pgx.Conn
+	migrations []*Migration
+	adoptions  []Adoption
+	lockKey    int64
+	logger     *zap.Logger
+}
+
+// New возвращает мигратор. lockKey должен быть уникален для каждой схемы, которую мигрируют в одной базе данных.
+func New(conn *pgx.Conn, migrations []*Migration, lockKey int64, logger *zap.Logger) *Migrator {
+	return &Migrator{
+		conn:       conn,
+		migrations: migrations,
+		lockKey:    lockKey,
+		logger:     logger,
+	}
+}
+
+// WithAdoptions задает признаки миграций, выполненных до появления мигратора. Если таблица миграций пуста,
+// Up отмечает примененными миграции, признак которых выполнен, не запуская их скрипты.
+func (m *Migrator) WithAdoptions(adoptions ...Adoption) *Migrator {
+	m.adoptions = adoptions
+	return m
+}
+
+const sqlCreateMigrationsTable = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name TEXT NOT NULL,
+		checksum TEXT NOT NULL,
+		applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+	)`
+
+type appliedMigration struct {
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+// Up применяет все еще не примененные миграции. Перед этим сверяет контрольные суммы уже примененных.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.withLock(ctx, func(applied map[int64]appliedMigration) error {
+		if len(applied) == 0 {
+			if err := m.adopt(ctx, applied); err != nil {
+				return err
+			}
+		}
+		for _, mg := range m.migrations {
+			if _, ok := applied[mg.Version]; ok {
+				continue
+			}
+			err := m.inTx(ctx, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, mg.Up); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`,
+					mg.Version, mg.Name, mg.Checksum)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", mg.Version, mg.Name, err)
+			}
+			m.logger.Info(fmt.Sprintf("migration %d_%s applied", mg.Version, mg.Name))
+		}
+		return nil
+	})
+}
+
+// Down откатывает steps последних примененных миграций.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	return m.withLock(ctx, func(applied map[int64]appliedMigration) error {
+		for i := len(m.migrations) - 1; i >= 0 && steps > 0; i-- {
+			mg := m.migrations[i]
+			if _, ok := applied[mg.Version]; !ok {
+				continue
+			}
+			if mg.Down == "" {
+				return fmt.Errorf("migration %d_%s: %w", mg.Version, mg.Name, ErrNoDownMigration)
+			}
+			err := m.inTx(ctx, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, mg.Down); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, mg.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", mg.Version, mg.Name, err)
+			}
+			m.logger.Info(fmt.Sprintf("migration %d_%s rolled back", mg.Version, mg.Name))
+			steps--
+		}
+		return nil
+	})
+}
+
+// Status возвращает список известных миграций и время их применения.
+func (m *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	var statuses []*Status
+	err := m.withLock(ctx, func(applied map[int64]appliedMigration) error {
+		for _, mg := range m.migrations {
+			s := &Status{Version: mg.Version, Name: mg.Name}
+			if a, ok := applied[mg.Version]; ok {
+				s.AppliedAt = &a.appliedAt
+			}
+			statuses = append(statuses, s)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// withLock берет advisory lock, создает таблицу миграций, проверяет примененные миграции и выполняет fn.
+func (m *Migrator) withLock(ctx context.Context, fn func(applied map[int64]appliedMigration) error) (err error) {
+	if _, err := m.conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, m.lockKey); err != nil {
+		return err
+	}
+	defer func() {
+		// блокировка должна быть снята даже если контекст уже отменен
+		_, errUnlock := m.conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, m.lockKey)
+		err = errors.Join(err, errUnlock)
+	}()
+	if _, err := m.conn.Exec(ctx, sqlCreateMigrationsTable); err != nil {
+		return err
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	if err := m.verify(applied); err != nil {
+		return err
+	}
+	return fn(applied)
+}
+
+// adopt отмечает примененными миграции, признак которых выполнен в базе данных, и добавляет их в applied.
+func (m *Migrator) adopt(ctx context.Context, applied map[int64]appliedMigration) error {
+	known := make(map[int64]*Migration, len(m.migrations))
+	for _, mg := range m.migrations {
+		known[mg.Version] = mg
+	}
+	for _, a := range m.adoptions {
+		mg, ok := known[a.Version]
+		if !ok {
+			return fmt.Errorf("adoption of migration %d: %w", a.Version, ErrUnknownVersion)
+		}
+		var done bool
+		if err := m.conn.QueryRow(ctx, a.Probe).Scan(&done); err != nil {
+			return fmt.Errorf("adoption of migration %d_%s: %w", mg.Version, mg.Name, err)
+		}
+		if !done {
+			continue
+		}
+		var appliedAt time.Time
+		err := m.conn.QueryRow(ctx, `INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3) RETURNING applied_at`,
+			mg.Version, mg.Name, mg.Checksum).Scan(&appliedAt)
+		if err != nil {
+			return fmt.Errorf("adoption of migration %d_%s: %w", mg.Version, mg.Name, err)
+		}
+		applied[mg.Version] = appliedMigration{name: mg.Name, checksum: mg.Checksum, appliedAt: appliedAt}
+		m.logger.Info(fmt.Sprintf("migration %d_%s marked as applied, database already contains its changes", mg.Version, mg.Name))
+	}
+	return nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int64]appliedMigration, error) {
+	rows, err := m.conn.Query(ctx, `SELECT version, name, checksum, applied_at FROM schema_migrations`)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]appliedMigration)
+	for rows.Next() {
+		var version int64
+		var a appliedMigration
+		if err := rows.Scan(&version, &a.name, &a.checksum, &a.appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = a
+	}
+	return applied, rows.Err()
+}
+
+// verify проверяет, что примененные миграции не менялись и известны текущей версии приложения.
+func (m *Migrator) verify(applied map[int64]appliedMigration) error {
+	known := make(map[int64]*Migration, len(m.migrations))
+	for _, mg := range m.migrations {
+		known[mg.Version] = mg
+	}
+	for version, a := range applied {
+		mg, ok := known[version]
+		if !ok {
+			// база данных уже мигрирована более новой версией приложения, например при постепенном обновлении реплик
+			m.logger.Warn(fmt.Sprintf("migration %d_%s: %v", version, a.name, ErrUnknownVersion))
+			continue
+		}
+		if mg.Checksum != a.checksum {
+			return fmt.Errorf("migration %d_%s: %w", version, a.name, ErrChecksumMismatch)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) inTx(ctx context.Context, fn func(tx pgx.Tx) error) (err error) {
+	tx, err := m.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+	if err = fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
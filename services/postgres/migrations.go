@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+
+	"github.com/cantylv/authorization-service/services/postgres/migrate"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// migrationLockKey ключ advisory lock, под которым применяются миграции сервиса. Несколько реплик,
+// запущенных одновременно, применяют миграции по очереди.
+const migrationLockKey int64 = 7243001
+
+// legacyAdoptions признаки миграций 0001-0004 для баз данных, созданных скриптом
+// docker-entrypoint-initdb.d/ddl.sql до появления миграций. Разные версии скрипта содержали разный набор
+// изменений, поэтому каждая миграция проверяется отдельно, а недостающие затем применяются как обычно.
+var legacyAdoptions = []migrate.Adoption{
+	{Version: 1, Probe: `SELECT to_regclass('"user"') IS NOT NULL`},
+	{Version: 2, Probe: `SELECT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'privelege_group_unique_agent')`},
+	{Version: 3, Probe: `SELECT to_regclass('refresh_token') IS NOT NULL`},
+	{Version: 4, Probe: `SELECT to_regclass('user_identity') IS NOT NULL`},
+}
+
+var ErrUnknownMigrateCommand = errors.New("unknown migrate command, expected up, down or status")
+
+// migrateUp применяет к базе данных все еще не примененные миграции.
+func migrateUp(ctx context.Context, pool *pgxpool.Pool, logger *zap.Logger) error {
+	return withMigrator(ctx, pool, logger, func(m *migrate.Migrator) error {
+		return m.Up(ctx)
+	})
+}
+
+// Migrate выполняет команду миграций: up, down (откат steps последних миграций) или status.
+func Migrate(ctx context.Context, logger *zap.Logger, command string, steps int) error {
+	pool := Connect(logger)
+	defer pool.Close()
+
+	return withMigrator(ctx, pool, logger, func(m *migrate.Migrator) error {
+		switch command {
+		case "up":
+			return m.Up(ctx)
+		case "down":
+			return m.Down(ctx, steps)
+		case "status":
+			statuses, err := m.Status(ctx)
+			if err != nil {
+				return err
+			}
+			for _, s := range statuses {
+				if s.AppliedAt == nil {
+					fmt.Printf("%04d_%s\tpending\n", s.Version, s.Name)
+					continue
+				}
+				fmt.Printf("%04d_%s\tapplied at %s\n", s.Version, s.Name, s.AppliedAt.Format("2006-01-02 15:04:05"))
+			}
+			return nil
+		}
+		return ErrUnknownMigrateCommand
+	})
+}
+
+// withMigrator берет из пула отдельное соединение (advisory lock привязан к сессии) и выполняет fn.
+func withMigrator(ctx context.Context, pool *pgxpool.Pool, logger *zap.Logger, fn func(m *migrate.Migrator) error) error {
+	migrations, err := migrate.Load(migrationsFS, "migrations")
+	if err != nil {
+		return err
+	}
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	return fn(migrate.New(conn.Conn(), migrations, migrationLockKey, logger).WithAdoptions(legacyAdoptions...))
+}
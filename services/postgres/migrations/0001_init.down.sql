@@ -0,0 +1,12 @@
+DROP TRIGGER IF EXISTS update_group_updated_at ON "group";
+DROP TRIGGER IF EXISTS update_user_updated_at ON "user";
+DROP FUNCTION IF EXISTS update_updated_at_column();
+
+DROP TABLE IF EXISTS participation;
+DROP TABLE IF EXISTS privelege_user;
+DROP TABLE IF EXISTS privelege_group;
+DROP TABLE IF EXISTS bid;
+DROP TYPE IF EXISTS status_type;
+DROP TABLE IF EXISTS agent;
+DROP TABLE IF EXISTS "group";
+DROP TABLE IF EXISTS "user";
@@ -136,3 +136,7 @@ FOR EACH ROW
 EXECUTE FUNCTION update_updated_at_column();
 
 
+
+-------- DATA --------
+-- агент самого микросервиса прав, доступен группе пользователей
+INSERT INTO agent(name) VALUES('privelege');
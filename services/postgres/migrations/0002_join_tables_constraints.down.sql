@@ -0,0 +1,21 @@
+ALTER TABLE agent
+ALTER COLUMN name DROP NOT NULL,
+ALTER COLUMN created_at DROP NOT NULL;
+
+DROP INDEX IF EXISTS participation_group_id_idx;
+ALTER TABLE participation
+DROP CONSTRAINT IF EXISTS participation_unique_user,
+ALTER COLUMN user_id DROP NOT NULL,
+ALTER COLUMN group_id DROP NOT NULL;
+
+DROP INDEX IF EXISTS privelege_user_agent_id_idx;
+ALTER TABLE privelege_user
+DROP CONSTRAINT IF EXISTS privelege_user_unique_agent,
+ALTER COLUMN agent_id DROP NOT NULL,
+ALTER COLUMN user_id DROP NOT NULL;
+
+DROP INDEX IF EXISTS privelege_group_agent_id_idx;
+ALTER TABLE privelege_group
+DROP CONSTRAINT IF EXISTS privelege_group_unique_agent,
+ALTER COLUMN agent_id DROP NOT NULL,
+ALTER COLUMN group_id DROP NOT NULL;
@@ -0,0 +1,44 @@
+-- удаляем дубликаты, которые могли появиться до введения ограничений уникальности
+DELETE FROM privelege_group a USING privelege_group b
+WHERE a.id > b.id AND a.group_id = b.group_id AND a.agent_id = b.agent_id;
+
+DELETE FROM privelege_user a USING privelege_user b
+WHERE a.id > b.id AND a.user_id = b.user_id AND a.agent_id = b.agent_id;
+
+DELETE FROM participation a USING participation b
+WHERE a.id > b.id AND a.user_id = b.user_id AND a.group_id = b.group_id;
+
+-- table 'privelege_group'
+ALTER TABLE privelege_group
+ADD CONSTRAINT privelege_group_unique_agent UNIQUE (group_id, agent_id);
+
+ALTER TABLE privelege_group
+ALTER COLUMN agent_id SET NOT NULL,
+ALTER COLUMN group_id SET NOT NULL;
+
+CREATE INDEX privelege_group_agent_id_idx ON privelege_group (agent_id);
+
+-- table 'privelege_user'
+ALTER TABLE privelege_user
+ADD CONSTRAINT privelege_user_unique_agent UNIQUE (user_id, agent_id);
+
+ALTER TABLE privelege_user
+ALTER COLUMN agent_id SET NOT NULL,
+ALTER COLUMN user_id SET NOT NULL;
+
+CREATE INDEX privelege_user_agent_id_idx ON privelege_user (agent_id);
+
+-- table 'participation'
+ALTER TABLE participation
+ADD CONSTRAINT participation_unique_user UNIQUE (user_id, group_id);
+
+ALTER TABLE participation
+ALTER COLUMN user_id SET NOT NULL,
+ALTER COLUMN group_id SET NOT NULL;
+
+CREATE INDEX participation_group_id_idx ON participation (group_id);
+
+-- table 'agent'
+ALTER TABLE agent
+ALTER COLUMN name SET NOT NULL,
+ALTER COLUMN created_at SET NOT NULL;
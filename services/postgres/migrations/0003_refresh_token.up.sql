@@ -0,0 +1,22 @@
+-- Эта таблица содержит refresh токены пользователей. Хранится только хэш токена,
+-- при обновлении пары токенов старый токен отзывается и ссылается на выпущенный взамен
+CREATE TABLE refresh_token (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES "user"(id) ON DELETE CASCADE,
+    token_hash TEXT,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    replaced_by UUID REFERENCES refresh_token(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+ALTER TABLE refresh_token
+ADD CONSTRAINT refresh_token_unique_hash UNIQUE (token_hash);
+
+ALTER TABLE refresh_token
+ALTER COLUMN user_id SET NOT NULL,
+ALTER COLUMN token_hash SET NOT NULL,
+ALTER COLUMN expires_at SET NOT NULL,
+ALTER COLUMN created_at SET NOT NULL;
+
+CREATE INDEX refresh_token_user_id_idx ON refresh_token (user_id);
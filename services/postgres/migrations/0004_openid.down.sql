@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS oidc_state;
+DROP TABLE IF EXISTS user_identity;
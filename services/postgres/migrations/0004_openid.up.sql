@@ -0,0 +1,26 @@
+-- Эта таблица связывает пользователей с учетными записями OpenID провайдеров
+CREATE TABLE user_identity (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id UUID REFERENCES "user"(id) ON DELETE CASCADE,
+    issuer TEXT,
+    subject TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+ALTER TABLE user_identity
+ADD CONSTRAINT user_identity_unique_subject UNIQUE (issuer, subject);
+
+ALTER TABLE user_identity
+ALTER COLUMN user_id SET NOT NULL,
+ALTER COLUMN issuer SET NOT NULL,
+ALTER COLUMN subject SET NOT NULL,
+ALTER COLUMN created_at SET NOT NULL;
+
+-- Эта таблица содержит незавершенные попытки входа через OpenID провайдера
+CREATE TABLE oidc_state (
+    state TEXT PRIMARY KEY,
+    nonce TEXT NOT NULL,
+    code_verifier TEXT NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
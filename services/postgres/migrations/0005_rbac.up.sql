@@ -0,0 +1,59 @@
+-- Эта таблица содержит права, которые проверяются политикой доступа. Набор прав задается кодом сервиса
+CREATE TABLE permission (
+    name TEXT PRIMARY KEY,
+    description TEXT NOT NULL
+);
+
+-- Эта таблица содержит роли. Системные роли нельзя удалить или изменить
+CREATE TABLE role (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    is_system BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT role_unique_name UNIQUE (name)
+);
+
+CREATE TABLE role_permission (
+    role_id INT NOT NULL REFERENCES role(id) ON DELETE CASCADE,
+    permission TEXT NOT NULL REFERENCES permission(name) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission)
+);
+
+-- Роли назначаются пользователям напрямую, либо группам. Участники группы наследуют ее роли
+CREATE TABLE user_role (
+    user_id UUID NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    role_id INT NOT NULL REFERENCES role(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE TABLE group_role (
+    group_id INT NOT NULL REFERENCES "group"(id) ON DELETE CASCADE,
+    role_id INT NOT NULL REFERENCES role(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (group_id, role_id)
+);
+
+CREATE INDEX user_role_role_id_idx ON user_role(role_id);
+CREATE INDEX group_role_role_id_idx ON group_role(role_id);
+
+-------- DML --------
+INSERT INTO permission(name, description) VALUES
+    ('agent.create', 'create server agents'),
+    ('agent.delete', 'delete server agents'),
+    ('agent.read', 'list all server agents'),
+    ('privilege.grant', 'grant agents to users and groups'),
+    ('privilege.revoke', 'revoke agents from users and groups'),
+    ('privilege.read', 'view agents of any user or group'),
+    ('group.create', 'create groups without a bid'),
+    ('group.approve_bid', 'approve or reject bids for group creation'),
+    ('group.manage', 'manage members and owner of any group'),
+    ('user.delete', 'delete any user'),
+    ('role.manage', 'manage roles and their assignments');
+
+-- администратор обладает всеми правами, роль назначается root пользователю при старте сервиса
+INSERT INTO role(name, description, is_system) VALUES ('admin', 'full access to the service', true);
+
+INSERT INTO role_permission(role_id, permission)
+SELECT r.id, p.name FROM role r CROSS JOIN permission p WHERE r.name = 'admin';
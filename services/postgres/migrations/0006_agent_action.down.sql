@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS privelege_group_action;
+DROP TABLE IF EXISTS privelege_user_action;
+DROP TABLE IF EXISTS agent_action;
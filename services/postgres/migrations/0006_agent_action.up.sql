@@ -0,0 +1,33 @@
+-- Эта таблица содержит действия, которые предоставляет агент, например чтение и запись архива
+CREATE TABLE agent_action (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    agent_id INT NOT NULL REFERENCES agent(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT agent_action_unique_name UNIQUE (agent_id, name)
+);
+
+-- Привелегии на отдельные действия агента. Записи в privelege_user/privelege_group по-прежнему означают
+-- доступ ко всем действиям агента
+CREATE TABLE privelege_user_action (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    action_id INT NOT NULL REFERENCES agent_action(id) ON DELETE CASCADE,
+    CONSTRAINT privelege_user_action_unique UNIQUE (user_id, action_id)
+);
+
+CREATE TABLE privelege_group_action (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    group_id INT NOT NULL REFERENCES "group"(id) ON DELETE CASCADE,
+    action_id INT NOT NULL REFERENCES agent_action(id) ON DELETE CASCADE,
+    CONSTRAINT privelege_group_action_unique UNIQUE (group_id, action_id)
+);
+
+CREATE INDEX privelege_user_action_action_id_idx ON privelege_user_action(action_id);
+CREATE INDEX privelege_group_action_action_id_idx ON privelege_group_action(action_id);
+
+-------- DML --------
+-- агент archive_manager предоставляет чтение и запись архива
+INSERT INTO agent_action(agent_id, name)
+SELECT a.id, act.name FROM agent a CROSS JOIN (VALUES ('read'), ('write')) AS act(name)
+WHERE a.name = 'archive';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS privelege_group_deny;
+DROP TABLE IF EXISTS privelege_user_deny;
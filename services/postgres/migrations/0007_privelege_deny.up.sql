@@ -0,0 +1,22 @@
+-- Явные запреты доступа к агенту целиком (action_id IS NULL) или к отдельному действию агента.
+-- Порядок применения: запрет пользователя > разрешение пользователя > запрет группы > разрешение группы
+CREATE TABLE privelege_user_deny (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    agent_id INT NOT NULL REFERENCES agent(id) ON DELETE CASCADE,
+    action_id INT REFERENCES agent_action(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT privelege_user_deny_unique UNIQUE NULLS NOT DISTINCT (user_id, agent_id, action_id)
+);
+
+CREATE TABLE privelege_group_deny (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    group_id INT NOT NULL REFERENCES "group"(id) ON DELETE CASCADE,
+    agent_id INT NOT NULL REFERENCES agent(id) ON DELETE CASCADE,
+    action_id INT REFERENCES agent_action(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT privelege_group_deny_unique UNIQUE NULLS NOT DISTINCT (group_id, agent_id, action_id)
+);
+
+CREATE INDEX privelege_user_deny_agent_id_idx ON privelege_user_deny(agent_id);
+CREATE INDEX privelege_group_deny_agent_id_idx ON privelege_group_deny(agent_id);
@@ -0,0 +1,26 @@
+DROP TABLE IF EXISTS expired_grant;
+
+ALTER TABLE privelege_user
+DROP CONSTRAINT IF EXISTS privelege_user_valid_period,
+DROP COLUMN IF EXISTS valid_until,
+DROP COLUMN IF EXISTS valid_from;
+
+ALTER TABLE privelege_group
+DROP CONSTRAINT IF EXISTS privelege_group_valid_period,
+DROP COLUMN IF EXISTS valid_until,
+DROP COLUMN IF EXISTS valid_from;
+
+ALTER TABLE privelege_user_action
+DROP CONSTRAINT IF EXISTS privelege_user_action_valid_period,
+DROP COLUMN IF EXISTS valid_until,
+DROP COLUMN IF EXISTS valid_from;
+
+ALTER TABLE privelege_group_action
+DROP CONSTRAINT IF EXISTS privelege_group_action_valid_period,
+DROP COLUMN IF EXISTS valid_until,
+DROP COLUMN IF EXISTS valid_from;
+
+ALTER TABLE participation
+DROP CONSTRAINT IF EXISTS participation_valid_period,
+DROP COLUMN IF EXISTS valid_until,
+DROP COLUMN IF EXISTS valid_from;
@@ -0,0 +1,50 @@
+-- Срок действия привелегий и участия в группах. valid_until = NULL означает бессрочную запись,
+-- истекшие записи не учитываются при проверке доступа и удаляются фоновой очисткой
+ALTER TABLE privelege_user
+ADD COLUMN valid_from TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+ADD COLUMN valid_until TIMESTAMP WITH TIME ZONE,
+ADD CONSTRAINT privelege_user_valid_period CHECK (valid_until IS NULL OR valid_until > valid_from);
+
+CREATE INDEX privelege_user_valid_until_idx ON privelege_user (valid_until) WHERE valid_until IS NOT NULL;
+
+ALTER TABLE privelege_group
+ADD COLUMN valid_from TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+ADD COLUMN valid_until TIMESTAMP WITH TIME ZONE,
+ADD CONSTRAINT privelege_group_valid_period CHECK (valid_until IS NULL OR valid_until > valid_from);
+
+CREATE INDEX privelege_group_valid_until_idx ON privelege_group (valid_until) WHERE valid_until IS NOT NULL;
+
+ALTER TABLE privelege_user_action
+ADD COLUMN valid_from TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+ADD COLUMN valid_until TIMESTAMP WITH TIME ZONE,
+ADD CONSTRAINT privelege_user_action_valid_period CHECK (valid_until IS NULL OR valid_until > valid_from);
+
+CREATE INDEX privelege_user_action_valid_until_idx ON privelege_user_action (valid_until) WHERE valid_until IS NOT NULL;
+
+ALTER TABLE privelege_group_action
+ADD COLUMN valid_from TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+ADD COLUMN valid_until TIMESTAMP WITH TIME ZONE,
+ADD CONSTRAINT privelege_group_action_valid_period CHECK (valid_until IS NULL OR valid_until > valid_from);
+
+CREATE INDEX privelege_group_action_valid_until_idx ON privelege_group_action (valid_until) WHERE valid_until IS NOT NULL;
+
+ALTER TABLE participation
+ADD COLUMN valid_from TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+ADD COLUMN valid_until TIMESTAMP WITH TIME ZONE,
+ADD CONSTRAINT participation_valid_period CHECK (valid_until IS NULL OR valid_until > valid_from);
+
+CREATE INDEX participation_valid_until_idx ON participation (valid_until) WHERE valid_until IS NOT NULL;
+
+-- Эта таблица содержит записи, удаленные фоновой очисткой после истечения срока действия
+CREATE TABLE expired_grant (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    table_name TEXT NOT NULL,
+    row_id INT NOT NULL,
+    user_id UUID,
+    group_id INT,
+    agent_id INT,
+    action_id INT,
+    valid_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    valid_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    removed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS access_request;
+DROP TYPE IF EXISTS access_request_status;
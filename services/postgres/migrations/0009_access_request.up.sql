@@ -0,0 +1,27 @@
+CREATE TYPE access_request_status AS ENUM ('in_progress', 'approved', 'rejected', 'canceled');
+
+-- Эта таблица содержит заявки пользователей на временный доступ к агенту или к его действию.
+-- Заявка с group_id просит участие в группе, через которую доступен агент, иначе прямой доступ пользователю
+CREATE TABLE access_request (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    agent_id INT NOT NULL REFERENCES agent(id) ON DELETE CASCADE,
+    action_id INT REFERENCES agent_action(id) ON DELETE CASCADE,
+    group_id INT REFERENCES "group"(id) ON DELETE CASCADE,
+    justification TEXT NOT NULL,
+    duration INTERVAL NOT NULL,
+    status access_request_status NOT NULL DEFAULT 'in_progress',
+    reviewer_id UUID REFERENCES "user"(id) ON DELETE SET NULL,
+    valid_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT access_request_justification_length CHECK (char_length(justification) BETWEEN 10 AND 500),
+    CONSTRAINT access_request_duration_positive CHECK (duration > INTERVAL '0')
+);
+
+-- у пользователя может быть только одна нерассмотренная заявка на один и тот же доступ
+CREATE UNIQUE INDEX access_request_unique_in_progress ON access_request (user_id, agent_id, action_id, group_id)
+NULLS NOT DISTINCT WHERE status = 'in_progress';
+
+CREATE INDEX access_request_status_idx ON access_request (status);
+CREATE INDEX access_request_group_id_idx ON access_request (group_id) WHERE group_id IS NOT NULL;
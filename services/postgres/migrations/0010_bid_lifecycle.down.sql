@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS bid_history;
+
+DROP INDEX IF EXISTS bid_user_id_idx;
+DROP INDEX IF EXISTS bid_status_idx;
+
+ALTER TABLE bid
+DROP CONSTRAINT IF EXISTS bid_review_comment_length,
+DROP COLUMN IF EXISTS reviewed_at,
+DROP COLUMN IF EXISTS review_comment,
+DROP COLUMN IF EXISTS reviewer_id;
+
+-- значение из перечисления удалить нельзя, поэтому тип пересоздается, а отмененные заявки считаются отклоненными
+UPDATE bid SET status = 'rejected' WHERE status = 'cancelled';
+ALTER TYPE status_type RENAME TO status_type_old;
+CREATE TYPE status_type AS ENUM ('in_progress', 'rejected', 'approved');
+ALTER TABLE bid ALTER COLUMN status TYPE status_type USING status::text::status_type;
+DROP TYPE status_type_old;
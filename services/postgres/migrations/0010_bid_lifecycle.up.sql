@@ -0,0 +1,33 @@
+-- новое значение перечисления нельзя использовать в той же транзакции, поэтому ниже 'cancelled' не встречается
+ALTER TYPE status_type ADD VALUE IF NOT EXISTS 'cancelled';
+
+ALTER TABLE bid
+ADD COLUMN reviewer_id UUID REFERENCES "user"(id) ON DELETE SET NULL,
+ADD COLUMN review_comment TEXT,
+ADD COLUMN reviewed_at TIMESTAMP WITH TIME ZONE,
+ADD CONSTRAINT bid_review_comment_length CHECK (char_length(review_comment) <= 500);
+
+CREATE INDEX bid_status_idx ON bid (status);
+CREATE INDEX bid_user_id_idx ON bid (user_id);
+
+-- Эта таблица содержит историю переходов заявки на создание группы между статусами.
+-- status_from = NULL у записи о создании заявки
+CREATE TABLE bid_history (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    bid_id INT NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    status_from status_type,
+    status_to status_type NOT NULL,
+    actor_id UUID REFERENCES "user"(id) ON DELETE SET NULL,
+    comment TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT bid_history_comment_length CHECK (char_length(comment) <= 500)
+);
+
+CREATE INDEX bid_history_bid_id_idx ON bid_history (bid_id);
+
+-- у существующих заявок восстанавливаем создание и, для рассмотренных, решение с неизвестным автором
+INSERT INTO bid_history(bid_id, status_from, status_to, actor_id, created_at)
+SELECT id, NULL, 'in_progress', user_id, created_at FROM bid;
+
+INSERT INTO bid_history(bid_id, status_from, status_to, actor_id, created_at)
+SELECT id, 'in_progress', status, NULL, updated_at FROM bid WHERE status != 'in_progress';
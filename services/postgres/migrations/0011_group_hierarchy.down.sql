@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS group_parent_id_idx;
+
+ALTER TABLE "group"
+DROP CONSTRAINT IF EXISTS group_parent_not_self,
+DROP COLUMN IF EXISTS parent_id;
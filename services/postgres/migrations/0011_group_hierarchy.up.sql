@@ -0,0 +1,7 @@
+-- Родительская группа. Участники группы получают привелегии и запреты всех ее родительских групп.
+-- Циклы в иерархии запрещаются при привязке подгруппы
+ALTER TABLE "group"
+ADD COLUMN parent_id INT REFERENCES "group"(id) ON DELETE SET NULL,
+ADD CONSTRAINT group_parent_not_self CHECK (parent_id <> id);
+
+CREATE INDEX group_parent_id_idx ON "group" (parent_id) WHERE parent_id IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS group_primary_owner ON "group";
+DROP FUNCTION IF EXISTS add_group_primary_owner();
+DROP TABLE IF EXISTS group_owner;
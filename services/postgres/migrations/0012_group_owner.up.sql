@@ -0,0 +1,29 @@
+-- Эта таблица содержит ответственных за группу. owner_id в "group" остается основным ответственным
+-- и всегда входит в этот список, остальные записи - совладельцы группы
+CREATE TABLE group_owner (
+    group_id INT NOT NULL REFERENCES "group"(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE INDEX group_owner_user_id_idx ON group_owner (user_id);
+
+INSERT INTO group_owner(group_id, user_id, created_at)
+SELECT id, owner_id, created_at FROM "group" WHERE owner_id IS NOT NULL;
+
+-- основной ответственный автоматически становится совладельцем при создании группы и смене owner_id
+CREATE OR REPLACE FUNCTION add_group_primary_owner()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.owner_id IS NOT NULL THEN
+        INSERT INTO group_owner(group_id, user_id) VALUES (NEW.id, NEW.owner_id) ON CONFLICT DO NOTHING;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER group_primary_owner
+AFTER INSERT OR UPDATE OF owner_id ON "group"
+FOR EACH ROW
+EXECUTE FUNCTION add_group_primary_owner();
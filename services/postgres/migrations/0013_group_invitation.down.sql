@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS notification;
+DROP TABLE IF EXISTS group_invitation;
+DROP TYPE IF EXISTS invitation_status;
@@ -0,0 +1,37 @@
+CREATE TYPE invitation_status AS ENUM ('pending', 'accepted', 'declined', 'revoked', 'expired');
+
+-- Эта таблица содержит приглашения пользователей в группы. Приглашенный становится участником группы только после
+-- принятия приглашения, valid_from и valid_until задают срок будущего участия, expires_at - срок самого приглашения
+CREATE TABLE group_invitation (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    group_id INT NOT NULL REFERENCES "group"(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    inviter_id UUID REFERENCES "user"(id) ON DELETE SET NULL,
+    status invitation_status NOT NULL DEFAULT 'pending',
+    valid_from TIMESTAMP WITH TIME ZONE,
+    valid_until TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    responded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT group_invitation_period CHECK (valid_until IS NULL OR valid_from IS NULL OR valid_until > valid_from)
+);
+
+-- у пользователя может быть только одно ожидающее ответа приглашение в одну группу
+CREATE UNIQUE INDEX group_invitation_unique_pending ON group_invitation (group_id, user_id) WHERE status = 'pending';
+
+CREATE INDEX group_invitation_user_id_idx ON group_invitation (user_id);
+CREATE INDEX group_invitation_expires_at_idx ON group_invitation (expires_at) WHERE status = 'pending';
+
+-- Эта таблица содержит уведомления пользователей о событиях, например о приглашении в группу или о выходе
+-- участника из группы. payload содержит данные события в формате JSON
+CREATE TABLE notification (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    read_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX notification_user_id_idx ON notification (user_id, id DESC);
@@ -0,0 +1,5 @@
+DROP FUNCTION IF EXISTS audit_log_hash(audit_log);
+DROP TABLE IF EXISTS audit_log;
+DROP TABLE IF EXISTS audit_log_head;
+DROP FUNCTION IF EXISTS audit_log_chain();
+DROP FUNCTION IF EXISTS audit_log_append_only();
@@ -0,0 +1,74 @@
+-- Журнал аудита изменений, влияющих на авторизацию. Записи только добавляются: изменение и удаление запрещены
+-- триггерами. Каждая запись хранит хэш предыдущей, поэтому правка или удаление записи в обход триггеров
+-- обнаруживается проверкой цепочки. У actor_id нет внешнего ключа, чтобы удаление пользователя не меняло журнал
+CREATE TABLE audit_log (
+    id BIGINT PRIMARY KEY,
+    actor_id UUID,
+    actor_email TEXT,
+    action TEXT NOT NULL,
+    target_type TEXT NOT NULL,
+    target TEXT NOT NULL,
+    before_state JSONB,
+    after_state JSONB,
+    request_id TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    prev_hash TEXT NOT NULL,
+    hash TEXT NOT NULL
+);
+
+CREATE INDEX audit_log_created_at_idx ON audit_log (created_at);
+CREATE INDEX audit_log_actor_id_idx ON audit_log (actor_id);
+CREATE INDEX audit_log_target_idx ON audit_log (target_type, target);
+
+-- последняя запись цепочки. Добавляющие транзакции обновляют эту строку, поэтому записи добавляются строго
+-- по очереди, а конкурирующая транзакция с уровнем изоляции serializable получает конфликт сериализации
+CREATE TABLE audit_log_head (
+    singleton BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (singleton),
+    last_id BIGINT NOT NULL,
+    last_hash TEXT NOT NULL
+);
+
+INSERT INTO audit_log_head(singleton, last_id, last_hash) VALUES (TRUE, 0, repeat('0', 64));
+
+-- хэш записи считается от хэша предыдущей записи и всех полей самой записи
+CREATE OR REPLACE FUNCTION audit_log_hash(e audit_log)
+RETURNS TEXT AS $$
+    SELECT encode(sha256(convert_to(jsonb_build_array(
+        e.prev_hash, e.id, e.actor_id, e.actor_email, e.action, e.target_type, e.target,
+        e.before_state, e.after_state, e.request_id, (extract(epoch FROM e.created_at) * 1000000)::BIGINT
+    )::TEXT, 'UTF8')), 'hex');
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION audit_log_chain()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE audit_log_head SET last_id = last_id + 1
+    RETURNING last_id, last_hash INTO NEW.id, NEW.prev_hash;
+    NEW.created_at := clock_timestamp();
+    NEW.hash := audit_log_hash(NEW);
+    UPDATE audit_log_head SET last_hash = NEW.hash;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_chain
+BEFORE INSERT ON audit_log
+FOR EACH ROW
+EXECUTE FUNCTION audit_log_chain();
+
+CREATE OR REPLACE FUNCTION audit_log_append_only()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_no_update
+BEFORE UPDATE OR DELETE ON audit_log
+FOR EACH ROW
+EXECUTE FUNCTION audit_log_append_only();
+
+CREATE TRIGGER audit_log_no_truncate
+BEFORE TRUNCATE ON audit_log
+FOR EACH STATEMENT
+EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1,21 @@
+-- Журнал решений проверки доступа. Записи добавляются асинхронно и с выборкой (см. decision_log.sample_rate),
+-- поэтому журнал не обязан содержать каждую проверку. Старые записи удаляет фоновая очистка по decision_log.retention.
+-- Почты и названия хранятся текстом, чтобы удаление пользователя или агента не меняло журнал
+CREATE TABLE access_decision (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    checked_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    caller_id UUID,
+    caller_email TEXT,
+    user_email TEXT NOT NULL,
+    agent TEXT NOT NULL,
+    action TEXT,
+    real_ip TEXT,
+    allowed BOOLEAN NOT NULL,
+    decision TEXT NOT NULL,
+    failed_check TEXT,
+    request_id TEXT
+);
+
+CREATE INDEX access_decision_checked_at_idx ON access_decision (checked_at);
+CREATE INDEX access_decision_user_email_idx ON access_decision (user_email, checked_at);
+CREATE INDEX access_decision_agent_idx ON access_decision (agent, checked_at);
@@ -0,0 +1,26 @@
+-- Transactional outbox событий изменений, влияющих на доступ к агентам. Событие добавляется в транзакции
+-- изменения, поэтому подписчики узнают только о зафиксированных изменениях. Доставкой занимается диспетчер:
+-- он захватывает события на время lease через next_attempt_at, при ошибке откладывает следующую попытку,
+-- а после outbox.max_attempts попыток помечает событие failed_at. Доставка выполняется хотя бы один раз,
+-- подписчики должны отбрасывать повторы по id события
+CREATE TABLE outbox_event (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    event TEXT NOT NULL,
+    target_type TEXT NOT NULL,
+    target TEXT NOT NULL,
+    actor_email TEXT,
+    before_state JSONB,
+    after_state JSONB,
+    request_id TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    last_error TEXT,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    failed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- диспетчер ищет только недоставленные события
+CREATE INDEX outbox_event_pending_idx ON outbox_event (next_attempt_at, id)
+    WHERE delivered_at IS NULL AND failed_at IS NULL;
+CREATE INDEX outbox_event_created_at_idx ON outbox_event (created_at);
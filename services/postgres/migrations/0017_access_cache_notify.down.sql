@@ -0,0 +1,12 @@
+DROP TRIGGER IF EXISTS group_access_cache ON "group";
+DROP TRIGGER IF EXISTS agent_action_access_cache ON agent_action;
+DROP TRIGGER IF EXISTS agent_access_cache ON agent;
+DROP TRIGGER IF EXISTS privelege_group_action_access_cache ON privelege_group_action;
+DROP TRIGGER IF EXISTS privelege_group_deny_access_cache ON privelege_group_deny;
+DROP TRIGGER IF EXISTS privelege_group_access_cache ON privelege_group;
+DROP TRIGGER IF EXISTS user_access_cache ON "user";
+DROP TRIGGER IF EXISTS participation_access_cache ON participation;
+DROP TRIGGER IF EXISTS privelege_user_deny_access_cache ON privelege_user_deny;
+DROP TRIGGER IF EXISTS privelege_user_action_access_cache ON privelege_user_action;
+DROP TRIGGER IF EXISTS privelege_user_access_cache ON privelege_user;
+DROP FUNCTION IF EXISTS access_cache_notify();
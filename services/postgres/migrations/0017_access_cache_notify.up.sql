@@ -0,0 +1,75 @@
+-- Уведомления об изменениях, после которых кэш решений о доступе должен забыть записи. Каждый экземпляр
+-- сервиса слушает канал access_cache, поэтому кэш сбрасывается на всех репликах. Уведомления доставляются
+-- только после фиксации транзакции, одинаковые уведомления одной транзакции схлопываются.
+-- Полезная нагрузка: 'user:<id>' - изменились права или участия пользователя, 'agent:<id>' - изменились агент
+-- или права групп на него, 'all' - изменилась иерархия групп, которая влияет на неизвестный набор пользователей.
+-- Аргументы триггера: вид объекта (user, agent, action или all) и столбец строки с его идентификатором,
+-- для action агент ищется по действию
+CREATE OR REPLACE FUNCTION access_cache_notify()
+RETURNS TRIGGER AS $$
+DECLARE
+    r JSONB;
+    action_agent_id INT;
+BEGIN
+    IF TG_ARGV[0] = 'all' THEN
+        PERFORM pg_notify('access_cache', 'all');
+        RETURN NULL;
+    END IF;
+    -- при изменении строки сбрасываются записи и старого, и нового объекта
+    FOREACH r IN ARRAY ARRAY[to_jsonb(OLD), to_jsonb(NEW)] LOOP
+        CONTINUE WHEN r IS NULL;
+        IF TG_ARGV[0] = 'action' THEN
+            SELECT aa.agent_id INTO action_agent_id FROM agent_action aa WHERE aa.id = (r ->> TG_ARGV[1])::INT;
+            -- действие уже удалено каскадом, агент неизвестен
+            PERFORM pg_notify('access_cache', COALESCE('agent:' || action_agent_id, 'all'));
+        ELSE
+            PERFORM pg_notify('access_cache', TG_ARGV[0] || ':' || (r ->> TG_ARGV[1]));
+        END IF;
+    END LOOP;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER privelege_user_access_cache
+AFTER INSERT OR UPDATE OR DELETE ON privelege_user
+FOR EACH ROW EXECUTE FUNCTION access_cache_notify('user', 'user_id');
+
+CREATE TRIGGER privelege_user_action_access_cache
+AFTER INSERT OR UPDATE OR DELETE ON privelege_user_action
+FOR EACH ROW EXECUTE FUNCTION access_cache_notify('user', 'user_id');
+
+CREATE TRIGGER privelege_user_deny_access_cache
+AFTER INSERT OR UPDATE OR DELETE ON privelege_user_deny
+FOR EACH ROW EXECUTE FUNCTION access_cache_notify('user', 'user_id');
+
+CREATE TRIGGER participation_access_cache
+AFTER INSERT OR UPDATE OR DELETE ON participation
+FOR EACH ROW EXECUTE FUNCTION access_cache_notify('user', 'user_id');
+
+CREATE TRIGGER user_access_cache
+AFTER UPDATE OF email OR DELETE ON "user"
+FOR EACH ROW EXECUTE FUNCTION access_cache_notify('user', 'id');
+
+CREATE TRIGGER privelege_group_access_cache
+AFTER INSERT OR UPDATE OR DELETE ON privelege_group
+FOR EACH ROW EXECUTE FUNCTION access_cache_notify('agent', 'agent_id');
+
+CREATE TRIGGER privelege_group_deny_access_cache
+AFTER INSERT OR UPDATE OR DELETE ON privelege_group_deny
+FOR EACH ROW EXECUTE FUNCTION access_cache_notify('agent', 'agent_id');
+
+CREATE TRIGGER privelege_group_action_access_cache
+AFTER INSERT OR UPDATE OR DELETE ON privelege_group_action
+FOR EACH ROW EXECUTE FUNCTION access_cache_notify('action', 'action_id');
+
+CREATE TRIGGER agent_access_cache
+AFTER UPDATE OR DELETE ON agent
+FOR EACH ROW EXECUTE FUNCTION access_cache_notify('agent', 'id');
+
+CREATE TRIGGER agent_action_access_cache
+AFTER UPDATE OR DELETE ON agent_action
+FOR EACH ROW EXECUTE FUNCTION access_cache_notify('agent', 'agent_id');
+
+CREATE TRIGGER group_access_cache
+AFTER UPDATE OF parent_id ON "group"
+FOR EACH ROW EXECUTE FUNCTION access_cache_notify('all');
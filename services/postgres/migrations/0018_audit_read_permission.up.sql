@@ -0,0 +1,5 @@
+-- Право audit.read на чтение и проверку журнала аудита, входит в роль admin
+INSERT INTO permission(name, description) VALUES ('audit.read', 'read and verify the audit log');
+
+INSERT INTO role_permission(role_id, permission)
+SELECT r.id, 'audit.read' FROM role r WHERE r.name = 'admin';
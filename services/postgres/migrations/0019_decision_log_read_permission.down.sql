@@ -0,0 +1 @@
+DELETE FROM permission WHERE name = 'decision_log.read';
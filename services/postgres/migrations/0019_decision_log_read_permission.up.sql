@@ -0,0 +1,5 @@
+-- Право decision_log.read на чтение и выгрузку журнала решений о доступе, входит в роль admin
+INSERT INTO permission(name, description) VALUES ('decision_log.read', 'read and export the access decision log');
+
+INSERT INTO role_permission(role_id, permission)
+SELECT r.id, 'decision_log.read' FROM role r WHERE r.name = 'admin';
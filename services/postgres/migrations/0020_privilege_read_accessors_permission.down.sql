@@ -0,0 +1 @@
+DELETE FROM permission WHERE name = 'privilege.read_accessors';
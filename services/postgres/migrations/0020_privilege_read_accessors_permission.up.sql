@@ -0,0 +1,5 @@
+-- Право privilege.read_accessors на просмотр пользователей с доступом к агенту, входит в роль admin
+INSERT INTO permission(name, description) VALUES ('privilege.read_accessors', 'list users with access to an agent');
+
+INSERT INTO role_permission(role_id, permission)
+SELECT r.id, 'privilege.read_accessors' FROM role r WHERE r.name = 'admin';
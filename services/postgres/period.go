@@ -0,0 +1,15 @@
+package postgres
+
+import "fmt"
+
+// ValidNow возвращает SQL условие того, что запись таблицы с псевдонимом alias действует в момент запроса.
+// Запись без valid_until бессрочная.
+func ValidNow(alias string) string {
+	return fmt.Sprintf("%[1]s.valid_from <= now() AND (%[1]s.valid_until IS NULL OR %[1]s.valid_until > now())", alias)
+}
+
+// NotExpired возвращает SQL условие того, что срок действия записи еще не истек. В отличие от ValidNow
+// учитывает записи, которые начнут действовать в будущем.
+func NotExpired(alias string) string {
+	return fmt.Sprintf("(%[1]s.valid_until IS NULL OR %[1]s.valid_until > now())", alias)
+}
@@ -5,18 +5,57 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/cantylv/authorization-service/internal/entity/dto"
 	f "github.com/cantylv/authorization-service/internal/utils/functions"
 	me "github.com/cantylv/authorization-service/internal/utils/myerrors"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
 
-// Init инициализирует клиента PostgreSQL.
-func Init(logger *zap.Logger) *pgx.Conn {
+// DB набор методов для работы с базой данных, от которого зависят репозитории. Ему удовлетворяют
+// как пул соединений, так и транзакция.
+type DB interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+var _ DB = (*pgxpool.Pool)(nil)
+
+// Init инициализирует пул соединений PostgreSQL. Пул безопасен для конкурентного использования,
+// сам проверяет соединения и заменяет разорванные новыми. Если 'postgres.migrate_on_start' включен,
+// перед началом работы применяются миграции схемы.
+func Init(logger *zap.Logger) *Client {
+	pool := Connect(logger)
+	if viper.GetBool("postgres.migrate_on_start") {
+		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("postgres.migrate_timeout"))
+		defer cancel()
+		if err := migrateUp(ctx, pool, logger); err != nil {
+			logger.Fatal(fmt.Sprintf("error while applying migrations: %v", err))
+		}
+	}
+
+	client := NewClient(pool)
+	err := createRootUser(client)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("error while creating root user: %v", err))
+	}
+	err = grantRootAdminRole(client)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("error while granting admin role to root user: %v", err))
+	}
+	return client
+}
+
+// Connect открывает пул соединений PostgreSQL и проверяет соединение.
+func Connect(logger *zap.Logger) *pgxpool.Pool {
 	connString := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
 		viper.GetString("postgres.user"),
 		viper.GetString("postgres.password"),
@@ -25,9 +64,13 @@ func Init(logger *zap.Logger) *pgx.Conn {
 		viper.GetString("postgres.database_name"),
 		viper.GetString("postgres.sslmode"),
 	)
+	poolConfig, err := newPoolConfig(connString)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("error while parsing postgresql config: %v", err))
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	conn, err := pgx.Connect(ctx, connString)
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		logger.Fatal(fmt.Sprintf("error while connecting to postgresql: %v", err))
 	}
@@ -36,28 +79,43 @@ func Init(logger *zap.Logger) *pgx.Conn {
 	var successConn bool
 	for i := 0; i < maxPingAttempts; i++ {
 		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-		err := conn.Ping(ctx)
+		err := pool.Ping(ctx)
 		cancel()
 		if err == nil {
 			successConn = true
 			break
 		}
 		logger.Warn(fmt.Sprintf("error while ping to postgresql: %v", err))
+		time.Sleep(time.Duration(i+1) * time.Second)
 	}
 	if !successConn {
 		logger.Fatal("can't establish connection to postgresql")
 	}
 
-	err = createRootUser(conn)
-	if err != nil {
-		logger.Fatal(fmt.Sprintf("error while creating root user: %v", err))
-	}
-
 	logger.Info("postgresql connected successfully")
-	return conn
+	return pool
 }
 
-func isExistRootUser(ctx context.Context, conn *pgx.Conn) (bool, error) {
+// newPoolConfig настраивает пул соединений параметрами 'postgres.pool.*'.
+func newPoolConfig(connString string) (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, err
+	}
+	poolConfig.MaxConns = viper.GetInt32("postgres.pool.max_conns")
+	poolConfig.MinConns = viper.GetInt32("postgres.pool.min_conns")
+	poolConfig.MaxConnLifetime = viper.GetDuration("postgres.pool.max_conn_lifetime")
+	poolConfig.MaxConnIdleTime = viper.GetDuration("postgres.pool.max_conn_idle_time")
+	poolConfig.HealthCheckPeriod = viper.GetDuration("postgres.pool.health_check_period")
+	poolConfig.ConnConfig.ConnectTimeout = viper.GetDuration("postgres.pool.connect_timeout")
+	// запросы, выполняющиеся дольше statement_timeout, прерываются самим PostgreSQL
+	if timeout := viper.GetDuration("postgres.pool.statement_timeout"); timeout > 0 {
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(timeout.Milliseconds(), 10)
+	}
+	return poolConfig, nil
+}
+
+func isExistRootUser(ctx context.Context, conn DB) (bool, error) {
 	row := conn.QueryRow(ctx, `SELECT 1 FROM "user" WHERE email=$1`, viper.GetString("root_email"))
 	var exist int
 	err := row.Scan(&exist)
@@ -70,7 +128,7 @@ func isExistRootUser(ctx context.Context, conn *pgx.Conn) (bool, error) {
 	return true, nil
 }
 
-func createRootUser(conn *pgx.Conn) error {
+func createRootUser(conn DB) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	isExist, err := isExistRootUser(ctx, conn)
@@ -135,7 +193,20 @@ func createRootUser(conn *pgx.Conn) error {
 	return nil
 }
 
-func initUsersGroupAgents(ctx context.Context, conn *pgx.Conn, groupID int) error {
+// grantRootAdminRole назначает root пользователю системную роль администратора. Назначение идемпотентно,
+// поэтому выполняется при каждом старте: так роль получит и root, указанный в конфигурации после смены почты.
+func grantRootAdminRole(conn DB) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := conn.Exec(ctx, `
+		INSERT INTO user_role(user_id, role_id)
+		SELECT u.id, r.id FROM "user" u, role r
+		WHERE u.email = $1 AND r.name = 'admin'
+		ON CONFLICT DO NOTHING`, viper.GetString("root_email"))
+	return err
+}
+
+func initUsersGroupAgents(ctx context.Context, conn DB, groupID int) error {
 	row := conn.QueryRow(ctx, `SELECT id FROM agent WHERE name='privelege'`)
 	var agentID int
 	err := row.Scan(&agentID)
@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/spf13/viper"
+)
+
+// IsoLevel уровень изоляции транзакции.
+type IsoLevel = pgx.TxIsoLevel
+
+const (
+	ReadCommitted  = pgx.ReadCommitted
+	RepeatableRead = pgx.RepeatableRead
+	Serializable   = pgx.Serializable
+)
+
+// UnitOfWork позволяет выполнить несколько вызовов репозиториев атомарно.
+type UnitOfWork interface {
+	Do(ctx context.Context, isoLevel IsoLevel, fn func(ctx context.Context) error) error
+}
+
+var (
+	_ DB         = (*Client)(nil)
+	_ UnitOfWork = (*Client)(nil)
+)
+
+// Client клиент PostgreSQL поверх пула соединений. Если в контексте запроса есть транзакция, открытая
+// методом Do, запросы выполняются в ней, поэтому репозитории не знают о транзакциях usecase уровня.
+// Транзакции, которые репозитории открывают сами через Begin, в этом случае становятся точками сохранения.
+type Client struct {
+	pool *pgxpool.Pool
+}
+
+// NewClient возвращает клиента PostgreSQL поверх пула соединений.
+func NewClient(pool *pgxpool.Pool) *Client {
+	return &Client{
+		pool: pool,
+	}
+}
+
+type txKey struct{}
+
+func (c *Client) executor(ctx context.Context) DB {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return c.pool
+}
+
+func (c *Client) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	return c.executor(ctx).Exec(ctx, sql, arguments...)
+}
+
+func (c *Client) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return c.executor(ctx).Query(ctx, sql, args...)
+}
+
+func (c *Client) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return c.executor(ctx).QueryRow(ctx, sql, args...)
+}
+
+func (c *Client) Begin(ctx context.Context) (pgx.Tx, error) {
+	return c.executor(ctx).Begin(ctx)
+}
+
+// Ping проверяет соединение с базой данных.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.pool.Ping(ctx)
+}
+
+// Close закрывает все соединения пула.
+func (c *Client) Close() {
+	c.pool.Close()
+}
+
+// Do выполняет fn в транзакции с заданным уровнем изоляции. Если fn вернула ошибку, транзакция откатывается.
+// При конфликте сериализации или взаимоблокировке транзакция повторяется целиком, не более
+// 'postgres.tx.max_attempts' раз. Вложенный вызов Do выполняется в уже открытой транзакции.
+func (c *Client) Do(ctx context.Context, isoLevel IsoLevel, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+	maxAttempts := viper.GetInt("postgres.tx.max_attempts")
+	backoff := viper.GetDuration("postgres.tx.retry_backoff")
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = c.do(ctx, isoLevel, fn)
+		if err == nil || !isRetryable(err) || attempt >= maxAttempts {
+			return err
+		}
+		// небольшая случайная задержка, чтобы конкурирующие транзакции не столкнулись снова
+		delay := time.Duration(attempt)*backoff + rand.N(backoff+1)
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (c *Client) do(ctx context.Context, isoLevel IsoLevel, fn func(ctx context.Context) error) (err error) {
+	tx, err := c.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: isoLevel})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// isRetryable сообщает, можно ли повторить транзакцию: serialization_failure (40001) или deadlock_detected (40P01).
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == "40001" || pgErr.Code == "40P01"
+}
@@ -0,0 +1,68 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+)
+
+var _ Sink = (*WriterSink)(nil)
+
+// WriterSink записывает каждое событие строкой JSON (формат jsonl). Подходит для отладки и тестов
+// подписчиков: события можно читать из stdout сервиса или из файла.
+type WriterSink struct {
+	name   string
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewWriterSink возвращает получателя, записывающего события в w.
+func NewWriterSink(name string, w io.Writer) *WriterSink {
+	return &WriterSink{
+		name: name,
+		w:    w,
+	}
+}
+
+// NewFileSink возвращает получателя, дописывающего события в файл path. Значение stdout означает
+// стандартный вывод сервиса.
+func NewFileSink(path string) (*WriterSink, error) {
+	if path == "stdout" {
+		return NewWriterSink("stdout", os.Stdout), nil
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	s := NewWriterSink("file "+path, file)
+	s.closer = file
+	return s, nil
+}
+
+func (s *WriterSink) Name() string {
+	return s.name
+}
+
+func (s *WriterSink) Send(_ context.Context, event *ent.ChangeEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
+
+// Close закрывает файл получателя, стандартный вывод не закрывается.
+func (s *WriterSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
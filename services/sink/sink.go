@@ -0,0 +1,38 @@
+package sink
+
+import (
+	"context"
+	"strings"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+	"github.com/spf13/viper"
+)
+
+// Sink получатель событий изменений из outbox. Send должен вернуть ошибку, если событие не доставлено,
+// тогда диспетчер повторит доставку позже. Одно событие может быть доставлено несколько раз.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, event *ent.ChangeEvent) error
+}
+
+// FromConfig возвращает получателей, настроенных параметрами 'outbox.*': вебхук на каждый адрес из
+// outbox.webhook.urls и файл outbox.file, если он задан. Возвращаемая функция закрывает открытые файлы.
+func FromConfig() ([]Sink, func() error, error) {
+	var sinks []Sink
+	for _, url := range viper.GetStringSlice("outbox.webhook.urls") {
+		if url = strings.TrimSpace(url); url == "" {
+			continue
+		}
+		sinks = append(sinks, NewWebhookSink(url, viper.GetString("outbox.webhook.secret"), viper.GetDuration("outbox.webhook.timeout")))
+	}
+	closeSinks := func() error { return nil }
+	if path := viper.GetString("outbox.file"); path != "" {
+		fileSink, err := NewFileSink(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		sinks = append(sinks, fileSink)
+		closeSinks = fileSink.Close
+	}
+	return sinks, closeSinks, nil
+}
@@ -0,0 +1,89 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	ent "github.com/cantylv/authorization-service/internal/entity"
+)
+
+// Заголовки запроса вебхука
+const (
+	HeaderEventID   = "X-Event-ID"
+	HeaderEventType = "X-Event-Type"
+	HeaderTimestamp = "X-Signature-Timestamp"
+	HeaderSignature = "X-Signature"
+)
+
+var _ Sink = (*WebhookSink)(nil)
+
+// WebhookSink отправляет событие POST запросом с телом в формате JSON. Запрос подписывается HMAC-SHA256
+// от строки "<timestamp>.<тело запроса>" с секретом outbox.webhook.secret, подпись передается в заголовке
+// X-Signature в виде "sha256=<hex>", а время подписи в секундах Unix в заголовке X-Signature-Timestamp.
+// Получатель должен проверить подпись и отбрасывать старые запросы и повторы по X-Event-ID.
+type WebhookSink struct {
+	url    string
+	secret []byte
+	client *http.Client
+}
+
+// NewWebhookSink возвращает получателя, отправляющего события на адрес url.
+func NewWebhookSink(url, secret string, timeout time.Duration) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		secret: []byte(secret),
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhook " + s.url
+}
+
+// Send доставляет событие, успешным считается только ответ со статусом 2xx.
+func (s *WebhookSink) Send(ctx context.Context, event *ent.ChangeEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, strconv.FormatInt(event.ID, 10))
+	req.Header.Set(HeaderEventType, event.Event)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(s.secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// тело ответа дочитывается, чтобы соединение вернулось в пул
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded with status %d", s.url, resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign возвращает подпись HMAC-SHA256 тела запроса вебхука в шестнадцатеричном виде.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}